@@ -17,6 +17,8 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/data/i18n"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
 	"github.com/boilerplate/ebiten-template/internal/engine/event"
+	"github.com/boilerplate/ebiten-template/internal/engine/replay"
+	"github.com/boilerplate/ebiten-template/internal/engine/save"
 	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
 )
 
@@ -33,6 +35,8 @@ type AppContext struct {
 	ActorManager      *actors.Manager
 	SceneManager      navigation.SceneManager
 	PhaseManager      *phases.Manager
+	SaveManager       *save.Manager
+	Replay            *replay.Controller // nil unless recording or replaying input
 	I18n              *i18n.I18nManager
 	Assets            fs.FS
 	Config            *config.AppConfig
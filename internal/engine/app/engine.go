@@ -130,6 +130,12 @@ func (g *Game) Update() error {
 		cfg.FastForward = !cfg.FastForward
 	}
 
+	// A replay controller brackets the simulation step so input is sampled
+	// once per tick and actor state is hashed after it.
+	if g.AppContext.Replay != nil {
+		g.AppContext.Replay.BeginFrame()
+	}
+
 	// Update Dialogue Manager
 	if g.AppContext.DialogueManager != nil {
 		g.AppContext.DialogueManager.Update()
@@ -137,6 +143,17 @@ func (g *Game) Update() error {
 
 	// Then, update the current scene
 	g.AppContext.SceneManager.Update()
+	// Deferred events fire after the whole tick has simulated.
+	if g.AppContext.EventManager != nil {
+		g.AppContext.EventManager.Flush()
+	}
+	// Ducking follows whatever started or stopped playing this tick.
+	if g.AppContext.AudioManager != nil {
+		g.AppContext.AudioManager.Update()
+	}
+	if g.AppContext.Replay != nil {
+		g.AppContext.Replay.EndFrame(g.AppContext.ActorManager)
+	}
 	return nil
 }
 
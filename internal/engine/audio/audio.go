@@ -6,8 +6,10 @@ import (
 	"io"
 	"io/fs"
 	"log"
+	"maps"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
@@ -24,8 +26,11 @@ const (
 // Manager defines the interface for audio management.
 type Manager interface {
 	PlayMusic(name string, loop bool)
+	CrossfadeMusic(name string, loop bool, duration time.Duration)
+	PlayPlaylist(list Playlist)
 	PlaySound(name string)
 	PlaySoundAtVolume(name string, volume float64)
+	PlaySoundOnBus(bus Bus, name string, volume float64)
 	IsPlaying(name string) bool
 	IsPaused(name string) bool
 	SetVolume(volume float64)
@@ -36,6 +41,9 @@ type Manager interface {
 	FadeOutCurrentTrack(duration time.Duration)
 	Stop(path string)
 	StopAll()
+	Mixer() *Mixer
+	// Update advances per-tick mixing such as ducking. Call it once per tick.
+	Update()
 }
 
 type AudioItem struct {
@@ -53,27 +61,175 @@ func (a *AudioItem) Data() []byte {
 type AudioManager struct {
 	audioContext *audio.Context
 	audioPlayers map[string]*audio.Player
-	volume       float64
 	noSound      bool
-	fadeCancel   map[string]context.CancelFunc
-	paused       map[string]bool
+
+	// fadeMu guards fadeCancel and paused, which fade and loop goroutines
+	// read and clear. Everything else is only touched by the game loop.
+	fadeMu     sync.Mutex
+	fadeCancel map[string]context.CancelFunc
+	paused     map[string]bool
+
 	currentTrack string
+
+	mixer *Mixer
+	// routes maps each player to the bus it last played on; levels holds the
+	// per-call volume it was played at, before bus gains.
+	routes          map[string]Bus
+	levels          map[string]float64
+	appliedRevision int
+
+	// looped marks tracks whose stream loops itself between LoopPoints;
+	// lengths holds each track's decoded play time.
+	looped   map[string]bool
+	lengths  map[string]time.Duration
+	xfade    *crossfade
+	playlist *playlistState
 }
 
 func NewAudioManager() *AudioManager {
-	initialVolume := 1.0
 	noSound := config.Get().NoSound
+	mixer := NewMixer()
 	if noSound {
-		initialVolume = 0.0
+		mixer.SetVolume(BusMaster, 0)
 	}
 	return &AudioManager{
 		audioContext: audio.NewContext(sampleRate),
 		audioPlayers: make(map[string]*audio.Player),
-		volume:       initialVolume,
 		noSound:      noSound,
 		fadeCancel:   make(map[string]context.CancelFunc),
 		paused:       make(map[string]bool),
+		mixer:        mixer,
+		routes:       make(map[string]Bus),
+		levels:       make(map[string]float64),
+		looped:       make(map[string]bool),
+		lengths:      make(map[string]time.Duration),
+	}
+}
+
+// Mixer returns the bus mixer. Changing its volumes takes effect on the next
+// Update.
+func (am *AudioManager) Mixer() *Mixer {
+	return am.mixer
+}
+
+// route records that name plays on bus at level and returns the resulting
+// player volume.
+func (am *AudioManager) route(name string, bus Bus, level float64) float64 {
+	am.routes[name] = bus
+	am.levels[name] = level
+	return am.outputVolume(name)
+}
+
+// outputVolume is the volume name should play at: its per-call level times
+// its bus gain. Players never routed play on the sfx bus at full level.
+func (am *AudioManager) outputVolume(name string) float64 {
+	bus, ok := am.routes[name]
+	if !ok {
+		bus = BusSFX
+	}
+	level, ok := am.levels[name]
+	if !ok {
+		level = 1
+	}
+	return level * am.mixer.Gain(bus)
+}
+
+// startFade cancels any fade on key and registers a new one, returning its
+// context. key is a track name, or "_all" for FadeOutAll.
+func (am *AudioManager) startFade(key string) context.Context {
+	am.fadeMu.Lock()
+	defer am.fadeMu.Unlock()
+	if cancel, ok := am.fadeCancel[key]; ok {
+		cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	am.fadeCancel[key] = cancel
+	return ctx
+}
+
+// cancelFade stops the fade on key, if any.
+func (am *AudioManager) cancelFade(key string) {
+	am.fadeMu.Lock()
+	defer am.fadeMu.Unlock()
+	if cancel, ok := am.fadeCancel[key]; ok {
+		cancel()
+		delete(am.fadeCancel, key)
+	}
+}
+
+// endFade unregisters the fade on key that ran with ctx, unless it was
+// cancelled and replaced in the meantime.
+func (am *AudioManager) endFade(key string, ctx context.Context) {
+	am.fadeMu.Lock()
+	defer am.fadeMu.Unlock()
+	if ctx.Err() == nil {
+		delete(am.fadeCancel, key)
+	}
+}
+
+// hasFade reports whether a fade on key is registered.
+func (am *AudioManager) hasFade(key string) bool {
+	am.fadeMu.Lock()
+	defer am.fadeMu.Unlock()
+	_, ok := am.fadeCancel[key]
+	return ok
+}
+
+func (am *AudioManager) setPaused(name string, paused bool) {
+	am.fadeMu.Lock()
+	defer am.fadeMu.Unlock()
+	if paused {
+		am.paused[name] = true
+	} else {
+		delete(am.paused, name)
+	}
+}
+
+func (am *AudioManager) isPaused(name string) bool {
+	am.fadeMu.Lock()
+	defer am.fadeMu.Unlock()
+	return am.paused[name]
+}
+
+// Update advances ducking, crossfades and playlists. When any bus gain
+// changed it re-applies volumes to every player that is not mid-fade.
+func (am *AudioManager) Update() {
+	if am.noSound {
+		return
+	}
+	am.mixer.Update(am.busActive)
+	am.applyGains()
+	am.stepCrossfade()
+	am.stepPlaylist()
+}
+
+func (am *AudioManager) applyGains() {
+	if am.mixer.revision == am.appliedRevision {
+		return
+	}
+	am.appliedRevision = am.mixer.revision
+	if am.hasFade("_all") {
+		return
+	}
+	for name, player := range am.audioPlayers {
+		if am.hasFade(name) {
+			continue
+		}
+		player.SetVolume(am.outputVolume(name))
+	}
+}
+
+// busActive reports whether any player routed to bus is playing.
+func (am *AudioManager) busActive(bus Bus) bool {
+	for name, b := range am.routes {
+		if b != bus {
+			continue
+		}
+		if p, ok := am.audioPlayers[name]; ok && p.IsPlaying() {
+			return true
+		}
 	}
+	return false
 }
 
 func (am *AudioManager) Load(path string) (*AudioItem, error) {
@@ -116,8 +272,22 @@ func (am *AudioManager) LoadFromFS(fs fs.FS, path string) (*AudioItem, error) {
 	return &AudioItem{path, bs}, nil
 }
 
+// Add decodes data and registers it as a player under name.
 func (am *AudioManager) Add(name string, data []byte) {
-	var s io.ReadSeeker
+	am.add(name, data, nil)
+}
+
+// AddLooped registers an intro-then-loop track: after its intro it repeats
+// the loop section seamlessly, whatever loop flag PlayMusic is given.
+func (am *AudioManager) AddLooped(name string, data []byte, loop LoopPoints) {
+	am.add(name, data, &loop)
+}
+
+func (am *AudioManager) add(name string, data []byte, loop *LoopPoints) {
+	var s interface {
+		io.ReadSeeker
+		Length() int64
+	}
 	var err error
 
 	switch {
@@ -144,27 +314,41 @@ func (am *AudioManager) Add(name string, data []byte) {
 		return
 	}
 
-	p, err := am.audioContext.NewPlayer(s)
+	var src io.ReadSeeker = s
+	if loop != nil {
+		src, err = loopStream(s, s.Length(), *loop)
+		if err != nil {
+			log.Printf("failed to loop %s: %v", name, err)
+			return
+		}
+	}
+
+	p, err := am.audioContext.NewPlayer(src)
 	if err != nil {
 		log.Printf("failed to create audio player: %v", err)
 		return
 	}
 	am.audioPlayers[name] = p
+	am.lengths[name] = frameDuration(s.Length())
+	am.looped[name] = loop != nil
 }
 
+// PlayMusic plays name on the music bus, replacing any playlist or
+// crossfade in progress. loop restarts the track when it ends (ADR-003);
+// tracks added with AddLooped loop on their own.
 func (am *AudioManager) PlayMusic(name string, loop bool) {
+	am.playlist = nil
+	am.finishCrossfade()
+	am.playMusic(name, loop)
+}
+
+func (am *AudioManager) playMusic(name string, loop bool) {
 	if am.noSound {
 		return
 	}
 
-	if cancel, ok := am.fadeCancel[name]; ok {
-		cancel()
-		delete(am.fadeCancel, name)
-	}
-	if cancel, ok := am.fadeCancel["_all"]; ok {
-		cancel()
-		delete(am.fadeCancel, "_all")
-	}
+	am.cancelFade(name)
+	am.cancelFade("_all")
 
 	player, ok := am.audioPlayers[name]
 	if !ok {
@@ -172,30 +356,27 @@ func (am *AudioManager) PlayMusic(name string, loop bool) {
 		return
 	}
 
-	delete(am.paused, name)
+	am.setPaused(name, false)
 	am.currentTrack = name
 
-	player.SetVolume(am.volume)
+	player.SetVolume(am.route(name, BusMusic, 1))
 	player.Rewind()
 	player.Play()
 
-	if loop {
+	if loop && !am.looped[name] {
 		go func() {
 			for {
 				for player.IsPlaying() {
 					time.Sleep(100 * time.Millisecond)
-					if _, exists := am.fadeCancel[name]; exists {
-						return
-					}
-					if _, exists := am.fadeCancel["_all"]; exists {
+					if am.hasFade(name) || am.hasFade("_all") {
 						return
 					}
 				}
-				if am.paused[name] {
-					for am.paused[name] {
+				if am.isPaused(name) {
+					for am.isPaused(name) {
 						time.Sleep(100 * time.Millisecond)
-						if _, exists := am.fadeCancel[name]; exists {
-							delete(am.paused, name)
+						if am.hasFade(name) {
+							am.setPaused(name, false)
 							return
 						}
 					}
@@ -210,12 +391,13 @@ func (am *AudioManager) PlayMusic(name string, loop bool) {
 }
 
 func (am *AudioManager) PauseMusic(name string) {
+	am.finishCrossfade()
 	player, ok := am.audioPlayers[name]
 	if !ok {
 		log.Printf("audio player not found: %s", name)
 		return
 	}
-	am.paused[name] = true
+	am.setPaused(name, true)
 	player.Pause()
 }
 
@@ -228,8 +410,8 @@ func (am *AudioManager) ResumeMusic(name string) {
 		log.Printf("audio player not found: %s", name)
 		return
 	}
-	delete(am.paused, name)
-	player.SetVolume(am.volume)
+	am.setPaused(name, false)
+	player.SetVolume(am.outputVolume(name))
 	player.Play()
 }
 
@@ -257,23 +439,21 @@ func (am *AudioManager) FadeOutCurrentTrack(duration time.Duration) {
 	am.FadeOut(am.currentTrack, duration)
 }
 
+// PlaySound plays a sound effect on the sfx bus.
 func (am *AudioManager) PlaySound(name string) {
-	if am.noSound {
-		return
-	}
-	player, ok := am.audioPlayers[name]
-	if !ok {
-		log.Printf("audio player not found: %s", name)
-		return
-	}
-	player.SetVolume(am.volume)
-	// Always rewind to ensure sound can replay from the beginning
-	player.Rewind()
-	player.Play()
+	am.PlaySoundOnBus(BusSFX, name, 1)
 }
 
-// PlaySoundAtVolume plays a sound effect at a specific volume (0.0 to 1.0).
+// PlaySoundAtVolume plays a sound effect on the sfx bus at a specific volume
+// (0.0 to 1.0), scaled by the bus gains.
 func (am *AudioManager) PlaySoundAtVolume(name string, volume float64) {
+	am.PlaySoundOnBus(BusSFX, name, volume)
+}
+
+// PlaySoundOnBus plays a sound routed to bus at volume (0.0 to 1.0), scaled
+// by the bus gains. Speech audio uses BusVoice; typing blips and menus use
+// BusUI, so they do not duck the music.
+func (am *AudioManager) PlaySoundOnBus(bus Bus, name string, volume float64) {
 	if am.noSound {
 		return
 	}
@@ -282,27 +462,32 @@ func (am *AudioManager) PlaySoundAtVolume(name string, volume float64) {
 		log.Printf("audio player not found: %s", name)
 		return
 	}
-	player.SetVolume(volume)
+	player.SetVolume(am.route(name, bus, volume))
 	// Always rewind to ensure sound can replay from the beginning
 	player.Rewind()
 	player.Play()
 }
 
+// SetVolume sets the master bus volume.
 func (am *AudioManager) SetVolume(volume float64) {
 	if am.noSound {
 		return
 	}
-	am.volume = volume
-	for _, player := range am.audioPlayers {
-		player.SetVolume(am.volume)
+	am.mixer.SetVolume(BusMaster, volume)
+	for name, player := range am.audioPlayers {
+		player.SetVolume(am.outputVolume(name))
 	}
+	am.appliedRevision = am.mixer.revision
 }
 
+// Volume returns the master bus volume.
 func (am *AudioManager) Volume() float64 {
-	return am.volume
+	return am.mixer.Volume(BusMaster)
 }
 
 func (am *AudioManager) PauseAll() {
+	am.playlist = nil
+	am.finishCrossfade()
 	for _, p := range am.audioPlayers {
 		p.Pause()
 	}
@@ -312,17 +497,22 @@ func (am *AudioManager) FadeOutAll(duration time.Duration) {
 	if am.noSound {
 		return
 	}
-	currentVolume := am.volume
-	if currentVolume == 0 {
+	am.playlist = nil
+	am.finishCrossfade()
+	if am.mixer.Gain(BusMaster) == 0 {
 		return
 	}
-
-	if am.fadeCancel["_all"] != nil {
-		am.fadeCancel["_all"]()
+	// The goroutine only sees these copies: the game loop keeps changing
+	// the players, routes and gains while it runs.
+	players := maps.Clone(am.audioPlayers)
+	start := make(map[string]float64, len(players))
+	restore := make(map[string]float64, len(players))
+	for name, p := range players {
+		start[name] = p.Volume()
+		restore[name] = am.outputVolume(name)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	am.fadeCancel["_all"] = cancel
+	ctx := am.startFade("_all")
 
 	go func() {
 		ticker := time.NewTicker(100 * time.Millisecond)
@@ -337,22 +527,18 @@ func (am *AudioManager) FadeOutAll(duration time.Duration) {
 			case <-ticker.C:
 				elapsed := time.Since(startTime)
 				if elapsed >= duration {
-					for _, p := range am.audioPlayers {
-						p.SetVolume(currentVolume)
+					for name, p := range players {
+						p.SetVolume(restore[name])
 						p.Rewind()
 						p.Pause()
 					}
-					delete(am.fadeCancel, "_all")
+					am.endFade("_all", ctx)
 					return
 				}
 
 				progress := float64(elapsed) / float64(duration)
-				newVolume := currentVolume * (1 - progress)
-				if newVolume < 0 {
-					newVolume = 0
-				}
-				for _, p := range am.audioPlayers {
-					p.SetVolume(newVolume)
+				for name, p := range players {
+					p.SetVolume(max(start[name]*(1-progress), 0))
 				}
 			}
 		}
@@ -363,6 +549,10 @@ func (am *AudioManager) FadeOut(name string, duration time.Duration) {
 	if am.noSound {
 		return
 	}
+	if name == am.currentTrack {
+		am.playlist = nil
+	}
+	am.finishCrossfade()
 	player, ok := am.audioPlayers[name]
 	if !ok {
 		log.Printf("audio player not found: %s", name)
@@ -374,17 +564,10 @@ func (am *AudioManager) FadeOut(name string, duration time.Duration) {
 		return
 	}
 
-	if cancel, ok := am.fadeCancel[name]; ok {
-		cancel()
-		delete(am.fadeCancel, name)
-	}
-	if cancel, ok := am.fadeCancel["_all"]; ok {
-		cancel()
-		delete(am.fadeCancel, "_all")
-	}
-
-	ctx, cancel := context.WithCancel(context.Background())
-	am.fadeCancel[name] = cancel
+	am.cancelFade("_all")
+	ctx := am.startFade(name)
+	// Computed here: the goroutine must not read the routes or gains.
+	restore := am.outputVolume(name)
 
 	go func() {
 		ticker := time.NewTicker(100 * time.Millisecond)
@@ -399,10 +582,10 @@ func (am *AudioManager) FadeOut(name string, duration time.Duration) {
 			case <-ticker.C:
 				elapsed := time.Since(startTime)
 				if elapsed >= duration {
-					player.SetVolume(am.volume)
+					player.SetVolume(restore)
 					player.Rewind()
 					player.Pause()
-					delete(am.fadeCancel, name)
+					am.endFade(name, ctx)
 					return
 				}
 
@@ -418,6 +601,12 @@ func (am *AudioManager) FadeOut(name string, duration time.Duration) {
 }
 
 func (am *AudioManager) Stop(name string) {
+	if name == am.currentTrack {
+		am.playlist = nil
+	}
+	if am.xfade != nil && (name == am.xfade.from || name == am.xfade.to) {
+		am.finishCrossfade()
+	}
 	player, ok := am.audioPlayers[name]
 	if !ok {
 		return
@@ -427,6 +616,8 @@ func (am *AudioManager) Stop(name string) {
 }
 
 func (am *AudioManager) StopAll() {
+	am.playlist = nil
+	am.finishCrossfade()
 	for _, p := range am.audioPlayers {
 		p.Pause()
 		p.Rewind()
@@ -451,5 +642,5 @@ func (am *AudioManager) IsPlaying(name string) bool {
 }
 
 func (am *AudioManager) IsPaused(name string) bool {
-	return am.paused[name]
+	return am.isPaused(name)
 }
@@ -241,3 +241,24 @@ func TestAudioManagerCurrentTrack(t *testing.T) {
 		t.Error("expected test_track.wav to not be paused after ResumeCurrentMusic")
 	}
 }
+
+// TestAudioManagerPlayDuringFade plays sounds and updates the mixer while
+// fades run. Run it with -race: the fade goroutines must not share the
+// manager's routing maps with the game loop.
+func TestAudioManagerPlayDuringFade(t *testing.T) {
+	am := getTestAudioManager()
+	am.Add("test_fade_music.wav", createMinimalWAV())
+	am.Add("test_fade_sfx.wav", createMinimalWAV())
+
+	am.PlayMusic("test_fade_music.wav", true)
+	am.FadeOut("test_fade_music.wav", 150*time.Millisecond)
+	am.FadeOutAll(150 * time.Millisecond)
+
+	deadline := time.Now().Add(300 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		am.PlaySoundOnBus(BusUI, "test_fade_sfx.wav", 0.5)
+		am.Mixer().SetVolume(BusSFX, 0.8)
+		am.Update()
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,315 @@
+package audio
+
+import "encoding/json"
+
+// Bus names a mixer channel. Every player is routed to exactly one bus, and
+// its output level is the product of its own volume, its bus gain and the
+// master gain.
+type Bus string
+
+const (
+	BusMaster Bus = "master"
+	BusMusic  Bus = "music"
+	BusSFX    Bus = "sfx"
+	BusVoice  Bus = "voice"
+	BusUI     Bus = "ui"
+)
+
+// Buses lists the built-in buses in display order. Options menus can iterate
+// it to present a volume control per bus.
+//
+//nolint:gochecknoglobals
+var Buses = []Bus{BusMaster, BusMusic, BusSFX, BusVoice, BusUI}
+
+// BusSettings is the persisted, player-controlled state of one bus.
+type BusSettings struct {
+	Volume float64 `json:"volume"`
+	Muted  bool    `json:"muted,omitempty"`
+}
+
+// DuckRule lowers Target to Level (a gain multiplier) while anything plays on
+// Trigger, e.g. music dipping under voice lines. The dip ramps in and out
+// over Frames ticks; 0 switches instantly.
+type DuckRule struct {
+	Trigger Bus
+	Target  Bus
+	Level   float64
+	Frames  int
+}
+
+// Mixer holds bus volumes, mutes and ducking state. It has no audio
+// dependencies so its maths can be tested headless; AudioManager applies the
+// resulting gains to players.
+type Mixer struct {
+	settings map[Bus]BusSettings
+	rules    []DuckRule
+	// duck is the current ducking multiplier per target bus (1 = no dip).
+	duck map[Bus]float64
+	// scripted holds volume and mute overrides set by sequences. They scale
+	// the gain on top of the player's settings but are never persisted.
+	scripted map[Bus]BusSettings
+	onChange func()
+	// revision increments on every gain change so AudioManager knows when to
+	// re-apply volumes.
+	revision int
+}
+
+// NewMixer returns a mixer with every built-in bus at full volume and the
+// default rule ducking music to 40% while voice audio plays.
+func NewMixer() *Mixer {
+	m := &Mixer{
+		settings: make(map[Bus]BusSettings),
+		duck:     make(map[Bus]float64),
+		scripted: make(map[Bus]BusSettings),
+	}
+	for _, b := range Buses {
+		m.settings[b] = BusSettings{Volume: 1}
+	}
+	m.AddDuckRule(DuckRule{Trigger: BusVoice, Target: BusMusic, Level: 0.4, Frames: 10})
+	return m
+}
+
+// Volume returns the volume of bus, in [0, 1]. Unknown buses report 1.
+func (m *Mixer) Volume(bus Bus) float64 {
+	if s, ok := m.settings[bus]; ok {
+		return s.Volume
+	}
+	return 1
+}
+
+// SetVolume sets the volume of bus, clamped to [0, 1].
+func (m *Mixer) SetVolume(bus Bus, volume float64) {
+	s := m.bus(bus)
+	s.Volume = clamp01(volume)
+	m.set(bus, s)
+}
+
+// Muted reports whether bus is muted.
+func (m *Mixer) Muted(bus Bus) bool {
+	return m.settings[bus].Muted
+}
+
+// SetMuted mutes or unmutes bus without losing its volume.
+func (m *Mixer) SetMuted(bus Bus, muted bool) {
+	s := m.bus(bus)
+	s.Muted = muted
+	m.set(bus, s)
+}
+
+// SetScriptedVolume scales bus by volume, clamped to [0, 1], on top of the
+// player's setting. Scripted levels do not fire the change callback and are
+// not persisted, so a cutscene can quiet a bus without touching the options.
+func (m *Mixer) SetScriptedVolume(bus Bus, volume float64) {
+	s := m.scriptedBus(bus)
+	s.Volume = clamp01(volume)
+	m.setScripted(bus, s)
+}
+
+// SetScriptedMuted mutes or unmutes bus on top of the player's setting,
+// without persisting it.
+func (m *Mixer) SetScriptedMuted(bus Bus, muted bool) {
+	s := m.scriptedBus(bus)
+	s.Muted = muted
+	m.setScripted(bus, s)
+}
+
+// ClearScripted drops every scripted volume and mute, leaving each bus at the
+// player's setting.
+func (m *Mixer) ClearScripted() {
+	if len(m.scripted) == 0 {
+		return
+	}
+	m.scripted = make(map[Bus]BusSettings)
+	m.revision++
+}
+
+// AddDuckRule registers a ducking rule.
+func (m *Mixer) AddDuckRule(rule DuckRule) {
+	m.rules = append(m.rules, rule)
+}
+
+// ClearDuckRules removes every ducking rule and any active dip.
+func (m *Mixer) ClearDuckRules() {
+	m.rules = nil
+	m.duck = make(map[Bus]float64)
+	m.revision++
+}
+
+// Gain returns the effective output multiplier of bus: master and bus volume,
+// zero when either is muted, times any scripted level and the bus's current
+// ducking dip.
+func (m *Mixer) Gain(bus Bus) float64 {
+	master := m.settings[BusMaster]
+	if bus == BusMaster {
+		if master.Muted {
+			return 0
+		}
+		return m.Volume(BusMaster) * m.scriptedGain(BusMaster)
+	}
+	s := m.bus(bus)
+	if master.Muted || s.Muted {
+		return 0
+	}
+	gain := m.Volume(BusMaster) * m.scriptedGain(BusMaster) * s.Volume * m.scriptedGain(bus)
+	if d, ok := m.duck[bus]; ok {
+		gain *= d
+	}
+	return gain
+}
+
+// Update advances ducking by one tick. active reports whether anything is
+// playing on a bus.
+func (m *Mixer) Update(active func(Bus) bool) {
+	targets := make(map[Bus]float64)
+	steps := make(map[Bus]float64)
+	for _, r := range m.rules {
+		level := 1.0
+		if active(r.Trigger) {
+			level = clamp01(r.Level)
+		}
+		if cur, ok := targets[r.Target]; !ok || level < cur {
+			targets[r.Target] = level
+		}
+		step := 1.0
+		if r.Frames > 0 {
+			step = (1 - clamp01(r.Level)) / float64(r.Frames)
+		}
+		if cur, ok := steps[r.Target]; !ok || step > cur {
+			steps[r.Target] = step
+		}
+	}
+	for bus, target := range targets {
+		cur, ok := m.duck[bus]
+		if !ok {
+			cur = 1
+		}
+		next := cur
+		switch {
+		case cur > target:
+			next = max(cur-steps[bus], target)
+		case cur < target:
+			next = min(cur+steps[bus], target)
+		}
+		if next == cur {
+			continue
+		}
+		if next == 1 {
+			delete(m.duck, bus)
+		} else {
+			m.duck[bus] = next
+		}
+		m.revision++
+	}
+}
+
+// Settings returns a copy of every bus's settings.
+func (m *Mixer) Settings() map[Bus]BusSettings {
+	out := make(map[Bus]BusSettings, len(m.settings))
+	for b, s := range m.settings {
+		out[b] = s
+	}
+	return out
+}
+
+// ApplySettings replaces the settings of every bus present in settings.
+// Buses missing from it keep their current values, so older settings files
+// stay valid when buses are added.
+func (m *Mixer) ApplySettings(settings map[Bus]BusSettings) {
+	for b, s := range settings {
+		s.Volume = clamp01(s.Volume)
+		m.settings[b] = s
+	}
+	m.changed()
+}
+
+// SetOnChange registers fn to be called after any volume or mute change,
+// e.g. to persist the settings.
+func (m *Mixer) SetOnChange(fn func()) {
+	m.onChange = fn
+}
+
+// MarshalJSON encodes the bus settings as {"music":{"volume":0.8},...}.
+// Ducking rules are code-defined and not persisted.
+func (m *Mixer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.settings)
+}
+
+// UnmarshalJSON decodes settings written by MarshalJSON and applies them.
+func (m *Mixer) UnmarshalJSON(data []byte) error {
+	var settings map[Bus]BusSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+	if m.settings == nil {
+		m.settings = make(map[Bus]BusSettings)
+		m.duck = make(map[Bus]float64)
+		m.scripted = make(map[Bus]BusSettings)
+	}
+	m.ApplySettings(settings)
+	return nil
+}
+
+func (m *Mixer) bus(bus Bus) BusSettings {
+	if s, ok := m.settings[bus]; ok {
+		return s
+	}
+	return BusSettings{Volume: 1}
+}
+
+func (m *Mixer) set(bus Bus, s BusSettings) {
+	if m.settings[bus] == s {
+		return
+	}
+	m.settings[bus] = s
+	m.changed()
+}
+
+func (m *Mixer) scriptedBus(bus Bus) BusSettings {
+	if s, ok := m.scripted[bus]; ok {
+		return s
+	}
+	return BusSettings{Volume: 1}
+}
+
+func (m *Mixer) setScripted(bus Bus, s BusSettings) {
+	if m.scriptedBus(bus) == s {
+		return
+	}
+	if m.scripted == nil {
+		m.scripted = make(map[Bus]BusSettings)
+	}
+	m.scripted[bus] = s
+	m.revision++
+}
+
+// scriptedGain returns the scripted multiplier of bus: 0 when muted.
+func (m *Mixer) scriptedGain(bus Bus) float64 {
+	s := m.scriptedBus(bus)
+	if s.Muted {
+		return 0
+	}
+	return s.Volume
+}
+
+func (m *Mixer) changed() {
+	m.revision++
+	if m.onChange != nil {
+		m.onChange()
+	}
+}
+
+func clamp01(v float64) float64 {
+	return min(max(v, 0), 1)
+}
+
+// CycleVolume raises bus by step, wrapping to silent once it passes full
+// volume, and returns the new volume. Options menus use it to offer a volume
+// control on a single button.
+func (m *Mixer) CycleVolume(bus Bus, step float64) float64 {
+	v := m.Volume(bus) + step
+	if v > 1+1e-9 {
+		v = 0
+	}
+	m.SetVolume(bus, v)
+	return m.Volume(bus)
+}
@@ -0,0 +1,159 @@
+package audio
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMixerGainCombinesMasterAndBus(t *testing.T) {
+	m := NewMixer()
+	m.SetVolume(BusMaster, 0.5)
+	m.SetVolume(BusSFX, 0.5)
+	if g := m.Gain(BusSFX); !approx(g, 0.25) {
+		t.Errorf("sfx gain = %v, want 0.25", g)
+	}
+	if g := m.Gain(BusMusic); !approx(g, 0.5) {
+		t.Errorf("music gain = %v, want 0.5", g)
+	}
+	m.SetVolume(BusSFX, 3)
+	if v := m.Volume(BusSFX); v != 1 {
+		t.Errorf("volume not clamped: %v", v)
+	}
+}
+
+func TestMixerMuteKeepsVolume(t *testing.T) {
+	m := NewMixer()
+	m.SetVolume(BusMusic, 0.7)
+	m.SetMuted(BusMusic, true)
+	if g := m.Gain(BusMusic); g != 0 {
+		t.Errorf("muted gain = %v, want 0", g)
+	}
+	m.SetMuted(BusMusic, false)
+	if g := m.Gain(BusMusic); !approx(g, 0.7) {
+		t.Errorf("unmuted gain = %v, want 0.7", g)
+	}
+	m.SetMuted(BusMaster, true)
+	if g := m.Gain(BusUI); g != 0 {
+		t.Errorf("gain with master muted = %v, want 0", g)
+	}
+}
+
+func TestMixerScriptedLayerIsNotPersisted(t *testing.T) {
+	m := NewMixer()
+	m.SetVolume(BusSFX, 0.5)
+	changes := 0
+	m.SetOnChange(func() { changes++ })
+	rev := m.revision
+
+	m.SetScriptedVolume(BusSFX, 0.5)
+	m.SetScriptedMuted(BusMusic, true)
+	if !approx(m.Gain(BusSFX), 0.25) || m.Gain(BusMusic) != 0 {
+		t.Errorf("scripted gains: sfx %v, music %v", m.Gain(BusSFX), m.Gain(BusMusic))
+	}
+	if m.Volume(BusSFX) != 0.5 || m.Muted(BusMusic) || changes != 0 {
+		t.Errorf("scripted layer leaked into settings: sfx %v, music muted %v, %d changes",
+			m.Volume(BusSFX), m.Muted(BusMusic), changes)
+	}
+	if m.revision == rev {
+		t.Error("scripted change did not bump the revision")
+	}
+
+	m.ClearScripted()
+	if !approx(m.Gain(BusSFX), 0.5) || m.Gain(BusMusic) != 1 {
+		t.Errorf("after ClearScripted: sfx %v, music %v", m.Gain(BusSFX), m.Gain(BusMusic))
+	}
+}
+
+func TestMixerDucksMusicWhileVoicePlays(t *testing.T) {
+	m := NewMixer()
+	m.ClearDuckRules()
+	m.AddDuckRule(DuckRule{Trigger: BusVoice, Target: BusMusic, Level: 0.5, Frames: 5})
+
+	voice := true
+	active := func(b Bus) bool { return b == BusVoice && voice }
+	m.Update(active)
+	if g := m.Gain(BusMusic); !approx(g, 0.9) {
+		t.Errorf("after one tick gain = %v, want 0.9", g)
+	}
+	for range 10 {
+		m.Update(active)
+	}
+	if g := m.Gain(BusMusic); !approx(g, 0.5) {
+		t.Errorf("fully ducked gain = %v, want 0.5", g)
+	}
+	if g := m.Gain(BusSFX); g != 1 {
+		t.Errorf("sfx should not duck, gain = %v", g)
+	}
+
+	voice = false
+	for range 5 {
+		m.Update(active)
+	}
+	if g := m.Gain(BusMusic); !approx(g, 1) {
+		t.Errorf("released gain = %v, want 1", g)
+	}
+}
+
+func TestMixerRevisionOnlyMovesOnChange(t *testing.T) {
+	m := NewMixer()
+	rev := m.revision
+	m.Update(func(Bus) bool { return false })
+	m.SetVolume(BusSFX, 1)
+	if m.revision != rev {
+		t.Errorf("idle update or no-op set bumped revision")
+	}
+	m.SetVolume(BusSFX, 0.2)
+	if m.revision == rev {
+		t.Errorf("volume change did not bump revision")
+	}
+}
+
+func TestMixerCycleVolumeWraps(t *testing.T) {
+	m := NewMixer()
+	m.SetVolume(BusMusic, 0.8)
+	if v := m.CycleVolume(BusMusic, 0.2); !approx(v, 1) {
+		t.Errorf("cycle to %v, want 1", v)
+	}
+	if v := m.CycleVolume(BusMusic, 0.2); v != 0 {
+		t.Errorf("cycle past full = %v, want 0", v)
+	}
+}
+
+func TestMixerJSONRoundTrip(t *testing.T) {
+	m := NewMixer()
+	changes := 0
+	m.SetOnChange(func() { changes++ })
+	m.SetVolume(BusMusic, 0.3)
+	m.SetMuted(BusVoice, true)
+	if changes != 2 {
+		t.Errorf("onChange called %d times, want 2", changes)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := NewMixer()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+	if v := restored.Volume(BusMusic); !approx(v, 0.3) {
+		t.Errorf("music volume = %v, want 0.3", v)
+	}
+	if !restored.Muted(BusVoice) {
+		t.Error("voice mute not restored")
+	}
+
+	// Older files without a bus keep that bus's defaults.
+	if err := json.Unmarshal([]byte(`{"sfx":{"volume":0.4}}`), restored); err != nil {
+		t.Fatal(err)
+	}
+	if v := restored.Volume(BusMusic); !approx(v, 0.3) {
+		t.Errorf("missing bus changed: %v", v)
+	}
+}
@@ -6,8 +6,19 @@ import (
 	"strings"
 )
 
+// loopManifest lists intro/loop points for music tracks; see ParseLoopPoints.
+const loopManifest = "assets/audio/loops.json"
+
 // LoadAudioAssetsFromFS is a helper function to load all audio files from an fs.FS.
+// Tracks listed in assets/audio/loops.json are added with their loop points.
 func LoadAudioAssetsFromFS(assets fs.FS, am *AudioManager) {
+	loops := map[string]LoopPoints{}
+	if data, err := fs.ReadFile(assets, loopManifest); err == nil {
+		if loops, err = ParseLoopPoints(data); err != nil {
+			log.Printf("failed to parse %s: %v", loopManifest, err)
+		}
+	}
+
 	// WalkDir ensures recursive loading, including assets/audio/bleeps
 	dir := "assets/audio"
 	if err := fs.WalkDir(assets, dir, func(path string, d fs.DirEntry, err error) error {
@@ -25,7 +36,11 @@ func LoadAudioAssetsFromFS(assets fs.FS, am *AudioManager) {
 			log.Printf("failed to read embedded file %s: %v", path, err)
 			return nil
 		}
-		am.Add(path, data)
+		if loop, ok := loops[path]; ok {
+			am.AddLooped(path, data, loop)
+		} else {
+			am.Add(path, data)
+		}
 		return nil
 	}); err != nil {
 		// Handle case where audio directory doesn't exist (e.g., in tests)
@@ -0,0 +1,281 @@
+package audio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// bytesPerFrame is the size of one decoded sample frame: 16-bit stereo.
+const bytesPerFrame = 4
+
+// LoopPoints marks the loop section of an intro-then-loop track, in sample
+// frames at 44100 Hz. Playback runs from 0 to End once, then repeats
+// Start..End forever. End <= 0 means the end of the file; any audio after
+// End is blended into the loop joint to smooth it.
+type LoopPoints struct {
+	Start int64 `json:"loop_start"`
+	End   int64 `json:"loop_end,omitempty"`
+}
+
+// ParseLoopPoints decodes a loop manifest mapping asset paths to loop points,
+// e.g. {"assets/audio/music/boss.ogg": {"loop_start": 264600}}.
+func ParseLoopPoints(data []byte) (map[string]LoopPoints, error) {
+	var points map[string]LoopPoints
+	if err := json.Unmarshal(data, &points); err != nil {
+		return nil, err
+	}
+	for name, p := range points {
+		if p.Start < 0 || (p.End > 0 && p.End <= p.Start) {
+			return nil, fmt.Errorf("%s: loop_start must be >= 0 and before loop_end", name)
+		}
+	}
+	return points, nil
+}
+
+// loopStream wraps a decoded stream of length bytes so it loops between the
+// given points. Looping happens inside the stream, so it is sample-accurate
+// and independent of the game's TPS.
+func loopStream(s io.ReadSeeker, length int64, loop LoopPoints) (io.ReadSeeker, error) {
+	end := loop.End * bytesPerFrame
+	if end <= 0 || end > length {
+		end = length
+	}
+	start := loop.Start * bytesPerFrame
+	if start < 0 || start >= end {
+		return nil, fmt.Errorf("loop start %d is outside the track", loop.Start)
+	}
+	return audio.NewInfiniteLoopWithIntro(s, start, end-start), nil
+}
+
+// frameDuration converts a byte length of decoded audio to playback time.
+func frameDuration(bytes int64) time.Duration {
+	return time.Duration(bytes/bytesPerFrame) * time.Second / sampleRate
+}
+
+// crossfade tracks an in-progress transition between two music tracks. It
+// advances on wall-clock time so it lasts the same with slow-mo or
+// fast-forward.
+type crossfade struct {
+	from, to string
+	start    time.Time
+	duration time.Duration
+}
+
+func (x *crossfade) progress(now time.Time) float64 {
+	if x.duration <= 0 {
+		return 1
+	}
+	return min(float64(now.Sub(x.start))/float64(x.duration), 1)
+}
+
+// Playlist is a list of music tracks played one after another.
+type Playlist struct {
+	Tracks []string
+	// Shuffle plays the tracks in a random order, reshuffled on every pass.
+	Shuffle bool
+	// Repeat starts over after the last track; otherwise music stops there.
+	Repeat bool
+	// Crossfade overlaps consecutive tracks by this long; 0 cuts between them.
+	Crossfade time.Duration
+}
+
+// playlistState walks a Playlist. perm returns a random permutation of
+// [0, n); music shuffling uses its own source rather than the seeded gameplay
+// RNG so replays stay deterministic.
+type playlistState struct {
+	list  Playlist
+	order []int
+	pos   int
+	last  string
+	perm  func(n int) []int
+}
+
+func newPlaylistState(list Playlist, perm func(n int) []int) *playlistState {
+	if perm == nil {
+		perm = rand.Perm
+	}
+	return &playlistState{list: list, perm: perm}
+}
+
+// next returns the track to play next, or false once a non-repeating list has
+// played every track.
+func (s *playlistState) next() (string, bool) {
+	n := len(s.list.Tracks)
+	if n == 0 {
+		return "", false
+	}
+	if s.pos >= len(s.order) {
+		if s.order != nil && !s.list.Repeat {
+			return "", false
+		}
+		s.reorder()
+	}
+	name := s.list.Tracks[s.order[s.pos]]
+	s.pos++
+	s.last = name
+	return name, true
+}
+
+func (s *playlistState) reorder() {
+	n := len(s.list.Tracks)
+	s.pos = 0
+	if !s.list.Shuffle {
+		s.order = make([]int, n)
+		for i := range s.order {
+			s.order[i] = i
+		}
+		return
+	}
+	s.order = s.perm(n)
+	// Don't play the same track twice in a row across a reshuffle.
+	if n > 1 && s.list.Tracks[s.order[0]] == s.last {
+		s.order[0], s.order[1] = s.order[1], s.order[0]
+	}
+}
+
+// CrossfadeMusic fades the current track out while name fades in over
+// duration. With nothing playing, or a non-positive duration, it behaves
+// like PlayMusic. Calling it stops any playlist.
+func (am *AudioManager) CrossfadeMusic(name string, loop bool, duration time.Duration) {
+	am.playlist = nil
+	am.crossfadeTo(name, loop, duration)
+}
+
+// PlayPlaylist starts playing list, replacing any current music. Tracks that
+// were never loaded are skipped. Tracks with loop points never end, so a
+// playlist stays on them.
+func (am *AudioManager) PlayPlaylist(list Playlist) {
+	if am.noSound {
+		return
+	}
+	tracks := make([]string, 0, len(list.Tracks))
+	for _, name := range list.Tracks {
+		if _, ok := am.audioPlayers[name]; !ok {
+			log.Printf("playlist: audio player not found: %s", name)
+			continue
+		}
+		tracks = append(tracks, name)
+	}
+	list.Tracks = tracks
+	am.playlist = newPlaylistState(list, nil)
+	am.advancePlaylist()
+}
+
+func (am *AudioManager) crossfadeTo(name string, loop bool, duration time.Duration) {
+	if am.noSound {
+		return
+	}
+	am.finishCrossfade()
+	to, ok := am.audioPlayers[name]
+	if !ok {
+		log.Printf("audio player not found: %s", name)
+		return
+	}
+	from := am.currentTrack
+	if from == name && to.IsPlaying() {
+		return
+	}
+	if from == "" || from == name || !am.IsPlaying(from) {
+		am.playMusic(name, loop)
+		return
+	}
+	if duration <= 0 {
+		am.stopTrack(from)
+		am.playMusic(name, loop)
+		return
+	}
+	// A registered fade stops the old track's loop goroutine and keeps
+	// Update's gain re-apply off it while it fades.
+	am.startFade(from)
+	am.playMusic(name, loop)
+	to.SetVolume(0)
+	am.xfade = &crossfade{from: from, to: name, start: time.Now(), duration: duration}
+}
+
+// stepCrossfade applies the current crossfade volumes. It runs after gains
+// are re-applied so it has the last word on both tracks.
+func (am *AudioManager) stepCrossfade() {
+	x := am.xfade
+	if x == nil {
+		return
+	}
+	p := x.progress(time.Now())
+	if p >= 1 {
+		am.finishCrossfade()
+		return
+	}
+	if from, ok := am.audioPlayers[x.from]; ok {
+		from.SetVolume(am.outputVolume(x.from) * (1 - p))
+	}
+	if to, ok := am.audioPlayers[x.to]; ok {
+		to.SetVolume(am.outputVolume(x.to) * p)
+	}
+}
+
+// finishCrossfade jumps an in-progress crossfade to its end: the old track
+// stops and the new one plays at full volume.
+func (am *AudioManager) finishCrossfade() {
+	x := am.xfade
+	if x == nil {
+		return
+	}
+	am.xfade = nil
+	am.cancelFade(x.from)
+	am.stopTrack(x.from)
+	if to, ok := am.audioPlayers[x.to]; ok {
+		to.SetVolume(am.outputVolume(x.to))
+	}
+}
+
+// stopTrack pauses and rewinds name and restores its volume for next time.
+func (am *AudioManager) stopTrack(name string) {
+	p, ok := am.audioPlayers[name]
+	if !ok {
+		return
+	}
+	p.Pause()
+	p.Rewind()
+	p.SetVolume(am.outputVolume(name))
+}
+
+// stepPlaylist moves to the next playlist track once the current one ends,
+// or Crossfade before it ends. Track progress comes from the player's own
+// position, so the switch point does not depend on the tick rate.
+func (am *AudioManager) stepPlaylist() {
+	pl := am.playlist
+	if pl == nil || am.xfade != nil {
+		return
+	}
+	cur := am.currentTrack
+	player, ok := am.audioPlayers[cur]
+	if !ok {
+		am.advancePlaylist()
+		return
+	}
+	if am.isPaused(cur) || am.looped[cur] || am.hasFade(cur) {
+		return
+	}
+	if player.IsPlaying() {
+		// A single-track list has nothing to overlap with; it restarts at the end.
+		fade := pl.list.Crossfade
+		if fade <= 0 || len(pl.list.Tracks) < 2 || am.lengths[cur]-player.Position() > fade {
+			return
+		}
+	}
+	am.advancePlaylist()
+}
+
+func (am *AudioManager) advancePlaylist() {
+	name, ok := am.playlist.next()
+	if !ok {
+		am.playlist = nil
+		return
+	}
+	am.crossfadeTo(name, false, am.playlist.list.Crossfade)
+}
@@ -0,0 +1,119 @@
+package audio
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseLoopPoints(t *testing.T) {
+	points, err := ParseLoopPoints([]byte(`{"a.ogg": {"loop_start": 100, "loop_end": 200}, "b.ogg": {"loop_start": 5}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := points["a.ogg"]; got != (LoopPoints{Start: 100, End: 200}) {
+		t.Errorf("a.ogg = %+v", got)
+	}
+	if got := points["b.ogg"]; got != (LoopPoints{Start: 5}) {
+		t.Errorf("b.ogg = %+v", got)
+	}
+	if _, err := ParseLoopPoints([]byte(`{"c.ogg": {"loop_start": 300, "loop_end": 200}}`)); err == nil {
+		t.Error("expected error for loop_end before loop_start")
+	}
+}
+
+func TestLoopStreamRepeatsLoopSection(t *testing.T) {
+	// Six frames labelled 0..5; loop frames 2..5 after the intro. The loop
+	// runs to the end of the data so ebiten has nothing to blend into the
+	// joint.
+	var data []byte
+	for i := range 6 {
+		data = append(data, bytes.Repeat([]byte{byte(i)}, bytesPerFrame)...)
+	}
+	src, err := loopStream(bytes.NewReader(data), int64(len(data)), LoopPoints{Start: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 10*bytesPerFrame)
+	if _, err := io.ReadFull(src, buf); err != nil {
+		t.Fatal(err)
+	}
+	var frames []byte
+	for i := 0; i < len(buf); i += bytesPerFrame {
+		frames = append(frames, buf[i])
+	}
+	if want := []byte{0, 1, 2, 3, 4, 5, 2, 3, 4, 5}; !slices.Equal(frames, want) {
+		t.Errorf("frames = %v, want %v", frames, want)
+	}
+
+	if _, err := loopStream(bytes.NewReader(data), int64(len(data)), LoopPoints{Start: 6}); err == nil {
+		t.Error("expected error for loop start at the end of the track")
+	}
+}
+
+func TestFrameDuration(t *testing.T) {
+	if d := frameDuration(sampleRate * bytesPerFrame * 2); d != 2*time.Second {
+		t.Errorf("frameDuration = %v, want 2s", d)
+	}
+}
+
+func TestCrossfadeProgressUsesWallClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	x := &crossfade{start: start, duration: time.Second}
+	if p := x.progress(start.Add(250 * time.Millisecond)); p != 0.25 {
+		t.Errorf("progress = %v, want 0.25", p)
+	}
+	if p := x.progress(start.Add(2 * time.Second)); p != 1 {
+		t.Errorf("progress past the end = %v, want 1", p)
+	}
+}
+
+func drain(s *playlistState, n int) []string {
+	var out []string
+	for range n {
+		name, ok := s.next()
+		if !ok {
+			break
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+func TestPlaylistInOrderStopsWithoutRepeat(t *testing.T) {
+	s := newPlaylistState(Playlist{Tracks: []string{"a", "b", "c"}}, nil)
+	if got := drain(s, 5); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestPlaylistRepeat(t *testing.T) {
+	s := newPlaylistState(Playlist{Tracks: []string{"a", "b"}, Repeat: true}, nil)
+	if got := drain(s, 5); !slices.Equal(got, []string{"a", "b", "a", "b", "a"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestPlaylistShuffleAvoidsBackToBackRepeat(t *testing.T) {
+	// A permutation that always puts the last track first would replay it
+	// across the reshuffle without the swap.
+	perm := func(n int) []int {
+		out := make([]int, n)
+		for i := range out {
+			out[i] = n - 1 - i
+		}
+		return out
+	}
+	s := newPlaylistState(Playlist{Tracks: []string{"a", "b", "c"}, Shuffle: true, Repeat: true}, perm)
+	got := drain(s, 6)
+	if !slices.Equal(got[:3], []string{"c", "b", "a"}) {
+		t.Errorf("first pass = %v", got[:3])
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] == got[i-1] {
+			t.Errorf("track %q played twice in a row: %v", got[i], got)
+		}
+	}
+}
@@ -238,6 +238,22 @@ type BodiesSpace interface {
 	Find(id string) Collidable
 	// Query returns all collidable bodies whose collision shapes overlap the given rectangle.
 	Query(rect image.Rectangle) []Collidable
+	// Raycast traces a segment between two FP16 points and returns the first
+	// body it enters.
+	Raycast(from, to image.Point, filter QueryFilter) (Hit, bool)
+	// Sweep moves an FP16 rectangle by an FP16 delta and returns the first
+	// body it would touch.
+	Sweep(rect image.Rectangle, delta image.Point, filter QueryFilter) (Hit, bool)
+}
+
+// ChangeObservable is implemented by bodies that report changes to their
+// position, size, altitude or collision shapes. BodiesSpace implementations use
+// it to keep a broadphase index up to date incrementally; bodies that do not
+// implement it are re-checked on every query instead.
+type ChangeObservable interface {
+	// SetChangeObserver registers fn to be called after every such change.
+	// Passing nil removes the observer.
+	SetChangeObserver(fn func())
 }
 
 // Ownable tracks ownership of a body, supporting handoff between owners.
@@ -0,0 +1,26 @@
+package body
+
+import "image"
+
+// QueryFilter selects which bodies a Raycast or Sweep may hit. The zero value
+// hits every body.
+type QueryFilter struct {
+	// Self is the body casting the query. It is never hit, and one-way
+	// platforms it is currently passing through are ignored.
+	Self Collidable
+	// ObstructiveOnly limits hits to bodies that block movement.
+	ObstructiveOnly bool
+	// Include, when set, must return true for a body to be hit. Use it for
+	// faction or type filtering (see space.IgnoreFaction).
+	Include func(Collidable) bool
+}
+
+// Hit describes the first body met by a Raycast or Sweep.
+type Hit struct {
+	Body Collidable
+	// Point is where contact happens, in FP16 world coordinates. For a Sweep
+	// it is the swept rectangle's top-left corner at contact.
+	Point image.Point
+	// Normal is the contact surface normal; each component is -1, 0 or 1.
+	Normal image.Point
+}
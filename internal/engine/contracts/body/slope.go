@@ -0,0 +1,12 @@
+package body
+
+// Slope is a Body whose solid part lies below a straight sloped surface
+// across its width. Slopes never block movement step by step; movement
+// models keep actors on the surface instead.
+type Slope interface {
+	Body
+	IsSlope() bool
+	// SurfaceY returns the world y of the surface at world column x, clamped
+	// to the body's horizontal extent.
+	SurfaceY(x int) int
+}
@@ -0,0 +1,8 @@
+package body
+
+// Surface is a Body that changes how actors move while standing on it.
+type Surface interface {
+	// Friction scales ground friction: 1 is normal ground, values below 1
+	// are slippery and 0 keeps momentum entirely.
+	Friction() float64
+}
@@ -1,8 +1,70 @@
 package combat
 
+// DamageType names the element of a hit, so receivers can resist it or be
+// weak to it. An empty type counts as DamagePhysical.
+type DamageType string
+
+const (
+	DamagePhysical DamageType = "physical"
+	DamageFire     DamageType = "fire"
+	DamageIce      DamageType = "ice"
+	DamageElectric DamageType = "electric"
+)
+
+// DamageInfo describes a single hit.
+type DamageInfo struct {
+	Amount int
+	Type   DamageType
+	// Source is whoever dealt the hit: a weapon or projectile owner, or a
+	// hazard. Nil when unknown.
+	Source interface{}
+	// Projectile is the projectile that carried the hit, nil for direct hits.
+	// A parry reflects it when it implements Reflector.
+	Projectile interface{}
+	// DirectionX and DirectionY are the signs (-1, 0 or 1) of the direction
+	// the hit travels in.
+	DirectionX, DirectionY int
+	// KnockbackX16 and KnockbackY16 are the fp16 velocity the hit pushes the
+	// receiver with. Zero leaves its velocity alone.
+	KnockbackX16, KnockbackY16 int
+	// LaunchAltitude16 is the fp16 upward speed the hit lifts the receiver
+	// with, for receivers that move on an altitude axis. Zero keeps it on
+	// the ground.
+	LaunchAltitude16 int
+	// GroundBounce, WallSplat and Knockdown shape how an airborne receiver
+	// comes down: it bounces once off the floor, sticks to a wall it is
+	// knocked into, or, for Knockdown on a grounded receiver, is floored at
+	// once.
+	GroundBounce, WallSplat, Knockdown bool
+	// HitStunFrames keeps the receiver in its hurt state for that many
+	// frames; zero lets the hurt animation decide.
+	HitStunFrames int
+	// IFrames is the invulnerability granted after the hit; zero uses the
+	// receiver's default.
+	IFrames int
+	Crit    bool
+	// GuardBreak breaks the receiver's guard instead of being blocked.
+	GuardBreak bool
+	// Blocked and Parried are set by the receiver's guard, so hit listeners
+	// can tell a blocked or parried hit from one that was resisted.
+	Blocked, Parried bool
+}
+
 // Damageable is implemented by any entity that can receive damage.
 type Damageable interface {
-	TakeDamage(amount int)
+	TakeDamage(info DamageInfo)
+}
+
+// Reflector is implemented by hits a parry can send back at their attacker,
+// such as projectiles. Reflect turns the hit to fight for owner.
+type Reflector interface {
+	Reflect(owner interface{})
+}
+
+// Staggerable is implemented by anything a parry can stagger: it is stunned for
+// the given number of frames without taking damage.
+type Staggerable interface {
+	Stagger(frames int)
 }
 
 // Destructible extends Damageable with a lifecycle query: the projectile hit
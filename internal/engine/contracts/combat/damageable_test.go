@@ -12,7 +12,7 @@ type stubDamageable struct {
 	got int
 }
 
-func (s *stubDamageable) TakeDamage(amount int) { s.got = amount }
+func (s *stubDamageable) TakeDamage(info contractscombat.DamageInfo) { s.got = info.Amount }
 
 // stubDestructible implements contractscombat.Destructible. Covers AC7.
 type stubDestructible struct {
@@ -20,14 +20,14 @@ type stubDestructible struct {
 	destroyed bool
 }
 
-func (s *stubDestructible) TakeDamage(amount int) { s.got = amount }
-func (s *stubDestructible) IsDestroyed() bool     { return s.destroyed }
+func (s *stubDestructible) TakeDamage(info contractscombat.DamageInfo) { s.got = info.Amount }
+func (s *stubDestructible) IsDestroyed() bool                          { return s.destroyed }
 
 // TestDamageable_Interface asserts the Damageable interface exists with the
-// exact shape required by AC1: a single method TakeDamage(int).
+// exact shape required by AC1: a single method TakeDamage(DamageInfo).
 func TestDamageable_Interface(t *testing.T) {
 	var d contractscombat.Damageable = &stubDamageable{}
-	d.TakeDamage(7)
+	d.TakeDamage(contractscombat.DamageInfo{Amount: 7})
 	got := d.(*stubDamageable).got
 	if got != 7 {
 		t.Errorf("TakeDamage stored = %d, want 7", got)
@@ -40,7 +40,7 @@ func TestDamageable_Interface(t *testing.T) {
 func TestDestructible_Interface(t *testing.T) {
 	s := &stubDestructible{destroyed: false}
 	var d contractscombat.Destructible = s
-	d.TakeDamage(4)
+	d.TakeDamage(contractscombat.DamageInfo{Amount: 4})
 	if s.got != 4 {
 		t.Errorf("TakeDamage stored = %d, want 4", s.got)
 	}
@@ -54,5 +54,5 @@ func TestDestructible_Interface(t *testing.T) {
 
 	// Destructible is a Damageable.
 	var asDamageable contractscombat.Damageable = d
-	asDamageable.TakeDamage(1)
+	asDamageable.TakeDamage(contractscombat.DamageInfo{Amount: 1})
 }
@@ -37,6 +37,14 @@ type Manager interface {
 
 	// Display
 	ShowMessages(lines []string, position string, speed int)
+
+	// Choices
+	// ShowChoice types prompt into the active speech followed by options the
+	// player picks from with up/down and confirm.
+	ShowChoice(prompt string, options []string, position string, speed int)
+	// ChoiceResult returns the option picked in the last ShowChoice. It
+	// reports false until the player has chosen.
+	ChoiceResult() (int, bool)
 }
 
 const (
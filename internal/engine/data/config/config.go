@@ -59,6 +59,12 @@ type AppConfig struct {
 	TypingSoundVolume         float64
 	TypingSoundCooldownFrames int
 
+	// Input replay (see engine/replay). RecordPath and ReplayPath are mutually
+	// exclusive; ReplayVerify also checks per-frame actor hashes.
+	RecordPath   string
+	ReplayPath   string
+	ReplayVerify bool
+
 	// Transition
 	ScreenFlipSpeed     float64
 	FadeHoldDuration    time.Duration // black screen before scene change
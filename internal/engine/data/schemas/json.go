@@ -23,6 +23,17 @@ type HitboxFrameRange struct {
 	End   int `json:"end"`
 }
 
+// FrameBoxes defines the hurtboxes and hitboxes of the animation frames Start
+// through End, inclusive. Rectangles are relative to the body origin, like
+// collision rects, and are drawn facing right. Hurtboxes replace the asset's
+// collision rects on those frames; hitboxes deal the owner's attack damage.
+type FrameBoxes struct {
+	Start     int         `json:"start"`
+	End       int         `json:"end"`
+	Hurtboxes []ShapeRect `json:"hurtboxes,omitempty"`
+	Hitboxes  []ShapeRect `json:"hitboxes,omitempty"`
+}
+
 // SpriteOffset defines a pixel-space draw-time offset applied after all other
 // transforms. It has no effect on physics or collision.
 type SpriteOffset struct {
@@ -38,6 +49,7 @@ type AssetData struct {
 	Loop           *bool             `json:"loop,omitempty"`
 	HitboxFrames   *HitboxFrameRange `json:"hitbox_frames,omitempty"`
 	RenderOffset   *SpriteOffset     `json:"render_offset,omitempty"`
+	FrameBoxes     []FrameBoxes      `json:"frame_boxes,omitempty"`
 }
 
 // MovementConfig defines horizontal movement parameters.
@@ -93,6 +105,36 @@ type EnemyWeaponConfig struct {
 	ShootState     string `json:"shoot_state,omitempty"`
 }
 
+// GuardConfig defines an entity's guard: a meter drained by blocked hits, a
+// parry window at the start of the guard, and, for enemies, when to raise it.
+// Non-positive values fall back to defaults.
+type GuardConfig struct {
+	Meter              int `json:"meter,omitempty"`
+	BlockCost          int `json:"block_cost,omitempty"`
+	RegenDelayFrames   int `json:"regen_delay_frames,omitempty"`
+	RegenPerFrame      int `json:"regen_per_frame,omitempty"`
+	ParryFrames        int `json:"parry_frames,omitempty"`
+	ParryStaggerFrames int `json:"parry_stagger_frames,omitempty"`
+	BreakStunFrames    int `json:"break_stun_frames,omitempty"`
+	ReactRange         int `json:"react_range,omitempty"` // enemies guard against attacks started this close, in pixels
+	HoldFrames         int `json:"hold_frames,omitempty"` // and keep the guard up this long
+}
+
+// JuggleConfig tunes how a beat-em-up entity reacts to launching hits:
+// juggle lift, extra gravity per juggle hit and ground-bounce lift are fp16
+// speeds, the rest are frame counts. Non-positive values fall back to defaults.
+type JuggleConfig struct {
+	JuggleLift16    int `json:"juggle_lift16,omitempty"`
+	GravityScale16  int `json:"gravity_scale16,omitempty"`
+	BounceLift16    int `json:"bounce_lift16,omitempty"`
+	WallSplatFrames int `json:"wall_splat_frames,omitempty"`
+	KnockdownFrames int `json:"knockdown_frames,omitempty"`
+	WakeUpFrames    int `json:"wake_up_frames,omitempty"`
+	WakeUpIFrames   int `json:"wake_up_iframes,omitempty"`
+	JuggleIFrames   int `json:"juggle_iframes,omitempty"`
+	JuggleStunDecay int `json:"juggle_stun_decay,omitempty"`
+}
+
 // SpriteData contains all data related to a sprite's appearance and behavior,
 // including its body rectangle, assets for different states, animation frame rate, and initial facing direction.
 type SpriteData struct {
@@ -102,6 +144,8 @@ type SpriteData struct {
 	FacingDirection animation.FacingDirectionEnum `json:"facing_direction"` // 0 - right, 1 - left
 	Skills          *SkillsConfig                 `json:"skills,omitempty"`
 	Weapon          *EnemyWeaponConfig            `json:"weapon,omitempty"`
+	Guard           *GuardConfig                  `json:"guard,omitempty"`
+	Juggle          *JuggleConfig                 `json:"juggle,omitempty"`
 }
 
 // ParticleData defines the configuration for a particle effect.
@@ -2,6 +2,7 @@ package actors
 
 import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors/movement"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 )
@@ -11,6 +12,9 @@ type StatData struct {
 	Health   int `json:"health"`
 	Speed    int `json:"speed"`
 	MaxSpeed int `json:"max_speed"`
+	// DamageMultipliers scales incoming damage per damage type, e.g.
+	// {"fire": 2} for a weakness or {"ice": 0.5} for a resistance.
+	DamageMultipliers map[contractscombat.DamageType]float64 `json:"damage_multipliers,omitempty"`
 }
 
 // Controllable is implemented by actors that accept directional movement input.
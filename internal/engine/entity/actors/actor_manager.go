@@ -1,11 +1,18 @@
 package actors
 
-import "fmt"
+import (
+	"fmt"
+
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors/events"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
+)
 
 // Manager holds a registry of all active actors in a scene.
 type Manager struct {
 	actors       map[string]ActorEntity
 	primaryActor ActorEntity
+	events       *event.Manager
 }
 
 // NewManager creates a new actor manager.
@@ -20,6 +27,13 @@ func (m *Manager) RegisterPrimary(actor ActorEntity) {
 	m.primaryActor = actor
 }
 
+// SetEventManager makes actors registered from now on publish an
+// events.ActorHitEvent on em whenever they are hit, after calling any OnHit
+// they already had.
+func (m *Manager) SetEventManager(em *event.Manager) {
+	m.events = em
+}
+
 // Register adds an actor to the manager.
 func (m *Manager) Register(actor ActorEntity) {
 	id := actor.ID()
@@ -27,6 +41,30 @@ func (m *Manager) Register(actor ActorEntity) {
 		fmt.Printf("Warning: Actor with ID '%s' is already registered. Overwriting.\n", id)
 	}
 	m.actors[id] = actor
+	m.publishHits(actor)
+}
+
+func (m *Manager) publishHits(actor ActorEntity) {
+	c := actor.GetCharacter()
+	if m.events == nil || c == nil || c.hitsPublished {
+		return
+	}
+	c.hitsPublished = true
+	em := m.events
+	prev := c.OnHit
+	c.OnHit = func(info contractscombat.DamageInfo, dealt int) {
+		if prev != nil {
+			prev(info, dealt)
+		}
+		pos := c.Position()
+		em.Publish(&events.ActorHitEvent{
+			ID:     c.ID(),
+			X:      float64(pos.Min.X + pos.Dx()/2),
+			Y:      float64(pos.Min.Y),
+			Damage: info,
+			Dealt:  dealt,
+		})
+	}
 }
 
 // Find retrieves an actor by its ID.
@@ -6,8 +6,11 @@ import (
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors/events"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors/movement"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -184,3 +187,47 @@ func (m *mockActor) VAltitude16() int              { return 0 }
 func (m *mockActor) SetVAltitude16(v16 int)        {}
 func (m *mockActor) AccelerationAltitude() int     { return 0 }
 func (m *mockActor) SetAccelerationAltitude(a int) {}
+
+func TestManager_RegisterPublishesHits(t *testing.T) {
+	em := event.NewManager()
+	m := actors.NewManager()
+	m.SetEventManager(em)
+	c := newTestCharacter(t)
+	c.SetID("wolf")
+	c.SetPosition(10, 20)
+	m.Register(c)
+
+	var got *events.ActorHitEvent
+	event.Subscribe(em, func(e *events.ActorHitEvent) { got = e })
+	c.TakeDamage(contractscombat.DamageInfo{Amount: 3, Crit: true})
+
+	if got == nil {
+		t.Fatal("no ActorHitEvent published")
+	}
+	if got.ID != "wolf" || got.Dealt != 3 || !got.Damage.Crit || got.X != 18 || got.Y != 20 {
+		t.Errorf("event = %+v", got)
+	}
+}
+
+func TestManager_RegisterChainsExistingOnHit(t *testing.T) {
+	em := event.NewManager()
+	m := actors.NewManager()
+	m.SetEventManager(em)
+	c := newTestCharacter(t)
+	c.SetID("wolf")
+	var own []int
+	c.OnHit = func(_ contractscombat.DamageInfo, dealt int) { own = append(own, dealt) }
+	m.Register(c)
+	m.Register(c) // registering again must not publish twice
+
+	published := 0
+	event.Subscribe(em, func(*events.ActorHitEvent) { published++ })
+	c.TakeDamage(contractscombat.DamageInfo{Amount: 2})
+
+	if len(own) != 1 || own[0] != 2 {
+		t.Errorf("own OnHit saw %v, want [2]", own)
+	}
+	if published != 1 {
+		t.Errorf("published %d ActorHitEvents, want 1", published)
+	}
+}
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"fmt"
+	"image"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
@@ -17,6 +18,12 @@ type collisionRectSetter interface {
 	RefreshCollisions()
 }
 
+// frameBoxesSetter is implemented by characters that support per-frame
+// hurtboxes and hitboxes.
+type frameBoxesSetter interface {
+	AddFrameBoxes(state actors.ActorStateEnum, start, end int, hurtboxes []body.Collidable, hitboxes []image.Rectangle)
+}
+
 // ApplyPlatformerPhysics sets up the movement model and touchable interface for a platformer actor.
 func ApplyPlatformerPhysics(actor actors.ActorEntity, blocker physicsmovement.PlayerMovementBlocker) error {
 	model, err := physicsmovement.NewMovementModel(physicsmovement.Platform, blocker)
@@ -56,6 +63,20 @@ func SetCharacterBodies(
 	}
 
 	bodyphysics.SetCollisionBodies(character, data, stateMap, idProvider, addCollisionRect)
+
+	if frameSetter, ok := character.(frameBoxesSetter); ok {
+		addFrameBoxes := func(state animation.SpriteState, start, end int, hurtboxes []body.Collidable, hitboxes []image.Rectangle) {
+			actorState, ok := state.(actors.ActorStateEnum)
+			if !ok {
+				return
+			}
+			for _, rect := range hurtboxes {
+				rect.SetOwner(character)
+			}
+			frameSetter.AddFrameBoxes(actorState, start, end, hurtboxes, hitboxes)
+		}
+		bodyphysics.SetFrameBoxes(data, stateMap, idProvider, addFrameBoxes)
+	}
 	return nil
 }
 
@@ -67,6 +88,9 @@ func SetCharacterStats(character actors.ActorEntity, data actors.StatData) error
 	if err := character.SetMaxSpeed(data.MaxSpeed); err != nil {
 		return err
 	}
+	if c := character.GetCharacter(); c != nil && len(data.DamageMultipliers) > 0 {
+		c.SetDamageMultipliers(data.DamageMultipliers)
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package actors
 import (
 	"image"
 	"log"
+	"math"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
@@ -36,9 +37,12 @@ type Character struct {
 	movementModel        physicsmovement.MovementModel // platform physics model
 	movementBlockers     int                           // reference-counted movement lock
 	invulnerabilityTimer int                           // frames remaining of post-hurt invulnerability
+	hitStunTimer         int                           // frames remaining in Hurted; 0 lets the animation decide
+	hitsPublished        bool                          // OnHit already chains a Manager's ActorHitEvent publisher
 	imageOptions         *ebiten.DrawImageOptions
 
-	faction contractscombat.Faction // faction for damage resolution; default FactionNeutral
+	faction           contractscombat.Faction                // faction for damage resolution; default FactionNeutral
+	damageMultipliers map[contractscombat.DamageType]float64 // per damage type; 1 when absent
 
 	skills            []skill.Skill      // active gameplay skills (jump, dash, …)
 	stateContributors []StateContributor // optional per-frame state overrides
@@ -62,6 +66,12 @@ type Character struct {
 	MovementTransitionHandler func(*Character)
 	// OnStateChange is called after every successful state change with the old and new state.
 	OnStateChange func(oldState, newState ActorStateEnum)
+	// OnHit is called for every hit that reaches the character, with the
+	// damage it dealt after multipliers.
+	OnHit func(info contractscombat.DamageInfo, dealt int)
+	// HitFilter, when non-nil, sees every hit before it lands and returns the
+	// hit to apply instead, e.g. a guard blocking it.
+	HitFilter func(info contractscombat.DamageInfo) contractscombat.DamageInfo
 	bodyphysics.Ownership
 }
 
@@ -126,6 +136,9 @@ func (c *Character) SetPosition16(x16, y16 int) {
 func (c *Character) SetSize(width, height int) {
 	c.MovableBody.SetSize(width, height)
 }
+func (c *Character) SetChangeObserver(fn func()) {
+	c.CollidableBody.SetChangeObserver(fn)
+}
 func (c *Character) Scale() float64 {
 	return c.MovableBody.Scale()
 }
@@ -275,7 +288,7 @@ func (c *Character) Update(space body.BodiesSpace) error {
 			wasActive := activeSkill.IsActive()
 			activeSkill.HandleInput(c, c.movementModel, space)
 			if !wasActive && activeSkill.IsActive() {
-				debug.Log("skill_activated", "skill=%T player=%s key=%v", activeSkill, c.ID(), activeSkill.ActivationKey())
+				debug.Log("skill_activated", "skill=%T player=%s action=%v", activeSkill, c.ID(), activeSkill.ActivationAction())
 			}
 		}
 		s.Update(c, c.movementModel)
@@ -290,6 +303,7 @@ func (c *Character) Update(space body.BodiesSpace) error {
 	c.UpdateMovement(space)
 
 	c.handleState()
+	c.RefreshFrameCollisions()
 	return nil
 }
 
@@ -305,14 +319,7 @@ func (c *Character) UpdateImageOptions() {
 	}
 	c.imageOptions.GeoM.Reset()
 
-	sprite := c.GetSpriteByState(c.state.State())
-	if sprite == nil || sprite.Image == nil {
-		sprite = c.GetSpriteByState(Idle)
-	}
-	if sprite == nil || sprite.Image == nil {
-		sprite = c.GetFirstSprite()
-	}
-
+	sprite := c.currentSprite()
 	if sprite == nil || sprite.Image == nil {
 		return
 	}
@@ -384,7 +391,7 @@ func (c *Character) handleState() {
 		c.SetNewStateFatal(Dying)
 		return
 	}
-	if c.state.State() == Hurted && c.state.IsAnimationFinished() {
+	if c.state.State() == Hurted && c.hurtFinished() {
 		c.SetNewStateFatal(Idle)
 		return
 	}
@@ -435,27 +442,113 @@ func (c *Character) pollStateContributors() bool {
 	return false
 }
 
+// hurtFinished ticks the hit-stun of the last hit and reports whether it ran
+// out. Hits without hit-stun last until the hurt animation finishes.
+func (c *Character) hurtFinished() bool {
+	if c.hitStunTimer > 0 {
+		c.hitStunTimer--
+		return c.hitStunTimer == 0
+	}
+	return c.state.IsAnimationFinished()
+}
+
+// defaultIFrames is the invulnerability after a hit that sets none.
+const defaultIFrames = 120 // 2 seconds at 60fps
+
+// Hurt deals a plain hit of damage; see TakeDamage.
 func (c *Character) Hurt(damage int) {
+	c.TakeDamage(contractscombat.DamageInfo{Amount: damage})
+}
+
+// TakeDamage implements contracts/combat.Damageable. The amount is scaled by
+// the damage multiplier for info.Type. A hit that deals damage switches to
+// Hurted, applies the knockback and grants info.IFrames of invulnerability.
+// Hits are ignored while invulnerable.
+func (c *Character) TakeDamage(info contractscombat.DamageInfo) {
 	if c.Invulnerable() {
 		return
 	}
+	if c.HitFilter != nil {
+		info = c.HitFilter(info)
+	}
+
+	dealt := c.scaleDamage(info)
+	if dealt > 0 {
+		c.LoseHealth(dealt)
+
+		c.stun(info.HitStunFrames)
+		if info.KnockbackX16 != 0 || info.KnockbackY16 != 0 {
+			c.SetVelocity(info.KnockbackX16, info.KnockbackY16)
+		}
+		c.SetInvulnerability(true)
+		c.invulnerabilityTimer = info.IFrames
+		if c.invulnerabilityTimer <= 0 {
+			c.invulnerabilityTimer = defaultIFrames
+		}
+	}
+
+	if c.OnHit != nil {
+		c.OnHit(info, dealt)
+	}
+}
+
+// SetInvulnerableFrames makes the character invulnerable for frames frames,
+// as the i-frames of a hit would.
+func (c *Character) SetInvulnerableFrames(frames int) {
+	if frames <= 0 {
+		return
+	}
+	c.SetInvulnerability(true)
+	c.invulnerabilityTimer = frames
+}
 
-	c.LoseHealth(damage)
+// Stagger implements contracts/combat.Staggerable: it stuns the character
+// like a hit would, without dealing damage. Dying characters are left alone.
+func (c *Character) Stagger(frames int) {
+	if s := c.State(); s == Dying || s == Dead {
+		return
+	}
+	c.stun(frames)
+}
 
-	// Switch to Hurt state
+// stun switches to Hurted for frames frames, or for the hurt animation when
+// frames is not positive.
+func (c *Character) stun(frames int) {
 	state, err := c.NewState(Hurted)
 	if err != nil {
 		log.Fatal(err)
 	}
 	c.SetState(state)
-	c.SetInvulnerability(true)
-	c.invulnerabilityTimer = 120 // 2 seconds at 60fps
+	c.hitStunTimer = max(frames, 0)
+}
+
+// scaleDamage applies the multiplier for the hit's damage type, rounding to
+// the nearest whole point.
+func (c *Character) scaleDamage(info contractscombat.DamageInfo) int {
+	t := info.Type
+	if t == "" {
+		t = contractscombat.DamagePhysical
+	}
+	m, ok := c.damageMultipliers[t]
+	if !ok {
+		return info.Amount
+	}
+	return int(math.Round(float64(info.Amount) * m))
 }
 
-// TakeDamage implements contracts/combat.Damageable by delegating to Hurt.
-// This preserves existing invulnerability and state-transition logic.
-func (c *Character) TakeDamage(amount int) {
-	c.Hurt(amount)
+// SetDamageMultipliers sets how much of each damage type the character takes:
+// below 1 resists it, above 1 is a weakness and 0 is immunity. Types not in
+// the map deal their full amount.
+func (c *Character) SetDamageMultipliers(m map[contractscombat.DamageType]float64) {
+	c.damageMultipliers = m
+}
+
+// DamageMultiplier returns the multiplier applied to hits of type t.
+func (c *Character) DamageMultiplier(t contractscombat.DamageType) float64 {
+	if m, ok := c.damageMultipliers[t]; ok {
+		return m
+	}
+	return 1
 }
 
 // Faction returns the character's current faction.
@@ -472,7 +565,9 @@ func (c *Character) SetTouchable(t body.Touchable) {
 	c.Touchable = t
 }
 
-func (c *Character) Image() *ebiten.Image {
+// currentSprite returns the sprite drawn for the current state, falling back
+// to the idle sprite and then to any sprite.
+func (c *Character) currentSprite() *sprites.Sprite {
 	sprite := c.GetSpriteByState(c.state.State())
 	if sprite == nil || sprite.Image == nil {
 		// Try to fallback to idle sprite
@@ -481,7 +576,11 @@ func (c *Character) Image() *ebiten.Image {
 	if sprite == nil || sprite.Image == nil {
 		sprite = c.GetFirstSprite()
 	}
+	return sprite
+}
 
+func (c *Character) Image() *ebiten.Image {
+	sprite := c.currentSprite()
 	if sprite == nil || sprite.Image == nil {
 		return nil
 	}
@@ -496,6 +595,36 @@ func (c *Character) Image() *ebiten.Image {
 	return c.AnimatedSpriteImage(sprite, frameRect, stateDurationCount, c.FrameRate())
 }
 
+// AnimationFrame returns the index of the sprite frame shown for the current
+// state. Frame boxes authored in the sprite data are looked up by it.
+func (c *Character) AnimationFrame() int {
+	sprite := c.currentSprite()
+	if sprite == nil || sprite.Image == nil {
+		return 0
+	}
+	// Square frames, as in Image.
+	width := sprite.Image.Bounds().Dy()
+	return sprite.FrameIndex(width, c.state.GetAnimationCount(c.count), c.FrameRate())
+}
+
+// Hitboxes returns the world-space hitboxes of the current animation frame,
+// mirrored across the body when the character faces left.
+func (c *Character) Hitboxes() []image.Rectangle {
+	rects := c.FrameHitboxes()
+	if len(rects) == 0 {
+		return nil
+	}
+	pos := c.Position()
+	res := make([]image.Rectangle, 0, len(rects))
+	for _, r := range rects {
+		if c.FaceDirection() == animation.FaceDirectionLeft {
+			r = image.Rect(pos.Dx()-r.Max.X, r.Min.Y, pos.Dx()-r.Min.X, r.Max.Y)
+		}
+		res = append(res, r.Add(pos.Min))
+	}
+	return res
+}
+
 func (c *Character) ImageOptions() *ebiten.DrawImageOptions {
 	return c.imageOptions
 }
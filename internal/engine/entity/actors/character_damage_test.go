@@ -68,7 +68,7 @@ func TestCharacter_TakeDamageDelegatesToHurt(t *testing.T) {
 
 			// Assert Character satisfies the Damageable contract (AC5).
 			var d contractscombat.Damageable = c
-			d.TakeDamage(tt.damage)
+			d.TakeDamage(contractscombat.DamageInfo{Amount: tt.damage})
 
 			if got := c.Health(); got != tt.wantHealthAfter {
 				t.Errorf("Health = %d, want %d", got, tt.wantHealthAfter)
@@ -121,4 +121,85 @@ func TestCharacter_FactionAccessors(t *testing.T) {
 	}
 }
 
+func TestCharacter_TakeDamageAppliesMultipliers(t *testing.T) {
+	c := newTestCharacter(t)
+	c.SetDamageMultipliers(map[contractscombat.DamageType]float64{
+		contractscombat.DamageFire: 2,
+		contractscombat.DamageIce:  0,
+	})
+	var dealt []int
+	c.OnHit = func(_ contractscombat.DamageInfo, d int) { dealt = append(dealt, d) }
+
+	c.TakeDamage(contractscombat.DamageInfo{Amount: 5, Type: contractscombat.DamageIce})
+	if c.Health() != 100 || c.State() == actors.Hurted || c.Invulnerable() {
+		t.Errorf("immune hit: health %d, state %v, invulnerable %v", c.Health(), c.State(), c.Invulnerable())
+	}
+
+	c.TakeDamage(contractscombat.DamageInfo{Amount: 5, Type: contractscombat.DamageFire})
+	if c.Health() != 90 {
+		t.Errorf("fire weakness: health = %d, want 90", c.Health())
+	}
+	if len(dealt) != 2 || dealt[0] != 0 || dealt[1] != 10 {
+		t.Errorf("OnHit dealt = %v, want [0 10]", dealt)
+	}
+}
+
+func TestCharacter_TakeDamageHitStunIFramesAndKnockback(t *testing.T) {
+	c := newTestCharacter(t)
+	c.TakeDamage(contractscombat.DamageInfo{
+		Amount: 1, HitStunFrames: 3, IFrames: 5,
+		KnockbackX16: -32, KnockbackY16: -16,
+	})
+
+	if vx, vy := c.Velocity(); vx != -32 || vy != -16 {
+		t.Errorf("velocity = (%d, %d), want (-32, -16)", vx, vy)
+	}
+	for frame := 1; frame <= 5; frame++ {
+		if err := c.Update(nil); err != nil {
+			t.Fatal(err)
+		}
+		if hurt := c.State() == actors.Hurted; hurt != (frame < 3) {
+			t.Errorf("frame %d: state = %v", frame, c.State())
+		}
+		if c.Invulnerable() != (frame < 5) {
+			t.Errorf("frame %d: invulnerable = %v", frame, c.Invulnerable())
+		}
+	}
+}
+
+func TestCharacter_HitFilterRewritesHits(t *testing.T) {
+	c := newTestCharacter(t)
+	c.HitFilter = func(info contractscombat.DamageInfo) contractscombat.DamageInfo {
+		info.Amount = 0
+		info.Blocked = true
+		return info
+	}
+	var got contractscombat.DamageInfo
+	c.OnHit = func(info contractscombat.DamageInfo, _ int) { got = info }
+
+	c.TakeDamage(contractscombat.DamageInfo{Amount: 5, HitStunFrames: 3})
+	if c.Health() != 100 || c.State() == actors.Hurted {
+		t.Errorf("filtered hit: health %d, state %v", c.Health(), c.State())
+	}
+	if !got.Blocked {
+		t.Error("OnHit did not see the filtered hit")
+	}
+}
+
+func TestCharacter_StaggerStunsWithoutDamage(t *testing.T) {
+	c := newTestCharacter(t)
+	c.Stagger(2)
+	if c.State() != actors.Hurted || c.Health() != 100 {
+		t.Fatalf("after Stagger: state %v, health %d", c.State(), c.Health())
+	}
+	for i := 0; i < 2; i++ {
+		if err := c.Update(nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if c.State() == actors.Hurted {
+		t.Error("still staggered after 2 frames")
+	}
+}
+
 func ptrFaction(f contractscombat.Faction) *contractscombat.Faction { return &f }
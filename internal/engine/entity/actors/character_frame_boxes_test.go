@@ -0,0 +1,105 @@
+package actors_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/render/sprites"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newFrameBoxesCharacter builds a Character whose looping Idle animation has
+// three 16px frames, two ticks each, with a smaller hurtbox and a hitbox on
+// frame 1.
+func newFrameBoxesCharacter() *actors.Character {
+	sMap := sprites.SpriteMap{
+		actors.Idle: &sprites.Sprite{Image: ebiten.NewImage(48, 16), Loop: true},
+	}
+	c := actors.NewCharacter(sMap, bodyphysics.NewRect(0, 0, 16, 16))
+	c.SetID("fighter")
+	c.SetPosition(100, 200)
+	c.SetMaxHealth(10)
+	c.SetHealth(10)
+	c.SetFrameRate(2)
+
+	rect := func(x, y, w, h int) body.Collidable {
+		r := bodyphysics.NewCollidableBodyFromRect(bodyphysics.NewRect(x, y, w, h))
+		r.SetPosition(x, y)
+		return r
+	}
+	c.AddCollisionRect(actors.Idle, rect(0, 0, 16, 16))
+	c.AddFrameBoxes(actors.Idle, 1, 1,
+		[]body.Collidable{rect(4, 8, 8, 8)},
+		[]image.Rectangle{image.Rect(12, 4, 20, 10)},
+	)
+	c.RefreshCollisions()
+	return c
+}
+
+func TestCharacter_FrameBoxesFollowAnimationFrame(t *testing.T) {
+	c := newFrameBoxesCharacter()
+	full := []image.Rectangle{image.Rect(100, 200, 116, 216)}
+	small := []image.Rectangle{image.Rect(104, 208, 112, 216)}
+
+	// Ticks 1..6 show frames 0, 1, 1, 2, 2, 0.
+	want := []struct {
+		frame    int
+		hurt     []image.Rectangle
+		hitboxes int
+	}{
+		{0, full, 0},
+		{1, small, 1},
+		{1, small, 1},
+		{2, full, 0},
+		{2, full, 0},
+		{0, full, 0},
+	}
+	for tick, w := range want {
+		if err := c.Update(nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := c.AnimationFrame(); got != w.frame {
+			t.Fatalf("tick %d: AnimationFrame = %d, want %d", tick+1, got, w.frame)
+		}
+		if got := c.CollisionPosition(); !equalRects(got, w.hurt) {
+			t.Errorf("tick %d: hurtboxes = %v, want %v", tick+1, got, w.hurt)
+		}
+		if got := c.Hitboxes(); len(got) != w.hitboxes {
+			t.Errorf("tick %d: %d hitboxes, want %d", tick+1, len(got), w.hitboxes)
+		}
+	}
+}
+
+func TestCharacter_HitboxesMirrorWhenFacingLeft(t *testing.T) {
+	c := newFrameBoxesCharacter()
+	c.Update(nil)
+	c.Update(nil)
+
+	if !c.HasFrameHitboxes(actors.Idle) {
+		t.Fatal("HasFrameHitboxes(Idle) = false")
+	}
+	if got := c.Hitboxes(); !equalRects(got, []image.Rectangle{image.Rect(112, 204, 120, 210)}) {
+		t.Errorf("facing right: hitboxes = %v", got)
+	}
+
+	c.SetFaceDirection(animation.FaceDirectionLeft)
+	if got := c.Hitboxes(); !equalRects(got, []image.Rectangle{image.Rect(96, 204, 104, 210)}) {
+		t.Errorf("facing left: hitboxes = %v", got)
+	}
+}
+
+func equalRects(a, b []image.Rectangle) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
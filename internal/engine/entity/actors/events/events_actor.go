@@ -1,8 +1,13 @@
 package events
 
+import contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+
 const (
-	ActorJumpedType = "actor_jumped"
-	ActorLandedType = "actor_landed"
+	ActorJumpedType  = "actor_jumped"
+	ActorLandedType  = "actor_landed"
+	ActorDiedType    = "actor_died"
+	ActorHitType     = "actor_hit"
+	EnemySpawnedType = "enemy_spawned"
 )
 
 type ActorJumpedEvent struct {
@@ -20,3 +25,40 @@ type ActorLandedEvent struct {
 func (e *ActorLandedEvent) Type() string {
 	return ActorLandedType
 }
+
+// ActorDiedEvent is published by phase scenes when they remove a dead actor
+// from the space.
+type ActorDiedEvent struct {
+	ID   string
+	X, Y float64
+}
+
+func (e *ActorDiedEvent) Type() string {
+	return ActorDiedType
+}
+
+// ActorHitEvent is published for every hit that reaches a registered actor,
+// for hit VFX and audio. X, Y is the top center of the actor and Dealt the
+// damage left after its multipliers.
+type ActorHitEvent struct {
+	ID     string
+	X, Y   float64
+	Damage contractscombat.DamageInfo
+	Dealt  int
+}
+
+func (e *ActorHitEvent) Type() string {
+	return ActorHitType
+}
+
+// EnemySpawnedEvent is published when an enemy joins the scene, so kill-all
+// goals also track enemies added after the phase started. Tags are the
+// enemy's tags, for goals limited to one of them.
+type EnemySpawnedEvent struct {
+	ID   string
+	Tags []string
+}
+
+func (e *EnemySpawnedEvent) Type() string {
+	return EnemySpawnedType
+}
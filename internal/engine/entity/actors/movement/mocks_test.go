@@ -121,6 +121,12 @@ func (m *mockBodiesSpace) Query(rect image.Rectangle) []body.Collidable {
 	}
 	return nil
 }
+func (m *mockBodiesSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (m *mockBodiesSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
 
 func (m *mockBodiesSpace) GetTilemapDimensionsProvider() tilemaplayer.TilemapDimensionsProvider {
 	return m.tilemapProvider
@@ -22,6 +22,12 @@ func (m *mockSpace) Query(rect image.Rectangle) []body.Collidable {
 	}
 	return result
 }
+func (m *mockSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (m *mockSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
 
 func (m *mockSpace) AddBody(b body.Collidable)                        {}
 func (m *mockSpace) RemoveBody(b body.Collidable)                     {}
@@ -2,9 +2,9 @@ package movement
 
 import (
 	"image"
-	"math/rand"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/rng"
 )
 
 // State enum: part of engine public API
@@ -84,13 +84,13 @@ func (s *WanderMovementState) Move(space body.BodiesSpace) {
 func (s *WanderMovementState) startIdle() {
 	s.state = wanderIdle
 	s.timer = 0
-	s.idleTime = 60 + rand.Intn(120) // Random idle 1-3s (assuming 60 FPS)
+	s.idleTime = 60 + rng.Intn(120) // Random idle 1-3s (assuming 60 FPS)
 }
 
 func (s *WanderMovementState) pickNextMove() {
 	s.state = wanderMove
 	s.timer = 0
-	s.moveTime = 30 + rand.Intn(60) // Random move 0.5-1.5s
+	s.moveTime = 30 + rng.Intn(60) // Random move 0.5-1.5s
 
 	currentX := s.Actor().Position().Min.X
 
@@ -100,7 +100,7 @@ func (s *WanderMovementState) pickNextMove() {
 	} else if currentX < s.anchorX-s.maxDistance {
 		s.movingRight = true
 	} else {
-		s.movingRight = rand.Intn(2) == 0
+		s.movingRight = rng.Intn(2) == 0
 	}
 }
 
@@ -0,0 +1,17 @@
+package events
+
+const (
+	ItemCollectedType = "item_collected"
+)
+
+// ItemCollectedEvent is published when the player collects an item. ItemType
+// is the item_type the item was placed with on the tilemap.
+type ItemCollectedEvent struct {
+	ID       string
+	ItemType string
+	X, Y     float64
+}
+
+func (e *ItemCollectedEvent) Type() string {
+	return ItemCollectedType
+}
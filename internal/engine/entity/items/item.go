@@ -20,3 +20,9 @@ type Item interface {
 	IsRemoved() bool
 	SetRemoved(value bool)
 }
+
+// Typed is implemented by items that remember their ItemType, so collecting
+// them can report it.
+type Typed interface {
+	SetItemType(t ItemType)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	itemevents "github.com/boilerplate/ebiten-template/internal/engine/entity/items/events"
 	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
 	"github.com/boilerplate/ebiten-template/internal/engine/render/sprites"
@@ -21,6 +22,7 @@ type BaseItem struct {
 
 	count        int
 	removed      bool
+	itemType     ItemType
 	imageOptions *ebiten.DrawImageOptions
 	state        ItemState
 	bodyphysics.Ownership
@@ -75,6 +77,9 @@ func (b *BaseItem) SetPosition16(x16, y16 int) {
 func (b *BaseItem) SetSize(width, height int) {
 	b.MovableBody.SetSize(width, height)
 }
+func (b *BaseItem) SetChangeObserver(fn func()) {
+	b.CollidableBody.SetChangeObserver(fn)
+}
 func (b *BaseItem) Scale() float64 {
 	return b.MovableBody.Scale()
 }
@@ -138,6 +143,37 @@ func (b *BaseItem) SetRemoved(value bool) {
 	b.removed = value
 }
 
+// ItemType returns the type the item was created as.
+func (b *BaseItem) ItemType() ItemType {
+	return b.itemType
+}
+
+// SetItemType records the type the item was created as. InitItems sets it
+// from the tilemap.
+func (b *BaseItem) SetItemType(t ItemType) {
+	b.itemType = t
+}
+
+// Collect removes the item and publishes an ItemCollectedEvent, which
+// collect goals count. Collecting a removed item does nothing.
+func (b *BaseItem) Collect() {
+	if b.removed {
+		return
+	}
+	b.removed = true
+	ctx := b.AppContext()
+	if ctx == nil || ctx.EventManager == nil {
+		return
+	}
+	x, y := b.GetPositionMin()
+	ctx.EventManager.Publish(&itemevents.ItemCollectedEvent{
+		ID:       b.ID(),
+		ItemType: string(b.itemType),
+		X:        float64(x),
+		Y:        float64(y),
+	})
+}
+
 func (b *BaseItem) State() ItemStateEnum {
 	return b.state.State()
 }
@@ -3,6 +3,9 @@ package items
 import (
 	"testing"
 
+	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	itemevents "github.com/boilerplate/ebiten-template/internal/engine/entity/items/events"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
 	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/render/sprites"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -32,3 +35,23 @@ func TestBaseItem_Lifecycle(t *testing.T) {
 		t.Errorf("expected state Idle, got %v", item.State())
 	}
 }
+
+func TestBaseItem_CollectPublishesOnce(t *testing.T) {
+	sMap := sprites.SpriteMap{Idle: &sprites.Sprite{Image: ebiten.NewImage(1, 1)}}
+	item := NewBaseItem("ITEM_star_0", sMap, bodyphysics.NewRect(0, 0, 8, 8))
+	item.SetItemType("star")
+	em := event.NewManager()
+	item.SetAppContext(&app.AppContext{EventManager: em})
+
+	var got []*itemevents.ItemCollectedEvent
+	event.Subscribe(em, func(e *itemevents.ItemCollectedEvent) { got = append(got, e) })
+
+	item.Collect()
+	item.Collect()
+	if !item.IsRemoved() {
+		t.Error("collected item not removed")
+	}
+	if len(got) != 1 || got[0].ID != "ITEM_star_0" || got[0].ItemType != "star" {
+		t.Errorf("events = %+v, want one for the star", got)
+	}
+}
@@ -1,5 +1,10 @@
 package event
 
+import (
+	"reflect"
+	"sort"
+)
+
 // Event defines the interface for all events.
 type Event interface {
 	Type() string
@@ -8,51 +13,216 @@ type Event interface {
 // Listener is a function that handles an event.
 type Listener func(e Event)
 
-// wrappedListener holds a listener with its unique ID.
-type wrappedListener struct {
+// subscription is one registered listener. Listeners subscribed by string
+// are keyed by event type name; typed listeners (see Subscribe) by the
+// event's Go type.
+type subscription struct {
 	id       int
+	key      any
+	priority int
+	once     bool
+	removed  bool
 	listener Listener
 }
 
 // Manager handles event subscription and dispatching.
+//
+// Listeners run in priority order (highest first, then subscription order).
+// Subscribing or unsubscribing from inside a listener is safe: Publish works
+// on a snapshot, and a listener removed mid-dispatch is not called again.
 type Manager struct {
-	listeners map[string][]wrappedListener
+	listeners map[any][]*subscription
 	nextID    int
+	queue     []Event
 }
 
 // NewManager creates a new event manager.
 func NewManager() *Manager {
 	return &Manager{
-		listeners: make(map[string][]wrappedListener),
+		listeners: make(map[any][]*subscription),
 		nextID:    1,
 	}
 }
 
+// SubscribeOption configures a subscription.
+type SubscribeOption func(*subscription, *subscribeConfig)
+
+type subscribeConfig struct {
+	group *Group
+}
+
+// WithPriority sets the listener priority. Higher priorities run first; the
+// default is 0.
+func WithPriority(priority int) SubscribeOption {
+	return func(s *subscription, _ *subscribeConfig) { s.priority = priority }
+}
+
+// Once removes the listener after its first call.
+func Once() SubscribeOption {
+	return func(s *subscription, _ *subscribeConfig) { s.once = true }
+}
+
+// InGroup adds the subscription to g, so g.Clear removes it.
+func InGroup(g *Group) SubscribeOption {
+	return func(_ *subscription, c *subscribeConfig) { c.group = g }
+}
+
 // Subscribe adds a listener for a given event type and returns an unsubscribe function.
 // The returned function removes the listener when called.
-func (m *Manager) Subscribe(eventType string, listener Listener) func() {
-	id := m.nextID
+func (m *Manager) Subscribe(eventType string, listener Listener, opts ...SubscribeOption) func() {
+	return m.subscribe(eventType, listener, opts)
+}
+
+// Subscribe registers a typed listener for events of Go type T, e.g.
+// event.Subscribe(m, func(e *events.ActorJumpedEvent) { ... }). It returns an
+// unsubscribe function. Publish matches the concrete type of the event, so T
+// must be concrete: an interface T could never fire and panics instead.
+func Subscribe[T Event](m *Manager, listener func(T), opts ...SubscribeOption) func() {
+	key := reflect.TypeFor[T]()
+	if key.Kind() == reflect.Interface {
+		panic("event.Subscribe: T must be a concrete event type, not interface " + key.String())
+	}
+	return m.subscribe(key, func(e Event) {
+		if t, ok := e.(T); ok {
+			listener(t)
+		}
+	}, opts)
+}
+
+func (m *Manager) subscribe(key any, listener Listener, opts []SubscribeOption) func() {
+	if m.listeners == nil {
+		m.listeners = make(map[any][]*subscription)
+	}
+	sub := &subscription{id: m.nextID, key: key, listener: listener}
 	m.nextID++
-	m.listeners[eventType] = append(m.listeners[eventType], wrappedListener{id: id, listener: listener})
-
-	return func() {
-		listeners := m.listeners[eventType]
-		for i, wl := range listeners {
-			if wl.id == id {
-				m.listeners[eventType] = append(listeners[:i], listeners[i+1:]...)
-				return
-			}
+	var cfg subscribeConfig
+	for _, opt := range opts {
+		opt(sub, &cfg)
+	}
+
+	// Insert after every listener of equal or higher priority.
+	list := m.listeners[key]
+	i := sort.Search(len(list), func(i int) bool { return list[i].priority < sub.priority })
+	updated := make([]*subscription, 0, len(list)+1)
+	updated = append(updated, list[:i]...)
+	updated = append(updated, sub)
+	m.listeners[key] = append(updated, list[i:]...)
+
+	unsubscribe := func() { m.unsubscribe(sub) }
+	if cfg.group != nil {
+		cfg.group.add(unsubscribe)
+	}
+	return unsubscribe
+}
+
+// unsubscribe removes sub. The listener slice is rebuilt rather than edited
+// in place so a Publish iterating the old slice is unaffected.
+func (m *Manager) unsubscribe(sub *subscription) {
+	if sub.removed {
+		return
+	}
+	sub.removed = true
+	list := m.listeners[sub.key]
+	updated := make([]*subscription, 0, len(list))
+	for _, s := range list {
+		if s != sub {
+			updated = append(updated, s)
 		}
 	}
+	if len(updated) == 0 {
+		delete(m.listeners, sub.key)
+		return
+	}
+	m.listeners[sub.key] = updated
 }
 
-// Publish dispatches an event to all registered listeners.
+// Publish dispatches an event to all registered listeners immediately: those
+// subscribed to its type name and those subscribed to its Go type.
 func (m *Manager) Publish(e Event) {
-	if listeners, ok := m.listeners[e.Type()]; ok {
-		for _, wl := range listeners {
-			wl.listener(e)
+	named := m.listeners[e.Type()]
+	typed := m.listeners[reflect.TypeOf(e)]
+	for _, sub := range mergeByPriority(named, typed) {
+		if sub.removed {
+			continue
 		}
+		if sub.once {
+			m.unsubscribe(sub)
+		}
+		sub.listener(e)
+	}
+}
+
+// PublishDeferred queues e for the next Flush instead of dispatching it now.
+func (m *Manager) PublishDeferred(e Event) {
+	m.queue = append(m.queue, e)
+}
+
+// Flush publishes every queued event in order. Events deferred while
+// flushing wait for the next Flush, so a listener that re-queues cannot loop
+// forever. The engine flushes once per tick, after the scene update.
+func (m *Manager) Flush() {
+	queue := m.queue
+	m.queue = nil
+	for _, e := range queue {
+		m.Publish(e)
+	}
+}
+
+// Pending returns the number of deferred events waiting for Flush.
+func (m *Manager) Pending() int {
+	return len(m.queue)
+}
+
+// mergeByPriority merges two priority-sorted lists, keeping subscription
+// order among equal priorities.
+func mergeByPriority(a, b []*subscription) []*subscription {
+	if len(b) == 0 {
+		return a
+	}
+	if len(a) == 0 {
+		return b
+	}
+	out := make([]*subscription, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0].priority > b[0].priority || (a[0].priority == b[0].priority && a[0].id < b[0].id) {
+			out = append(out, a[0])
+			a = a[1:]
+		} else {
+			out = append(out, b[0])
+			b = b[1:]
+		}
+	}
+	out = append(out, a...)
+	return append(out, b...)
+}
+
+// Group collects subscriptions so they can be removed together, e.g. every
+// listener a scene registered. Pass it to Subscribe with InGroup.
+type Group struct {
+	unsubscribes []func()
+}
+
+// NewGroup creates an empty subscription group.
+func NewGroup() *Group {
+	return &Group{}
+}
+
+func (g *Group) add(unsubscribe func()) {
+	g.unsubscribes = append(g.unsubscribes, unsubscribe)
+}
+
+// Len returns the number of subscriptions added to the group since the last
+// Clear.
+func (g *Group) Len() int {
+	return len(g.unsubscribes)
+}
+
+// Clear unsubscribes every listener in the group.
+func (g *Group) Clear() {
+	for _, unsubscribe := range g.unsubscribes {
+		unsubscribe()
 	}
+	g.unsubscribes = nil
 }
 
 // GenericEvent is a simple event implementation that holds a type and a payload.
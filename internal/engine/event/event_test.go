@@ -122,3 +122,145 @@ func TestUnsubscribeDifferentEventTypes(t *testing.T) {
 		t.Fatalf("expected only Y handled after unsubX, got %d, %d", countX, countY)
 	}
 }
+
+type typedEvt struct{ n int }
+
+func (e *typedEvt) Type() string { return "typed" }
+
+func TestTypedSubscribe(t *testing.T) {
+	m := NewManager()
+
+	var got []int
+	Subscribe(m, func(e *typedEvt) { got = append(got, e.n) })
+	named := 0
+	m.Subscribe("typed", func(e Event) { named++ })
+
+	m.Publish(&typedEvt{n: 1})
+	m.Publish(testEvt{"typed"})
+
+	if len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected typed listener to see only *typedEvt, got %v", got)
+	}
+	if named != 2 {
+		t.Fatalf("expected string listener to see both events, got %d", named)
+	}
+}
+
+func TestTypedSubscribeRejectsInterface(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Subscribe with an interface type to panic")
+		}
+	}()
+	Subscribe(NewManager(), func(Event) {})
+}
+
+func TestPriorityOrder(t *testing.T) {
+	m := NewManager()
+
+	var order []string
+	m.Subscribe("typed", func(e Event) { order = append(order, "low") }, WithPriority(-1))
+	m.Subscribe("typed", func(e Event) { order = append(order, "default-a") })
+	Subscribe(m, func(e *typedEvt) { order = append(order, "high") }, WithPriority(10))
+	Subscribe(m, func(e *typedEvt) { order = append(order, "default-b") })
+
+	m.Publish(&typedEvt{})
+
+	want := []string{"high", "default-a", "default-b", "low"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestOnce(t *testing.T) {
+	m := NewManager()
+
+	count := 0
+	m.Subscribe("X", func(e Event) {
+		count++
+		// Re-entrant publish must not call the one-shot listener again.
+		m.Publish(testEvt{"X"})
+	}, Once())
+
+	m.Publish(testEvt{"X"})
+	m.Publish(testEvt{"X"})
+	if count != 1 {
+		t.Fatalf("expected one-shot listener to run once, got %d", count)
+	}
+}
+
+func TestUnsubscribeDuringPublish(t *testing.T) {
+	m := NewManager()
+
+	count1, count2 := 0, 0
+	var unsub2 func()
+	m.Subscribe("X", func(e Event) {
+		count1++
+		unsub2()
+	})
+	unsub2 = m.Subscribe("X", func(e Event) { count2++ })
+	m.Subscribe("X", func(e Event) {
+		// Subscribing mid-dispatch takes effect on the next publish.
+		m.Subscribe("X", func(e Event) {})
+	}, Once())
+
+	m.Publish(testEvt{"X"})
+	if count1 != 1 || count2 != 0 {
+		t.Fatalf("expected listener removed mid-dispatch to be skipped, got %d, %d", count1, count2)
+	}
+}
+
+func TestPublishDeferred(t *testing.T) {
+	m := NewManager()
+
+	count := 0
+	m.Subscribe("X", func(e Event) {
+		count++
+		m.PublishDeferred(testEvt{"X"})
+	})
+
+	m.PublishDeferred(testEvt{"X"})
+	if count != 0 || m.Pending() != 1 {
+		t.Fatalf("expected event to wait for Flush, got count %d pending %d", count, m.Pending())
+	}
+
+	m.Flush()
+	if count != 1 || m.Pending() != 1 {
+		t.Fatalf("expected requeued event to wait for next Flush, got count %d pending %d", count, m.Pending())
+	}
+
+	m.Flush()
+	if count != 2 {
+		t.Fatalf("expected second Flush to deliver requeued event, got %d", count)
+	}
+}
+
+func TestGroupClear(t *testing.T) {
+	m := NewManager()
+	g := NewGroup()
+
+	count := 0
+	m.Subscribe("X", func(e Event) { count++ }, InGroup(g))
+	Subscribe(m, func(e *typedEvt) { count++ }, InGroup(g))
+	kept := 0
+	m.Subscribe("X", func(e Event) { kept++ })
+
+	if g.Len() != 2 {
+		t.Fatalf("expected 2 grouped subscriptions, got %d", g.Len())
+	}
+	g.Clear()
+
+	m.Publish(testEvt{"X"})
+	m.Publish(&typedEvt{})
+	if count != 0 || kept != 1 {
+		t.Fatalf("expected grouped listeners removed, got count %d kept %d", count, kept)
+	}
+	if g.Len() != 0 {
+		t.Fatalf("expected empty group after Clear, got %d", g.Len())
+	}
+}
@@ -0,0 +1,33 @@
+package input
+
+// Action names a logical input (e.g. "jump") independently of the physical
+// key, button or axis bound to it. Gameplay code asks an ActionMap whether an
+// action is pressed; players remap the bindings.
+type Action string
+
+const (
+	ActionUp         Action = "up"
+	ActionDown       Action = "down"
+	ActionLeft       Action = "left"
+	ActionRight      Action = "right"
+	ActionShoot      Action = "shoot"
+	ActionMelee      Action = "melee"
+	ActionJump       Action = "jump"
+	ActionDash       Action = "dash"
+	ActionConfirm    Action = "confirm"
+	ActionCancel     Action = "cancel"
+	ActionWeaponNext Action = "weapon_next"
+	ActionWeaponPrev Action = "weapon_prev"
+	ActionGuard      Action = "guard"
+)
+
+// PlayerActions lists the actions backing PlayerCommands, in field order.
+// Options menus can iterate it to present every rebindable action.
+//
+//nolint:gochecknoglobals
+var PlayerActions = []Action{
+	ActionUp, ActionDown, ActionLeft, ActionRight,
+	ActionShoot, ActionMelee, ActionJump, ActionDash,
+	ActionConfirm, ActionCancel, ActionWeaponNext, ActionWeaponPrev,
+	ActionGuard,
+}
@@ -0,0 +1,154 @@
+package input
+
+import (
+	"encoding/json"
+	"io/fs"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ActionMap binds each Action to any mix of keys, gamepad buttons and gamepad
+// axes. It marshals to JSON as {"jump":[{"key":"Space"},{"button":"south"}]},
+// so bindings can ship as an asset and player remaps can be persisted (e.g.
+// via save.Snapshot.SetData).
+type ActionMap struct {
+	bindings map[Action][]Binding
+}
+
+// NewActionMap creates an ActionMap with no bindings.
+func NewActionMap() *ActionMap {
+	return &ActionMap{bindings: make(map[Action][]Binding)}
+}
+
+// DefaultActionMap returns the stock keyboard layout plus standard gamepad
+// bindings (d-pad and left stick for movement, face buttons for actions).
+func DefaultActionMap() *ActionMap {
+	m := NewActionMap()
+	m.Bind(ActionUp, KeyBinding(ebiten.KeyUp), KeyBinding(ebiten.KeyW),
+		ButtonBinding(ebiten.StandardGamepadButtonLeftTop),
+		AxisBinding(ebiten.StandardGamepadAxisLeftStickVertical, -1, DefaultAxisDeadzone))
+	m.Bind(ActionDown, KeyBinding(ebiten.KeyDown), KeyBinding(ebiten.KeyS),
+		ButtonBinding(ebiten.StandardGamepadButtonLeftBottom),
+		AxisBinding(ebiten.StandardGamepadAxisLeftStickVertical, 1, DefaultAxisDeadzone))
+	m.Bind(ActionLeft, KeyBinding(ebiten.KeyLeft), KeyBinding(ebiten.KeyA),
+		ButtonBinding(ebiten.StandardGamepadButtonLeftLeft),
+		AxisBinding(ebiten.StandardGamepadAxisLeftStickHorizontal, -1, DefaultAxisDeadzone))
+	m.Bind(ActionRight, KeyBinding(ebiten.KeyRight), KeyBinding(ebiten.KeyD),
+		ButtonBinding(ebiten.StandardGamepadButtonLeftRight),
+		AxisBinding(ebiten.StandardGamepadAxisLeftStickHorizontal, 1, DefaultAxisDeadzone))
+	m.Bind(ActionShoot, KeyBinding(ebiten.KeyX), ButtonBinding(ebiten.StandardGamepadButtonRightLeft))
+	m.Bind(ActionMelee, KeyBinding(ebiten.KeyZ), ButtonBinding(ebiten.StandardGamepadButtonRightTop))
+	m.Bind(ActionJump, KeyBinding(ebiten.KeySpace), ButtonBinding(ebiten.StandardGamepadButtonRightBottom))
+	m.Bind(ActionDash, KeyBinding(ebiten.KeyShift), ButtonBinding(ebiten.StandardGamepadButtonRightRight))
+	m.Bind(ActionConfirm, KeyBinding(ebiten.KeyEnter), ButtonBinding(ebiten.StandardGamepadButtonCenterRight))
+	m.Bind(ActionCancel, KeyBinding(ebiten.KeyEscape), ButtonBinding(ebiten.StandardGamepadButtonCenterLeft))
+	m.Bind(ActionWeaponNext, KeyBinding(ebiten.KeyE), ButtonBinding(ebiten.StandardGamepadButtonFrontTopRight))
+	m.Bind(ActionWeaponPrev, KeyBinding(ebiten.KeyQ), ButtonBinding(ebiten.StandardGamepadButtonFrontTopLeft))
+	m.Bind(ActionGuard, KeyBinding(ebiten.KeyC), ButtonBinding(ebiten.StandardGamepadButtonFrontBottomRight))
+	return m
+}
+
+// LoadActionMap reads an ActionMap from a JSON file in fsys. Actions missing
+// from the file keep no bindings.
+func LoadActionMap(fsys fs.FS, path string) (*ActionMap, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	m := NewActionMap()
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Bind appends bindings to action.
+func (m *ActionMap) Bind(action Action, bindings ...Binding) {
+	m.bindings[action] = append(m.bindings[action], bindings...)
+}
+
+// SetBindings replaces every binding of action. Passing none unbinds it.
+func (m *ActionMap) SetBindings(action Action, bindings []Binding) {
+	if len(bindings) == 0 {
+		delete(m.bindings, action)
+		return
+	}
+	m.bindings[action] = append([]Binding(nil), bindings...)
+}
+
+// Bindings returns a copy of the bindings of action.
+func (m *ActionMap) Bindings(action Action) []Binding {
+	return append([]Binding(nil), m.bindings[action]...)
+}
+
+// IsPressed reports whether any binding of action is currently active.
+func (m *ActionMap) IsPressed(action Action) bool {
+	return m.isPressed(action, connectedGamepads())
+}
+
+func (m *ActionMap) isPressed(action Action, gamepads []ebiten.GamepadID) bool {
+	for _, b := range m.bindings[action] {
+		if b.pressed(gamepads) {
+			return true
+		}
+	}
+	return false
+}
+
+// Commands samples every player action into a PlayerCommands snapshot.
+func (m *ActionMap) Commands() PlayerCommands {
+	pads := connectedGamepads()
+	return PlayerCommands{
+		Up:         m.isPressed(ActionUp, pads),
+		Down:       m.isPressed(ActionDown, pads),
+		Left:       m.isPressed(ActionLeft, pads),
+		Right:      m.isPressed(ActionRight, pads),
+		Shoot:      m.isPressed(ActionShoot, pads),
+		Melee:      m.isPressed(ActionMelee, pads),
+		Jump:       m.isPressed(ActionJump, pads),
+		Dash:       m.isPressed(ActionDash, pads),
+		Confirm:    m.isPressed(ActionConfirm, pads),
+		Cancel:     m.isPressed(ActionCancel, pads),
+		WeaponNext: m.isPressed(ActionWeaponNext, pads),
+		WeaponPrev: m.isPressed(ActionWeaponPrev, pads),
+		Guard:      m.isPressed(ActionGuard, pads),
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *ActionMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.bindings)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces all bindings.
+func (m *ActionMap) UnmarshalJSON(data []byte) error {
+	bindings := make(map[Action][]Binding)
+	if err := json.Unmarshal(data, &bindings); err != nil {
+		return err
+	}
+	m.bindings = bindings
+	return nil
+}
+
+// connectedGamepads lists gamepads that report the standard layout; others
+// cannot be driven by standard button/axis bindings.
+func connectedGamepads() []ebiten.GamepadID {
+	var pads []ebiten.GamepadID
+	for _, id := range gamepadIDs() {
+		if isStandardGamepad(id) {
+			pads = append(pads, id)
+		}
+	}
+	return pads
+}
+
+// Actions is the ActionMap read by ReadPlayerCommands. Replace its bindings
+// (or the whole map) to apply player remaps.
+//
+//nolint:gochecknoglobals
+var Actions = DefaultActionMap()
+
+// IsActionPressed reports whether action is pressed on the global Actions map.
+func IsActionPressed(action Action) bool {
+	return Actions.IsPressed(action)
+}
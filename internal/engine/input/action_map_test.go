@@ -0,0 +1,158 @@
+package input
+
+import (
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// stubGamepad replaces the gamepad function vars with a single standard
+// gamepad whose buttons and axes come from the given maps.
+func stubGamepad(t *testing.T, buttons map[ebiten.StandardGamepadButton]bool, axes map[ebiten.StandardGamepadAxis]float64) {
+	t.Helper()
+	oldIDs, oldStd, oldButton, oldAxis, oldKey := gamepadIDs, isStandardGamepad, isGamepadButtonPressed, gamepadAxisValue, isKeyPressed
+	t.Cleanup(func() {
+		gamepadIDs, isStandardGamepad, isGamepadButtonPressed, gamepadAxisValue, isKeyPressed = oldIDs, oldStd, oldButton, oldAxis, oldKey
+	})
+	gamepadIDs = func() []ebiten.GamepadID { return []ebiten.GamepadID{0} }
+	isStandardGamepad = func(ebiten.GamepadID) bool { return true }
+	isGamepadButtonPressed = func(_ ebiten.GamepadID, b ebiten.StandardGamepadButton) bool { return buttons[b] }
+	gamepadAxisValue = func(_ ebiten.GamepadID, a ebiten.StandardGamepadAxis) float64 { return axes[a] }
+	isKeyPressed = func(ebiten.Key) bool { return false }
+}
+
+func TestActionMapGamepadBindings(t *testing.T) {
+	tests := []struct {
+		name    string
+		buttons map[ebiten.StandardGamepadButton]bool
+		axes    map[ebiten.StandardGamepadAxis]float64
+		want    PlayerCommands
+	}{
+		{
+			name:    "face button south jumps",
+			buttons: map[ebiten.StandardGamepadButton]bool{ebiten.StandardGamepadButtonRightBottom: true},
+			want:    PlayerCommands{Jump: true},
+		},
+		{
+			name:    "d-pad left",
+			buttons: map[ebiten.StandardGamepadButton]bool{ebiten.StandardGamepadButtonLeftLeft: true},
+			want:    PlayerCommands{Left: true},
+		},
+		{
+			name: "stick past deadzone",
+			axes: map[ebiten.StandardGamepadAxis]float64{ebiten.StandardGamepadAxisLeftStickHorizontal: 0.8},
+			want: PlayerCommands{Right: true},
+		},
+		{
+			name: "stick inside deadzone",
+			axes: map[ebiten.StandardGamepadAxis]float64{ebiten.StandardGamepadAxisLeftStickHorizontal: 0.1},
+			want: PlayerCommands{},
+		},
+		{
+			name: "stick up is negative vertical",
+			axes: map[ebiten.StandardGamepadAxis]float64{ebiten.StandardGamepadAxisLeftStickVertical: -1},
+			want: PlayerCommands{Up: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubGamepad(t, tt.buttons, tt.axes)
+			if got := DefaultActionMap().Commands(); got != tt.want {
+				t.Errorf("Commands() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionMapIgnoresNonStandardGamepads(t *testing.T) {
+	stubGamepad(t, map[ebiten.StandardGamepadButton]bool{ebiten.StandardGamepadButtonRightBottom: true}, nil)
+	isStandardGamepad = func(ebiten.GamepadID) bool { return false }
+
+	if DefaultActionMap().IsPressed(ActionJump) {
+		t.Error("expected non-standard gamepad to be ignored")
+	}
+}
+
+func TestActionMapRebind(t *testing.T) {
+	stubGamepad(t, nil, nil)
+	pressed := map[ebiten.Key]bool{ebiten.KeyK: true}
+	isKeyPressed = func(k ebiten.Key) bool { return pressed[k] }
+
+	m := DefaultActionMap()
+	m.SetBindings(ActionJump, []Binding{KeyBinding(ebiten.KeyK)})
+
+	if !m.IsPressed(ActionJump) {
+		t.Error("expected rebound key K to trigger jump")
+	}
+	pressed = map[ebiten.Key]bool{ebiten.KeySpace: true}
+	if m.IsPressed(ActionJump) {
+		t.Error("expected old Space binding to be replaced")
+	}
+
+	m.SetBindings(ActionJump, nil)
+	if len(m.Bindings(ActionJump)) != 0 {
+		t.Error("expected SetBindings(nil) to unbind the action")
+	}
+}
+
+func TestActionMapJSONRoundTrip(t *testing.T) {
+	m := NewActionMap()
+	m.Bind(ActionJump, KeyBinding(ebiten.KeySpace), ButtonBinding(ebiten.StandardGamepadButtonRightBottom))
+	m.Bind(ActionLeft, AxisBinding(ebiten.StandardGamepadAxisLeftStickHorizontal, -1, 0.4))
+	m.Bind(ActionDash, KeyBinding(ebiten.KeyShift))
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got, err := LoadActionMap(fstest.MapFS{"bindings.json": {Data: data}}, "bindings.json")
+	if err != nil {
+		t.Fatalf("LoadActionMap: %v", err)
+	}
+	for _, action := range []Action{ActionJump, ActionLeft, ActionDash} {
+		want, have := m.Bindings(action), got.Bindings(action)
+		if len(want) != len(have) {
+			t.Fatalf("%s: %d bindings, want %d", action, len(have), len(want))
+		}
+		for i := range want {
+			if want[i] != have[i] {
+				t.Errorf("%s[%d] = %+v, want %+v", action, i, have[i], want[i])
+			}
+		}
+	}
+}
+
+func TestBindingUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Binding
+		wantErr bool
+	}{
+		{name: "key", data: `{"key":"Enter"}`, want: KeyBinding(ebiten.KeyEnter)},
+		{name: "button", data: `{"button":"start"}`, want: ButtonBinding(ebiten.StandardGamepadButtonCenterRight)},
+		{
+			name: "axis default deadzone",
+			data: `{"axis":"right_y","direction":1}`,
+			want: AxisBinding(ebiten.StandardGamepadAxisRightStickVertical, 1, DefaultAxisDeadzone),
+		},
+		{name: "unknown key", data: `{"key":"NotAKey"}`, wantErr: true},
+		{name: "unknown button", data: `{"button":"triangle"}`, wantErr: true},
+		{name: "empty", data: `{}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Binding
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
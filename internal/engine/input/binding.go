@@ -0,0 +1,205 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultAxisDeadzone is the deadzone used by axis bindings that do not set
+// one explicitly.
+const DefaultAxisDeadzone = 0.25
+
+// BindingKind identifies which physical input a Binding reads.
+type BindingKind int
+
+const (
+	BindingKey BindingKind = iota
+	BindingGamepadButton
+	BindingGamepadAxis
+)
+
+// Binding maps one physical input to an action. Gamepad bindings use the
+// standard gamepad layout so they work across controller brands.
+type Binding struct {
+	Kind   BindingKind
+	Key    ebiten.Key
+	Button ebiten.StandardGamepadButton
+	Axis   ebiten.StandardGamepadAxis
+	// Direction selects the axis half that triggers the action: -1 or +1.
+	Direction int
+	// Deadzone is the minimum absolute axis value that counts as pressed.
+	Deadzone float64
+}
+
+// KeyBinding binds a keyboard key.
+func KeyBinding(key ebiten.Key) Binding {
+	return Binding{Kind: BindingKey, Key: key}
+}
+
+// ButtonBinding binds a standard gamepad button.
+func ButtonBinding(button ebiten.StandardGamepadButton) Binding {
+	return Binding{Kind: BindingGamepadButton, Button: button}
+}
+
+// AxisBinding binds one direction of a standard gamepad axis. A non-positive
+// deadzone falls back to DefaultAxisDeadzone.
+func AxisBinding(axis ebiten.StandardGamepadAxis, direction int, deadzone float64) Binding {
+	if deadzone <= 0 {
+		deadzone = DefaultAxisDeadzone
+	}
+	if direction < 0 {
+		direction = -1
+	} else {
+		direction = 1
+	}
+	return Binding{Kind: BindingGamepadAxis, Axis: axis, Direction: direction, Deadzone: deadzone}
+}
+
+// pressed reports whether the binding is active. Gamepad bindings are active
+// when any connected standard-layout gamepad satisfies them.
+func (b Binding) pressed(gamepads []ebiten.GamepadID) bool {
+	switch b.Kind {
+	case BindingKey:
+		return isKeyPressed(b.Key)
+	case BindingGamepadButton:
+		for _, id := range gamepads {
+			if isGamepadButtonPressed(id, b.Button) {
+				return true
+			}
+		}
+	case BindingGamepadAxis:
+		for _, id := range gamepads {
+			if v := gamepadAxisValue(id, b.Axis) * float64(b.Direction); v >= b.Deadzone {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String returns a short human-readable label, e.g. "Space", "pad:south" or
+// "pad:left_x-".
+func (b Binding) String() string {
+	switch b.Kind {
+	case BindingGamepadButton:
+		return "pad:" + buttonNames[b.Button]
+	case BindingGamepadAxis:
+		sign := "+"
+		if b.Direction < 0 {
+			sign = "-"
+		}
+		return "pad:" + axisNames[b.Axis] + sign
+	default:
+		return b.Key.String()
+	}
+}
+
+// bindingJSON is the on-disk form of a Binding. Exactly one of Key, Button or
+// Axis is set, e.g. {"key":"Space"}, {"button":"south"} or
+// {"axis":"left_x","direction":-1,"deadzone":0.3}.
+type bindingJSON struct {
+	Key       string  `json:"key,omitempty"`
+	Button    string  `json:"button,omitempty"`
+	Axis      string  `json:"axis,omitempty"`
+	Direction int     `json:"direction,omitempty"`
+	Deadzone  float64 `json:"deadzone,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Binding) MarshalJSON() ([]byte, error) {
+	var out bindingJSON
+	switch b.Kind {
+	case BindingKey:
+		out.Key = b.Key.String()
+	case BindingGamepadButton:
+		name, ok := buttonNames[b.Button]
+		if !ok {
+			return nil, fmt.Errorf("input: unknown gamepad button %d", b.Button)
+		}
+		out.Button = name
+	case BindingGamepadAxis:
+		name, ok := axisNames[b.Axis]
+		if !ok {
+			return nil, fmt.Errorf("input: unknown gamepad axis %d", b.Axis)
+		}
+		out.Axis = name
+		out.Direction = b.Direction
+		out.Deadzone = b.Deadzone
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Binding) UnmarshalJSON(data []byte) error {
+	var in bindingJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	switch {
+	case in.Key != "":
+		var key ebiten.Key
+		if err := key.UnmarshalText([]byte(in.Key)); err != nil {
+			return err
+		}
+		*b = KeyBinding(key)
+	case in.Button != "":
+		button, ok := lookupName(buttonNames, in.Button)
+		if !ok {
+			return fmt.Errorf("input: unknown gamepad button %q", in.Button)
+		}
+		*b = ButtonBinding(button)
+	case in.Axis != "":
+		axis, ok := lookupName(axisNames, in.Axis)
+		if !ok {
+			return fmt.Errorf("input: unknown gamepad axis %q", in.Axis)
+		}
+		*b = AxisBinding(axis, in.Direction, in.Deadzone)
+	default:
+		return fmt.Errorf("input: binding needs one of key, button or axis")
+	}
+	return nil
+}
+
+func lookupName[T comparable](names map[T]string, name string) (T, bool) {
+	for v, n := range names {
+		if n == name {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Layout-neutral names for the standard gamepad, using face-button positions
+// rather than brand-specific labels (A/B, Cross/Circle).
+//
+//nolint:gochecknoglobals
+var buttonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "south",
+	ebiten.StandardGamepadButtonRightRight:       "east",
+	ebiten.StandardGamepadButtonRightLeft:        "west",
+	ebiten.StandardGamepadButtonRightTop:         "north",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "left_shoulder",
+	ebiten.StandardGamepadButtonFrontTopRight:    "right_shoulder",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "left_trigger",
+	ebiten.StandardGamepadButtonFrontBottomRight: "right_trigger",
+	ebiten.StandardGamepadButtonCenterLeft:       "back",
+	ebiten.StandardGamepadButtonCenterRight:      "start",
+	ebiten.StandardGamepadButtonLeftStick:        "left_stick",
+	ebiten.StandardGamepadButtonRightStick:       "right_stick",
+	ebiten.StandardGamepadButtonLeftTop:          "dpad_up",
+	ebiten.StandardGamepadButtonLeftBottom:       "dpad_down",
+	ebiten.StandardGamepadButtonLeftLeft:         "dpad_left",
+	ebiten.StandardGamepadButtonLeftRight:        "dpad_right",
+	ebiten.StandardGamepadButtonCenterCenter:     "home",
+}
+
+//nolint:gochecknoglobals
+var axisNames = map[ebiten.StandardGamepadAxis]string{
+	ebiten.StandardGamepadAxisLeftStickHorizontal:  "left_x",
+	ebiten.StandardGamepadAxisLeftStickVertical:    "left_y",
+	ebiten.StandardGamepadAxisRightStickHorizontal: "right_x",
+	ebiten.StandardGamepadAxisRightStickVertical:   "right_y",
+}
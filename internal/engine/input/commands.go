@@ -1,7 +1,5 @@
 package input
 
-import "github.com/hajimehoshi/ebiten/v2"
-
 type PlayerCommands struct {
 	Up         bool
 	Down       bool
@@ -15,25 +13,13 @@ type PlayerCommands struct {
 	Cancel     bool
 	WeaponNext bool
 	WeaponPrev bool
+	Guard      bool
 }
 
-// ReadPlayerCommands returns the default keyboard mapping.
+// ReadPlayerCommands samples the global Actions map (keyboard and gamepad).
 // Swappable via CommandsReader for game-layer overrides.
 func ReadPlayerCommands() PlayerCommands {
-	return PlayerCommands{
-		Up:         isKeyPressed(ebiten.KeyUp) || isKeyPressed(ebiten.KeyW),
-		Down:       isKeyPressed(ebiten.KeyDown) || isKeyPressed(ebiten.KeyS),
-		Left:       isKeyPressed(ebiten.KeyLeft) || isKeyPressed(ebiten.KeyA),
-		Right:      isKeyPressed(ebiten.KeyRight) || isKeyPressed(ebiten.KeyD),
-		Shoot:      isKeyPressed(ebiten.KeyX),
-		Melee:      isKeyPressed(ebiten.KeyZ),
-		Jump:       isKeyPressed(ebiten.KeySpace),
-		Dash:       isKeyPressed(ebiten.KeyShift),
-		Confirm:    isKeyPressed(ebiten.KeyEnter),
-		Cancel:     isKeyPressed(ebiten.KeyEscape),
-		WeaponNext: isKeyPressed(ebiten.KeyE),
-		WeaponPrev: isKeyPressed(ebiten.KeyQ),
-	}
+	return Actions.Commands()
 }
 
 // Swappable function var: allows injection in tests and game-layer overrides
@@ -112,6 +112,11 @@ func TestReadPlayerCommands(t *testing.T) {
 			stubKeys: map[ebiten.Key]bool{ebiten.KeyEscape: true},
 			want:     PlayerCommands{Cancel: true},
 		},
+		{
+			name:     "guard",
+			stubKeys: map[ebiten.Key]bool{ebiten.KeyC: true},
+			want:     PlayerCommands{Guard: true},
+		},
 		{
 			name: "all keys pressed",
 			stubKeys: map[ebiten.Key]bool{
@@ -2,10 +2,16 @@ package input
 
 import "github.com/hajimehoshi/ebiten/v2"
 
-// Swappable function var: allows injection in tests
+// Swappable function vars: allow injection in tests
 //
 //nolint:gochecknoglobals
-var isKeyPressed = ebiten.IsKeyPressed
+var (
+	isKeyPressed           = ebiten.IsKeyPressed
+	isGamepadButtonPressed = ebiten.IsStandardGamepadButtonPressed
+	gamepadAxisValue       = ebiten.StandardGamepadAxisValue
+	isStandardGamepad      = ebiten.IsStandardGamepadLayoutAvailable
+	gamepadIDs             = func() []ebiten.GamepadID { return ebiten.AppendGamepadIDs(nil) }
+)
 
 func IsSomeKeyPressed(keys ...ebiten.Key) bool {
 	for _, k := range keys {
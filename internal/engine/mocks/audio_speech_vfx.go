@@ -2,6 +2,8 @@ package mocks
 
 import (
 	"time"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/audio"
 )
 
 // MockAudioManager implements audio.Manager for testing
@@ -11,6 +13,13 @@ type MockAudioManager struct {
 	VolumeSet      float64
 	PlayingPaths   map[string]bool
 	LoopSettings   map[string]bool
+	// PlayedBuses records the bus each PlaySoundOnBus path was routed to.
+	PlayedBuses map[string]audio.Bus
+	UpdateCount int
+	// Crossfades records the duration of each CrossfadeMusic call by path.
+	Crossfades map[string]time.Duration
+	Playlists  []audio.Playlist
+	mixer      *audio.Mixer
 }
 
 func NewMockAudioManager() *MockAudioManager {
@@ -18,6 +27,9 @@ func NewMockAudioManager() *MockAudioManager {
 		PlayedPaths:  make([]string, 0),
 		PlayingPaths: make(map[string]bool),
 		LoopSettings: make(map[string]bool),
+		PlayedBuses:  make(map[string]audio.Bus),
+		Crossfades:   make(map[string]time.Duration),
+		mixer:        audio.NewMixer(),
 	}
 }
 
@@ -27,6 +39,15 @@ func (m *MockAudioManager) PlayMusic(path string, loop bool) {
 	m.LoopSettings[path] = loop
 }
 
+func (m *MockAudioManager) CrossfadeMusic(path string, loop bool, duration time.Duration) {
+	m.PlayMusic(path, loop)
+	m.Crossfades[path] = duration
+}
+
+func (m *MockAudioManager) PlayPlaylist(list audio.Playlist) {
+	m.Playlists = append(m.Playlists, list)
+}
+
 func (m *MockAudioManager) PlaySound(path string) {
 	m.PlayedPaths = append(m.PlayedPaths, path)
 }
@@ -35,6 +56,19 @@ func (m *MockAudioManager) PlaySoundAtVolume(path string, _ float64) {
 	m.PlayedPaths = append(m.PlayedPaths, path)
 }
 
+func (m *MockAudioManager) PlaySoundOnBus(bus audio.Bus, path string, _ float64) {
+	m.PlayedPaths = append(m.PlayedPaths, path)
+	m.PlayedBuses[path] = bus
+}
+
+func (m *MockAudioManager) Mixer() *audio.Mixer {
+	return m.mixer
+}
+
+func (m *MockAudioManager) Update() {
+	m.UpdateCount++
+}
+
 func (m *MockAudioManager) PauseCurrentMusic() {}
 
 func (m *MockAudioManager) ResumeCurrentMusic() {}
@@ -27,6 +27,7 @@ type MockDialogueManager struct {
 	SetSpeechSkipEnabledFunc    func(enabled bool)
 	SetPlayerAdvanceEnabledFunc func(enabled bool)
 	ShowMessagesFunc            func(lines []string, position string, speed int)
+	ShowChoiceFunc              func(prompt string, options []string, position string, speed int)
 
 	UpdateCalls                  int
 	DrawCalls                    int
@@ -46,6 +47,7 @@ type MockDialogueManager struct {
 	SetSpeechSkipEnabledCalls    int
 	SetPlayerAdvanceEnabledCalls int
 	ShowMessagesCalls            int
+	ShowChoiceCalls              int
 
 	LastAddedSpeech speech.Speech
 	LastSpeechID    string
@@ -56,6 +58,11 @@ type MockDialogueManager struct {
 	LastAudioMgr    *audio.AudioManager
 	IsSpeakingValue bool
 	ActiveSpeech    speech.Speech
+	LastPrompt      string
+	LastOptions     []string
+	// ChoiceValue is returned by ChoiceResult once ChoiceMade is true.
+	ChoiceValue int
+	ChoiceMade  bool
 }
 
 func (m *MockDialogueManager) Update() error {
@@ -197,5 +204,20 @@ func (m *MockDialogueManager) ShowMessages(lines []string, position string, spee
 	}
 }
 
+func (m *MockDialogueManager) ShowChoice(prompt string, options []string, position string, speed int) {
+	m.ShowChoiceCalls++
+	m.LastPrompt = prompt
+	m.LastOptions = options
+	m.LastPosition = position
+	m.LastSpeed = speed
+	if m.ShowChoiceFunc != nil {
+		m.ShowChoiceFunc(prompt, options, position, speed)
+	}
+}
+
+func (m *MockDialogueManager) ChoiceResult() (int, bool) {
+	return m.ChoiceValue, m.ChoiceMade
+}
+
 // Ensure MockDialogueManager implements dialogue.Manager
 var _ dialogue.Manager = (*MockDialogueManager)(nil)
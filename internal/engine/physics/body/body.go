@@ -17,6 +17,8 @@ type Body struct {
 	x16, y16   int
 	altitude16 int
 	scale      float64
+
+	onChange func()
 }
 
 func NewBody(shape body.Shape) *Body {
@@ -59,8 +61,20 @@ func (b *Body) Position() image.Rectangle {
 
 func (b *Body) Altitude() int       { return fp16.From16(b.altitude16) }
 func (b *Body) Altitude16() int     { return b.altitude16 }
-func (b *Body) SetAltitude(alt int) { b.altitude16 = fp16.To16(alt) }
-func (b *Body) SetAltitude16(a int) { b.altitude16 = a }
+func (b *Body) SetAltitude(alt int) { b.altitude16 = fp16.To16(alt); b.notifyChange() }
+func (b *Body) SetAltitude16(a int) { b.altitude16 = a; b.notifyChange() }
+
+// SetChangeObserver implements body.ChangeObservable. The observer fires after
+// every position, size or altitude change.
+func (b *Body) SetChangeObserver(fn func()) {
+	b.onChange = fn
+}
+
+func (b *Body) notifyChange() {
+	if b.onChange != nil {
+		b.onChange()
+	}
+}
 
 func (b *Body) GetPositionMin() (int, int) {
 	pos := b.Position()
@@ -76,6 +90,7 @@ func (b *Body) SetPosition(x, y int) {
 	}
 	b.x16 = fp16.To16(x)
 	b.y16 = fp16.To16(y)
+	b.notifyChange()
 }
 
 func (b *Body) SetPosition16(x16, y16 int) {
@@ -86,6 +101,7 @@ func (b *Body) SetPosition16(x16, y16 int) {
 	}
 	b.x16 = x16
 	b.y16 = y16
+	b.notifyChange()
 }
 
 func (b *Body) GetPosition16() (int, int) {
@@ -95,6 +111,7 @@ func (b *Body) GetPosition16() (int, int) {
 func (b *Body) SetSize(width, height int) {
 	if r, ok := b.shape.(*Rect); ok {
 		r.SetSize(width, height)
+		b.notifyChange()
 	} else {
 		log.Printf("Warning: SetSize called on body with non-Rect shape: %T", b.shape)
 	}
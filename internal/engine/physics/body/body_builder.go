@@ -1,6 +1,8 @@
 package body
 
 import (
+	"image"
+
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/data/schemas"
@@ -25,10 +27,45 @@ func SetCollisionBodies(
 		}
 
 		for i, r := range assetData.CollisionRects {
-			rect := NewCollidableBodyFromRect(NewRect(r.Rect()))
-			rect.SetPosition(r.X, r.Y)
-			rect.SetID(idProvider(key, i))
-			addCollisionRect(state, rect)
+			addCollisionRect(state, newCollisionRect(r, idProvider(key, i)))
 		}
 	}
 }
+
+// SetFrameBoxes processes the per-frame hurtboxes and hitboxes of each asset in
+// the sprite data. Hurtboxes are built like collision rects; their IDs continue
+// the asset's collision rect indices.
+func SetFrameBoxes(
+	data schemas.SpriteData,
+	stateMap map[string]animation.SpriteState,
+	idProvider func(assetKey string, index int) string,
+	addFrameBoxes func(state animation.SpriteState, start, end int, hurtboxes []body.Collidable, hitboxes []image.Rectangle),
+) {
+	for key, assetData := range data.Assets {
+		state, ok := stateMap[key]
+		if !ok {
+			continue
+		}
+
+		index := len(assetData.CollisionRects)
+		for _, fb := range assetData.FrameBoxes {
+			var hurtboxes []body.Collidable
+			for _, r := range fb.Hurtboxes {
+				hurtboxes = append(hurtboxes, newCollisionRect(r, idProvider(key, index)))
+				index++
+			}
+			var hitboxes []image.Rectangle
+			for _, r := range fb.Hitboxes {
+				hitboxes = append(hitboxes, image.Rect(r.X, r.Y, r.X+r.Width, r.Y+r.Height))
+			}
+			addFrameBoxes(state, fb.Start, fb.End, hurtboxes, hitboxes)
+		}
+	}
+}
+
+func newCollisionRect(r schemas.ShapeRect, id string) *CollidableBody {
+	rect := NewCollidableBodyFromRect(NewRect(r.Rect()))
+	rect.SetPosition(r.X, r.Y)
+	rect.SetID(id)
+	return rect
+}
@@ -1,6 +1,7 @@
 package body
 
 import (
+	"image"
 	"testing"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
@@ -347,3 +348,46 @@ func TestSetCollisionBodies_NilParameters(t *testing.T) {
 
 	SetCollisionBodies(entity, spriteData, nil, idProvider, addCollisionRect)
 }
+
+func TestSetFrameBoxes(t *testing.T) {
+	spriteData := schemas.SpriteData{
+		Assets: map[string]schemas.AssetData{
+			"attack": {
+				CollisionRects: []schemas.ShapeRect{{X: 0, Y: 0, Width: 10, Height: 20}},
+				FrameBoxes: []schemas.FrameBoxes{{
+					Start:     3,
+					End:       6,
+					Hurtboxes: []schemas.ShapeRect{{X: 2, Y: 10, Width: 8, Height: 10}},
+					Hitboxes:  []schemas.ShapeRect{{X: 10, Y: 4, Width: 12, Height: 6}},
+				}},
+			},
+			"unmapped": {
+				FrameBoxes: []schemas.FrameBoxes{{Start: 0, End: 0}},
+			},
+		},
+	}
+	stateMap := map[string]animation.SpriteState{"attack": "attack"}
+	idProvider := func(assetKey string, index int) string {
+		return assetKey + "_" + string(rune('0'+index))
+	}
+
+	calls := 0
+	SetFrameBoxes(spriteData, stateMap, idProvider, func(state animation.SpriteState, start, end int, hurtboxes []body.Collidable, hitboxes []image.Rectangle) {
+		calls++
+		if state != "attack" || start != 3 || end != 6 {
+			t.Errorf("frame boxes for %v %d..%d, want attack 3..6", state, start, end)
+		}
+		if len(hurtboxes) != 1 || hurtboxes[0].Position() != image.Rect(2, 10, 10, 20) {
+			t.Fatalf("hurtboxes = %v", hurtboxes)
+		}
+		if hurtboxes[0].ID() != "attack_1" {
+			t.Errorf("hurtbox ID = %q, want attack_1", hurtboxes[0].ID())
+		}
+		if len(hitboxes) != 1 || hitboxes[0] != image.Rect(10, 4, 22, 10) {
+			t.Errorf("hitboxes = %v", hitboxes)
+		}
+	})
+	if calls != 1 {
+		t.Errorf("addFrameBoxes called %d times, want 1", calls)
+	}
+}
@@ -105,15 +105,37 @@ func (b *CollidableBody) AddCollision(list ...body.Collidable) {
 
 	for d, i := range list {
 		i.SetID(fmt.Sprintf("%v_COLLISION_%d", b.ID(), d))
+		if o, ok := i.(body.ChangeObservable); ok {
+			o.SetChangeObserver(b.onChange)
+		}
 		b.collisionList = append(b.collisionList, i)
 	}
+	b.notifyChange()
 }
 func (b *CollidableBody) CollisionShapes() []body.Collidable {
 	return b.collisionList
 }
 
 func (b *CollidableBody) ClearCollisions() {
+	for _, c := range b.collisionList {
+		if o, ok := c.(body.ChangeObservable); ok {
+			o.SetChangeObserver(nil)
+		}
+	}
 	b.collisionList = nil
+	b.notifyChange()
+}
+
+// SetChangeObserver implements body.ChangeObservable. Collision shapes share
+// the observer, so moving a shape directly (e.g. to follow altitude) is
+// reported like moving the body itself.
+func (b *CollidableBody) SetChangeObserver(fn func()) {
+	b.Body.SetChangeObserver(fn)
+	for _, c := range b.collisionList {
+		if o, ok := c.(body.ChangeObservable); ok {
+			o.SetChangeObserver(fn)
+		}
+	}
 }
 
 // SetPosition overrides Body.SetPosition method to updates the body position and its collisions
@@ -91,6 +91,12 @@ func (m *mockBodiesSpace) Query(rect image.Rectangle) []body.Collidable {
 	}
 	return result
 }
+func (m *mockBodiesSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (m *mockBodiesSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
 
 func TestNewCollidableBody(t *testing.T) {
 	b := NewBody(NewRect(0, 0, 10, 10))
@@ -2,6 +2,7 @@ package body
 
 import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 )
 
 // TouchTrigger implements the physics.Touchable interface to handle body contact.
@@ -29,3 +30,34 @@ func (e *TouchTrigger) OnTouch(other body.Collidable) {
 
 // OnBlock is called for obstructive collisions, which won't happen for a sensor.
 func (e *TouchTrigger) OnBlock(other body.Collidable) {}
+
+// HazardTrigger is a Touchable that damages whatever touches it: the touching
+// body itself when it is combat.Damageable, otherwise its owner.
+type HazardTrigger struct {
+	damage int
+}
+
+// NewHazardTrigger creates a HazardTrigger dealing damage per touch.
+func NewHazardTrigger(damage int) *HazardTrigger {
+	return &HazardTrigger{damage: damage}
+}
+
+// OnTouch implements body.Touchable.
+func (h *HazardTrigger) OnTouch(other body.Collidable) {
+	if other == nil || h.damage <= 0 {
+		return
+	}
+	info := combat.DamageInfo{Amount: h.damage, Source: h}
+	if d, ok := other.(combat.Damageable); ok {
+		d.TakeDamage(info)
+		return
+	}
+	if d, ok := other.Owner().(combat.Damageable); ok {
+		d.TakeDamage(info)
+	}
+}
+
+// OnBlock implements body.Touchable.
+func (h *HazardTrigger) OnBlock(other body.Collidable) {
+	h.OnTouch(other)
+}
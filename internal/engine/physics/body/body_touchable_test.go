@@ -1,6 +1,7 @@
 package body
 
 import (
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"testing"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
@@ -171,3 +172,28 @@ func TestTouchTrigger_DifferentSubjects(t *testing.T) {
 		t.Errorf("expected touchCount to be 2 (only matching); got %d", touchCount)
 	}
 }
+
+type damageSpy struct {
+	*CollidableBody
+	taken int
+}
+
+func (d *damageSpy) TakeDamage(info combat.DamageInfo) { d.taken += info.Amount }
+
+func TestHazardTrigger_DamagesBodyOrOwner(t *testing.T) {
+	h := NewHazardTrigger(2)
+
+	direct := &damageSpy{CollidableBody: NewCollidableBodyFromRect(NewRect(0, 0, 4, 4))}
+	h.OnTouch(direct)
+	if direct.taken != 2 {
+		t.Errorf("direct damage = %d, want 2", direct.taken)
+	}
+
+	owner := &damageSpy{CollidableBody: NewCollidableBodyFromRect(NewRect(0, 0, 4, 4))}
+	shape := NewCollidableBodyFromRect(NewRect(0, 0, 4, 4))
+	shape.SetOwner(owner)
+	h.OnBlock(shape)
+	if owner.taken != 2 {
+		t.Errorf("owner damage = %d, want 2", owner.taken)
+	}
+}
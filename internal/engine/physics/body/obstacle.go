@@ -21,6 +21,12 @@ type ObstacleRect struct {
 	*CollidableBody
 
 	imageOptions *ebiten.DrawImageOptions
+
+	oneWay      bool
+	passThrough map[string]int
+	friction    *float64
+	// slope holds the surface offsets at the left and right edges.
+	slope *[2]int
 }
 
 func NewObstacleRect(bodyRect *Rect) *ObstacleRect {
@@ -73,6 +79,10 @@ func (o *ObstacleRect) SetSize(width, height int) {
 	o.MovableBody.SetSize(width, height)
 }
 
+func (o *ObstacleRect) SetChangeObserver(fn func()) {
+	o.CollidableBody.SetChangeObserver(fn)
+}
+
 func (o *ObstacleRect) Scale() float64 {
 	return o.MovableBody.Scale()
 }
@@ -0,0 +1,95 @@
+package body
+
+import (
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+)
+
+// SetOneWay makes the obstacle a one-way platform: solid from above, passable
+// from below and sideways.
+func (o *ObstacleRect) SetOneWay(oneWay bool) {
+	o.oneWay = oneWay
+}
+
+// IsOneWay implements body.OneWayPlatform.
+func (o *ObstacleRect) IsOneWay() bool {
+	return o.oneWay
+}
+
+// SetPassThrough implements body.OneWayPlatform.
+func (o *ObstacleRect) SetPassThrough(actor body.Collidable, frames int) {
+	if actor == nil || frames <= 0 {
+		return
+	}
+	if o.passThrough == nil {
+		o.passThrough = make(map[string]int)
+	}
+	o.passThrough[actor.ID()] = frames
+}
+
+// IsPassThrough implements body.OneWayPlatform.
+func (o *ObstacleRect) IsPassThrough(actor body.Collidable) bool {
+	if actor == nil {
+		return false
+	}
+	return o.passThrough[actor.ID()] > 0
+}
+
+// Update implements body.OneWayPlatform by counting down pass-throughs.
+func (o *ObstacleRect) Update() {
+	for id, frames := range o.passThrough {
+		if frames <= 1 {
+			delete(o.passThrough, id)
+			continue
+		}
+		o.passThrough[id] = frames - 1
+	}
+}
+
+// SetFriction sets the ground friction multiplier actors get while standing
+// on the obstacle: 1 is normal ground, lower values are slippery.
+func (o *ObstacleRect) SetFriction(friction float64) {
+	o.friction = &friction
+}
+
+// Friction implements body.Surface. It is 1 unless SetFriction was called.
+func (o *ObstacleRect) Friction() float64 {
+	if o.friction == nil {
+		return 1
+	}
+	return *o.friction
+}
+
+// SetSlope turns the obstacle into a slope whose surface runs in a straight
+// line from leftY at its left edge to rightY at its right edge, both in
+// pixels below the obstacle's top. A 45° ramp rising to the right across a
+// 16px tile is SetSlope(16, 0).
+func (o *ObstacleRect) SetSlope(leftY, rightY int) {
+	o.slope = &[2]int{leftY, rightY}
+}
+
+// IsSlope implements body.Slope.
+func (o *ObstacleRect) IsSlope() bool {
+	return o.slope != nil
+}
+
+// SurfaceY implements body.Slope. For obstacles that are not slopes it is
+// the top edge.
+func (o *ObstacleRect) SurfaceY(x int) int {
+	r := o.Position()
+	if o.slope == nil || r.Dx() <= 0 {
+		return r.Min.Y
+	}
+	x = min(max(x, r.Min.X), r.Max.X)
+	left, right := o.slope[0], o.slope[1]
+	// Round to the nearest pixel so walking along the surface is even.
+	num := (right-left)*(x-r.Min.X)*2 + r.Dx()
+	return r.Min.Y + left + floorDiv(num, 2*r.Dx())
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
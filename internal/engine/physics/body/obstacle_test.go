@@ -252,3 +252,56 @@ func TestObstacleRect_MovableInterface(t *testing.T) {
 		t.Errorf("expected zero accelerationY; got %d", accY)
 	}
 }
+
+func TestObstacleRect_OneWayPassThroughExpires(t *testing.T) {
+	obs := NewObstacleRect(NewRect(0, 0, 16, 16))
+	if obs.IsOneWay() {
+		t.Fatal("obstacles are not one-way by default")
+	}
+	obs.SetOneWay(true)
+
+	actor := NewCollidableBodyFromRect(NewRect(0, 0, 4, 4))
+	actor.SetID("actor")
+	obs.SetPassThrough(actor, 2)
+	obs.Update()
+	if !obs.IsPassThrough(actor) {
+		t.Fatal("pass-through expired after one of two frames")
+	}
+	obs.Update()
+	if obs.IsPassThrough(actor) {
+		t.Error("pass-through still active after two frames")
+	}
+}
+
+func TestObstacleRect_Friction(t *testing.T) {
+	obs := NewObstacleRect(NewRect(0, 0, 16, 16))
+	if got := obs.Friction(); got != 1 {
+		t.Errorf("default friction = %v, want 1", got)
+	}
+	obs.SetFriction(0)
+	if got := obs.Friction(); got != 0 {
+		t.Errorf("friction = %v, want 0", got)
+	}
+}
+
+func TestObstacleRect_SlopeSurfaceY(t *testing.T) {
+	o := NewObstacleRect(NewRect(0, 0, 16, 16))
+	o.SetPosition(32, 64)
+	if o.IsSlope() || o.SurfaceY(40) != 64 {
+		t.Fatalf("plain obstacle: IsSlope=%v SurfaceY=%d", o.IsSlope(), o.SurfaceY(40))
+	}
+
+	o.SetSlope(16, 0)
+	for _, tc := range []struct{ x, want int }{
+		{32, 80}, {40, 72}, {48, 64}, {20, 80}, {60, 64},
+	} {
+		if got := o.SurfaceY(tc.x); got != tc.want {
+			t.Errorf("SurfaceY(%d) = %d, want %d", tc.x, got, tc.want)
+		}
+	}
+
+	o.SetSlope(8, 16)
+	if got := o.SurfaceY(41); got != 77 {
+		t.Errorf("gentle slope SurfaceY(41) = %d, want 77", got)
+	}
+}
@@ -16,6 +16,9 @@ type PlatformMovementModel struct {
 	dashActive            bool
 	dashVelocityX         int
 	gravityEnabled        bool
+	// ground is the surface the body stood on after the last Update, or nil
+	// for plain ground or while airborne.
+	ground body.Surface
 }
 
 // NewPlatformMovementModel creates a new PlatformMovementModel with default values.
@@ -63,6 +66,8 @@ func (m *PlatformMovementModel) UpdateHorizontalVelocity(body body.MovableCollid
 			// Apply air friction multiplier if the player is in the air
 			if !m.onGround && m.gravityEnabled {
 				friction = int(float64(baseFriction) * cfg.Physics.AirFrictionMultiplier)
+			} else if m.onGround && m.ground != nil {
+				friction = int(float64(baseFriction) * m.ground.Friction())
 			}
 
 			if vx16 > friction {
@@ -158,16 +163,27 @@ func (m *PlatformMovementModel) Update(body body.MovableCollidable, space body.B
 	_, _, isBlockingY := body.ApplyValidPosition(vy16, false, space)
 	vx16, vy16 = body.Velocity()
 
+	// Slopes don't block the moves above; keep the body on their surface.
+	slope := m.snapToSlope(body, space, vx16, vy16)
+
 	isGrounded := false
-	if isBlockingY {
+	ground := m.groundBody(body, space)
+	if slope != nil {
+		isGrounded = true
+		ground = slope
+	} else if isBlockingY {
 		if vy16 > 0 {
 			isGrounded = true
 		}
 	} else {
-		if vy16 >= 0 && m.CheckGround(body, space) {
+		if vy16 >= 0 && ground != nil {
 			isGrounded = true
 		}
 	}
+	m.ground = nil
+	if isGrounded {
+		m.ground = surfaceOf(ground)
+	}
 
 	if isGrounded {
 		m.onGround = true
@@ -228,6 +244,12 @@ func (m *PlatformMovementModel) SetGravityEnabled(enabled bool) {
 }
 
 func (m *PlatformMovementModel) CheckGround(b body.MovableCollidable, space body.BodiesSpace) bool {
+	return m.groundBody(b, space) != nil
+}
+
+// groundBody returns the obstructive body directly under b, or nil. One-way
+// platforms only count when b stands exactly on their top edge.
+func (m *PlatformMovementModel) groundBody(b body.MovableCollidable, space body.BodiesSpace) body.Collidable {
 	collisionRects := b.CollisionPosition()
 	// If no specific collision shapes are defined, fall back to the main body shape.
 	if len(collisionRects) == 0 {
@@ -253,10 +275,108 @@ func (m *PlatformMovementModel) CheckGround(b body.MovableCollidable, space body
 			if c.ID() == b.ID() {
 				continue
 			}
-			if c.IsObstructive() {
-				return true
+			if !c.IsObstructive() {
+				continue
+			}
+			if p, ok := c.(body.OneWayPlatform); ok && p.IsOneWay() &&
+				(pos.Max.Y != c.Position().Min.Y || p.IsPassThrough(b)) {
+				continue
+			}
+			if y, ok := slopeTop(c, pos); ok && pos.Max.Y != y {
+				continue
 			}
+			return c
 		}
 	}
-	return false
+	return nil
+}
+
+// surfaceOf returns ground as a body.Surface, or nil for plain ground.
+func surfaceOf(ground body.Collidable) body.Surface {
+	s, _ := ground.(body.Surface)
+	return s
+}
+
+// slopeSnapMargin is how far, in pixels beyond this frame's horizontal
+// move, a grounded body is pulled down onto a slope falling away under it.
+const slopeSnapMargin = 2
+
+// snapToSlope moves b vertically so it rests on the slope under it, and
+// returns that slope. A body rests on the highest point of the surface below
+// its collision box, so it never overlaps the slope and meets neighboring
+// ground level with it. Bodies that sank into a slope are lifted out, by at
+// most their own height; a grounded body walking down a slope is pulled onto
+// it instead of bouncing down in small falls. It returns nil when no slope is
+// in reach, and while rising or without gravity, so bodies jump up through
+// slopes.
+func (m *PlatformMovementModel) snapToSlope(b body.MovableCollidable, space body.BodiesSpace, vx16, vy16 int) body.Collidable {
+	if !m.gravityEnabled || vy16 < 0 {
+		return nil
+	}
+	box := collisionBox(b)
+	foot := box.Max.Y
+	reach := foot
+	if m.onGround {
+		dx := fp16.From16(vx16)
+		reach += max(dx, -dx) + slopeSnapMargin
+	}
+
+	// Walking down off a slope, follow it onto flat ground just below too,
+	// instead of stepping off into a fall.
+	sl, onSlope := m.ground.(body.Slope)
+	onSlope = onSlope && m.onGround && sl.IsSlope()
+
+	var best body.Collidable
+	bestY := 0
+	for _, c := range space.Query(image.Rect(box.Min.X, box.Min.Y, box.Max.X, reach+1)) {
+		if c.ID() == b.ID() {
+			continue
+		}
+		y, ok := slopeTop(c, box)
+		if !ok && onSlope && c.IsObstructive() {
+			y, ok = c.Position().Min.Y, c.Position().Min.Y >= foot
+		}
+		if !ok || y < box.Min.Y || y > reach {
+			continue
+		}
+		if best == nil || y < bestY {
+			best, bestY = c, y
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	if bestY != foot {
+		x16, y16 := b.GetPosition16()
+		b.SetPosition16(x16, y16+fp16.To16(bestY-foot))
+	}
+	return best
+}
+
+// slopeTop returns the highest point of c's slope surface across the columns
+// of box, when c is a slope under box.
+func slopeTop(c body.Collidable, box image.Rectangle) (int, bool) {
+	sl, ok := c.(body.Slope)
+	if !ok || !sl.IsSlope() {
+		return 0, false
+	}
+	r := c.Position()
+	x0, x1 := max(box.Min.X, r.Min.X), min(box.Max.X, r.Max.X)
+	if x0 >= x1 {
+		return 0, false
+	}
+	// The surface is straight, so its highest point is at one end.
+	return min(sl.SurfaceY(x0), sl.SurfaceY(x1)), true
+}
+
+// collisionBox is the union of b's collision rects, or its position.
+func collisionBox(b body.Collidable) image.Rectangle {
+	var box image.Rectangle
+	for _, r := range b.CollisionPosition() {
+		box = box.Union(r)
+	}
+	if box.Empty() {
+		box = b.Position()
+	}
+	return box
 }
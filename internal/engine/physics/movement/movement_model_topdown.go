@@ -7,7 +7,6 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
 	"github.com/boilerplate/ebiten-template/internal/engine/input"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type TopDownMovementModel struct {
@@ -104,16 +103,17 @@ func (m *TopDownMovementModel) InputHandler(body body.MovableCollidable, space b
 		return
 	}
 
-	if input.IsSomeKeyPressed(ebiten.KeyA, ebiten.KeyLeft) {
+	cmds := input.CommandsReader()
+	if cmds.Left {
 		body.OnMoveLeft(body.Speed())
 	}
-	if input.IsSomeKeyPressed(ebiten.KeyD, ebiten.KeyRight) {
+	if cmds.Right {
 		body.OnMoveRight(body.Speed())
 	}
-	if input.IsSomeKeyPressed(ebiten.KeyW, ebiten.KeyUp) {
+	if cmds.Up {
 		body.OnMoveUp(body.Speed())
 	}
-	if input.IsSomeKeyPressed(ebiten.KeyS, ebiten.KeyDown) {
+	if cmds.Down {
 		body.OnMoveDown(body.Speed())
 	}
 }
@@ -389,6 +389,60 @@ func TestPlatformMovementModel_CheckGround(t *testing.T) {
 	_ = result // Just verify it doesn't panic
 }
 
+func TestPlatformMovementModel_GroundFriction(t *testing.T) {
+	config.Set(&config.AppConfig{
+		Physics: config.PhysicsConfig{HorizontalInertia: 1.0},
+	})
+
+	ice := bodyphysics.NewObstacleRect(bodyphysics.NewRect(0, 0, 100, 10))
+	ice.SetFriction(0)
+	model := NewPlatformMovementModel(nil)
+	model.onGround = true
+
+	actor := newMockMovableCollidable()
+	actor.SetVelocity(fp16.To16(3), 0)
+	model.UpdateHorizontalVelocity(actor)
+	normal, _ := actor.Velocity()
+
+	model.ground = ice
+	actor.SetVelocity(fp16.To16(3), 0)
+	model.UpdateHorizontalVelocity(actor)
+	slid, _ := actor.Velocity()
+
+	if normal >= fp16.To16(3) || slid != fp16.To16(3) {
+		t.Errorf("velocity after friction: ground %d, ice %d (start %d)", normal, slid, fp16.To16(3))
+	}
+}
+
+func TestPlatformMovementModel_CheckGroundOneWay(t *testing.T) {
+	sp := space.NewSpace()
+	platform := bodyphysics.NewObstacleRect(bodyphysics.NewRect(0, 0, 100, 10))
+	platform.SetID("platform")
+	platform.SetIsObstructive(true)
+	platform.SetPosition(0, 20)
+	platform.AddCollisionBodies()
+	platform.SetOneWay(true)
+	sp.AddBody(platform)
+
+	actor := newMockMovableCollidable()
+	sp.AddBody(actor)
+	model := NewPlatformMovementModel(nil)
+
+	actor.SetPosition(10, 10)
+	if !model.CheckGround(actor, sp) {
+		t.Error("standing on a one-way platform is not grounded")
+	}
+	actor.SetPosition(10, 15)
+	if model.CheckGround(actor, sp) {
+		t.Error("body inside a one-way platform counts as grounded")
+	}
+	actor.SetPosition(10, 10)
+	platform.SetPassThrough(actor, 2)
+	if model.CheckGround(actor, sp) {
+		t.Error("dropping-through body counts as grounded")
+	}
+}
+
 func TestPlatformMovementModel_Update(t *testing.T) {
 	cfg := &config.AppConfig{
 		ScreenWidth:  320,
@@ -433,6 +487,80 @@ func TestPlatformMovementModel_Update(t *testing.T) {
 	}
 }
 
+func TestPlatformMovementModel_WalksSlopesWithoutJitter(t *testing.T) {
+	config.Set(&config.AppConfig{
+		ScreenWidth:  320,
+		ScreenHeight: 240,
+		Physics: config.PhysicsConfig{
+			DownwardGravity:   4,
+			UpwardGravity:     2,
+			MaxFallSpeed:      128,
+			HorizontalInertia: 1.0,
+			SpeedMultiplier:   1.0,
+		},
+	})
+
+	sp := space.NewSpace()
+	solid := func(id string, x, y, w, h int) *bodyphysics.ObstacleRect {
+		o := bodyphysics.NewObstacleRect(bodyphysics.NewRect(x, y, w, h))
+		o.SetID(id)
+		o.SetPosition(x, y)
+		o.AddCollisionBodies()
+		o.SetIsObstructive(true)
+		sp.AddBody(o)
+		return o
+	}
+	// Flat ground at y=100, a 45° ramp up to a plateau at y=68.
+	solid("ground", 0, 100, 64, 16)
+	ramp := solid("ramp", 64, 68, 32, 32)
+	ramp.SetSlope(32, 0)
+	solid("plateau", 96, 68, 224, 48)
+
+	actor := bodyphysics.NewObstacleRect(bodyphysics.NewRect(0, 0, 8, 8))
+	actor.SetID("actor")
+	actor.SetPosition(40, 92)
+	actor.SetMaxSpeed(2)
+	sp.AddBody(actor)
+
+	model := NewPlatformMovementModel(nil)
+	model.SetOnGround(true)
+
+	walk := func(dir, frames int) []int {
+		var feet []int
+		for range frames {
+			actor.SetAcceleration(dir*fp16.To16(1), 0)
+			if err := model.Update(actor, sp); err != nil {
+				t.Fatal(err)
+			}
+			if !model.OnGround() {
+				t.Fatalf("left the ground at %v", actor.Position())
+			}
+			feet = append(feet, actor.Position().Max.Y)
+		}
+		return feet
+	}
+
+	up := walk(1, 60)
+	for i := 1; i < len(up); i++ {
+		if up[i] > up[i-1] {
+			t.Fatalf("foot went down while walking uphill: %v", up)
+		}
+	}
+	if x := actor.Position().Min.X; x <= 96 || up[len(up)-1] != 68 {
+		t.Fatalf("did not reach the plateau: x=%d foot=%d", x, up[len(up)-1])
+	}
+
+	down := walk(-1, 70)
+	for i := 1; i < len(down); i++ {
+		if down[i] < down[i-1] {
+			t.Fatalf("foot went up while walking downhill: %v", down)
+		}
+	}
+	if down[len(down)-1] != 100 {
+		t.Errorf("did not get back down to the ground: foot=%d", down[len(down)-1])
+	}
+}
+
 func TestTopDownMovementModel_New(t *testing.T) {
 	blocker := &mockPlayerMovementBlocker{}
 	model := NewTopDownMovementModel(blocker)
@@ -0,0 +1,209 @@
+package space
+
+import (
+	"image"
+	"sort"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+)
+
+// DefaultCellSize is the edge length, in pixels, of a broadphase grid cell.
+// It is a few tiles wide so typical actors occupy one to four cells.
+const DefaultCellSize = 64
+
+// maxIndexedCells caps how many cells a single body may occupy. Larger bodies
+// (level bounds, huge triggers) are kept in the always-checked list instead of
+// flooding the grid.
+const maxIndexedCells = 256
+
+type cellKey struct{ x, y int }
+
+// gridEntry tracks where one body sits in the broadphase.
+type gridEntry struct {
+	body body.Collidable
+	// cells is the inclusive cell range the body was last indexed under.
+	cells image.Rectangle
+	// state is where the entry currently lives.
+	state entryState
+	// dirty is set by the body's change observer and cleared when the Space
+	// collects it for reindexing. Guarded by Space.dirtyMu.
+	dirty bool
+}
+
+type entryState int
+
+const (
+	entryUnindexed entryState = iota // empty bounds: cannot collide
+	entryGrid                        // registered in grid cells
+	entryLoose                       // checked on every query
+)
+
+// spatialGrid is a uniform-grid broadphase. Bodies that report their changes
+// through body.ChangeObservable are bucketed by cell and re-bucketed lazily
+// when they move; every other body lives in the loose set and is tested on
+// every query, which keeps arbitrary Collidable implementations correct.
+type spatialGrid struct {
+	cellSize int
+	cells    map[cellKey][]*gridEntry
+	loose    map[*gridEntry]struct{}
+}
+
+func newSpatialGrid(cellSize int) *spatialGrid {
+	if cellSize <= 0 {
+		cellSize = DefaultCellSize
+	}
+	return &spatialGrid{
+		cellSize: cellSize,
+		cells:    make(map[cellKey][]*gridEntry),
+		loose:    make(map[*gridEntry]struct{}),
+	}
+}
+
+// insert places e according to its body's current bounds.
+func (g *spatialGrid) insert(e *gridEntry) {
+	if !isTracked(e.body) {
+		e.state = entryLoose
+		g.loose[e] = struct{}{}
+		return
+	}
+	bounds := broadphaseBounds(e.body)
+	if bounds.Empty() {
+		e.state = entryUnindexed
+		return
+	}
+	cells := g.cellRange(bounds)
+	if (cells.Dx()+1)*(cells.Dy()+1) > maxIndexedCells {
+		e.state = entryLoose
+		g.loose[e] = struct{}{}
+		return
+	}
+	e.state = entryGrid
+	e.cells = cells
+	for y := cells.Min.Y; y <= cells.Max.Y; y++ {
+		for x := cells.Min.X; x <= cells.Max.X; x++ {
+			k := cellKey{x, y}
+			g.cells[k] = append(g.cells[k], e)
+		}
+	}
+}
+
+// remove takes e out of the grid or loose set.
+func (g *spatialGrid) remove(e *gridEntry) {
+	switch e.state {
+	case entryLoose:
+		delete(g.loose, e)
+	case entryGrid:
+		for y := e.cells.Min.Y; y <= e.cells.Max.Y; y++ {
+			for x := e.cells.Min.X; x <= e.cells.Max.X; x++ {
+				k := cellKey{x, y}
+				bucket := g.cells[k]
+				for i, other := range bucket {
+					if other == e {
+						bucket[i] = bucket[len(bucket)-1]
+						bucket[len(bucket)-1] = nil
+						bucket = bucket[:len(bucket)-1]
+						break
+					}
+				}
+				if len(bucket) == 0 {
+					delete(g.cells, k)
+				} else {
+					g.cells[k] = bucket
+				}
+			}
+		}
+	}
+	e.state = entryUnindexed
+}
+
+// update re-buckets e after its body changed. Bodies that stay within the
+// same cells are left untouched.
+func (g *spatialGrid) update(e *gridEntry) {
+	if e.state == entryGrid && isTracked(e.body) {
+		bounds := broadphaseBounds(e.body)
+		if !bounds.Empty() && g.cellRange(bounds) == e.cells {
+			return
+		}
+	}
+	g.remove(e)
+	g.insert(e)
+}
+
+// candidates returns every body that may overlap rect, sorted by ID and
+// without duplicates. Callers still run the exact overlap test.
+func (g *spatialGrid) candidates(rect image.Rectangle) []body.Collidable {
+	var out []body.Collidable
+	if !rect.Empty() {
+		cells := g.cellRange(rect)
+		for y := cells.Min.Y; y <= cells.Max.Y; y++ {
+			for x := cells.Min.X; x <= cells.Max.X; x++ {
+				for _, e := range g.cells[cellKey{x, y}] {
+					out = append(out, e.body)
+				}
+			}
+		}
+	}
+	for e := range g.loose {
+		out = append(out, e.body)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID() < out[j].ID() })
+	// Bodies spanning several cells appear once per cell.
+	n := 0
+	for i, b := range out {
+		if i > 0 && b == out[n-1] {
+			continue
+		}
+		out[n] = b
+		n++
+	}
+	return out[:n]
+}
+
+func (g *spatialGrid) clear() {
+	g.cells = make(map[cellKey][]*gridEntry)
+	g.loose = make(map[*gridEntry]struct{})
+}
+
+// cellRange converts pixel bounds to an inclusive range of cell coordinates.
+func (g *spatialGrid) cellRange(r image.Rectangle) image.Rectangle {
+	return image.Rect(
+		floorDiv(r.Min.X, g.cellSize), floorDiv(r.Min.Y, g.cellSize),
+		floorDiv(r.Max.X-1, g.cellSize), floorDiv(r.Max.Y-1, g.cellSize),
+	)
+}
+
+// isTracked reports whether every position change of b is reported through
+// body.ChangeObservable, including changes to its collision shapes.
+func isTracked(b body.Collidable) bool {
+	if _, ok := b.(body.ChangeObservable); !ok {
+		return false
+	}
+	for _, c := range b.CollisionShapes() {
+		if _, ok := c.(body.ChangeObservable); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// broadphaseBounds is the union of every rect HasCollision may test for b:
+// its screen-space collision rects and, for airborne 2.5D bodies, the same
+// rects projected onto the floor.
+func broadphaseBounds(b body.Collidable) image.Rectangle {
+	var bounds image.Rectangle
+	for _, r := range collisionRects(b) {
+		bounds = bounds.Union(r)
+	}
+	if alt := altitudeOf(b); alt != 0 {
+		bounds = bounds.Union(bounds.Add(image.Pt(0, alt)))
+	}
+	return bounds
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
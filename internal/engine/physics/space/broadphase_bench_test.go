@@ -0,0 +1,129 @@
+package space
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+)
+
+// linearSpace is the pre-broadphase implementation: every query scans every
+// body. Kept here as the baseline for the benchmarks below.
+type linearSpace struct {
+	bodies map[string]contractsbody.Collidable
+}
+
+func (s *linearSpace) AddBody(b contractsbody.Collidable) { s.bodies[b.ID()] = b }
+
+func (s *linearSpace) ResolveCollisions(b contractsbody.Collidable) (touching, blocking bool) {
+	for _, other := range s.bodies {
+		if other.ID() == b.ID() || !HasCollision(b, other) {
+			continue
+		}
+		touching = true
+		if other.IsObstructive() {
+			return true, true
+		}
+	}
+	return touching, false
+}
+
+func (s *linearSpace) Query(rect image.Rectangle) []contractsbody.Collidable {
+	var out []contractsbody.Collidable
+	for _, b := range s.bodies {
+		for _, r := range b.CollisionPosition() {
+			if r.Overlaps(rect) {
+				out = append(out, b)
+				break
+			}
+		}
+	}
+	return out
+}
+
+type benchSpace interface {
+	AddBody(contractsbody.Collidable)
+	ResolveCollisions(contractsbody.Collidable) (bool, bool)
+	Query(image.Rectangle) []contractsbody.Collidable
+}
+
+// populateLevel adds a w×h tile level with a solid floor row and sparse
+// platforms, plus movers actors, and returns the movers.
+func populateLevel(s benchSpace, w, h, movers int) []*bodyphysics.ObstacleRect {
+	const tile = 16
+	for ty := 0; ty < h; ty++ {
+		for tx := 0; tx < w; tx++ {
+			if ty != h-1 && (tx+ty)%7 != 0 {
+				continue
+			}
+			s.AddBody(newTrackedObstacle(fmt.Sprintf("OBSTACLE_%d_%d", tx, ty), tx*tile, ty*tile, tile, tile, true))
+		}
+	}
+	list := make([]*bodyphysics.ObstacleRect, 0, movers)
+	for i := 0; i < movers; i++ {
+		m := newTrackedObstacle(fmt.Sprintf("actor_%d", i), (i*37)%(w*tile), (i*53)%(h*tile), 12, 20, false)
+		s.AddBody(m)
+		list = append(list, m)
+	}
+	return list
+}
+
+func benchmarkResolve(b *testing.B, s benchSpace, w, h int) {
+	movers := populateLevel(s, w, h, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m := movers[i%len(movers)]
+		x, y := m.GetPositionMin()
+		m.SetPosition(x+1, y)
+		s.ResolveCollisions(m)
+	}
+}
+
+func benchmarkQuery(b *testing.B, s benchSpace, w, h int) {
+	populateLevel(s, w, h, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x := (i * 31) % (w * 16)
+		s.Query(image.Rect(x, 0, x+48, 48))
+	}
+}
+
+var benchLevelSizes = []struct{ w, h int }{{40, 15}, {200, 60}, {600, 120}}
+
+func BenchmarkResolveCollisions(b *testing.B) {
+	for _, sz := range benchLevelSizes {
+		b.Run(fmt.Sprintf("grid/%dx%d", sz.w, sz.h), func(b *testing.B) {
+			benchmarkResolve(b, NewSpaceWithCellSize(DefaultCellSize), sz.w, sz.h)
+		})
+		b.Run(fmt.Sprintf("linear/%dx%d", sz.w, sz.h), func(b *testing.B) {
+			benchmarkResolve(b, &linearSpace{bodies: map[string]contractsbody.Collidable{}}, sz.w, sz.h)
+		})
+	}
+}
+
+func BenchmarkQuery(b *testing.B) {
+	for _, sz := range benchLevelSizes {
+		b.Run(fmt.Sprintf("grid/%dx%d", sz.w, sz.h), func(b *testing.B) {
+			benchmarkQuery(b, NewSpaceWithCellSize(DefaultCellSize), sz.w, sz.h)
+		})
+		b.Run(fmt.Sprintf("linear/%dx%d", sz.w, sz.h), func(b *testing.B) {
+			benchmarkQuery(b, &linearSpace{bodies: map[string]contractsbody.Collidable{}}, sz.w, sz.h)
+		})
+	}
+}
+
+// BenchmarkPopulate loads a whole level, as scene setup does, and reads the
+// sorted body list once.
+func BenchmarkPopulate(b *testing.B) {
+	for _, sz := range benchLevelSizes {
+		b.Run(fmt.Sprintf("%dx%d", sz.w, sz.h), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := NewSpaceWithCellSize(DefaultCellSize)
+				populateLevel(s, sz.w, sz.h, 64)
+				s.Bodies()
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package space
+
+import (
+	"fmt"
+	"image"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+)
+
+func newTrackedObstacle(id string, x, y, w, h int, obstructive bool) *bodyphysics.ObstacleRect {
+	o := bodyphysics.NewObstacleRect(bodyphysics.NewRect(x, y, w, h))
+	o.SetPosition(x, y)
+	o.SetID(id)
+	o.AddCollisionBodies()
+	o.SetIsObstructive(obstructive)
+	return o
+}
+
+func ids(list []contractsbody.Collidable) []string {
+	out := make([]string, 0, len(list))
+	for _, b := range list {
+		out = append(out, b.ID())
+	}
+	return out
+}
+
+func TestSpace_Broadphase_TracksMovedBodies(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	wall := newTrackedObstacle("wall", 0, 0, 16, 16, true)
+	mover := newTrackedObstacle("mover", 1000, 1000, 16, 16, false)
+	s.AddBody(wall)
+	s.AddBody(mover)
+
+	area := image.Rect(0, 0, 20, 20)
+	if got := ids(s.Query(area)); !reflect.DeepEqual(got, []string{"wall"}) {
+		t.Fatalf("Query before move = %v", got)
+	}
+
+	mover.SetPosition(4, 4)
+	if got := ids(s.Query(area)); !reflect.DeepEqual(got, []string{"mover", "wall"}) {
+		t.Fatalf("Query after move = %v", got)
+	}
+	if s.grid.cellRange(mover.Position()) != s.entries["mover"].cells {
+		t.Errorf("mover not re-bucketed: %v", s.entries["mover"].cells)
+	}
+
+	mover.SetPosition(-500, 300)
+	if got := ids(s.Query(area)); !reflect.DeepEqual(got, []string{"wall"}) {
+		t.Fatalf("Query after moving away = %v", got)
+	}
+}
+
+func TestSpace_Broadphase_UntrackedBodiesAlwaysChecked(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	b := newTestCollidable("plain", image.Rect(1000, 1000, 1010, 1010), false)
+	s.AddBody(b)
+	if s.entries["plain"].state != entryLoose {
+		t.Fatalf("expected body without change observer to be loose")
+	}
+
+	// Moves without notification must still be seen.
+	b.SetPosition(0, 0)
+	if got := ids(s.Query(image.Rect(0, 0, 5, 5))); !reflect.DeepEqual(got, []string{"plain"}) {
+		t.Errorf("Query = %v, want [plain]", got)
+	}
+}
+
+func TestSpace_Broadphase_HugeBodiesAreLoose(t *testing.T) {
+	s := NewSpaceWithCellSize(16)
+	s.AddBody(newTrackedObstacle("bounds", 0, 0, 16*100, 16*100, false))
+	if s.entries["bounds"].state != entryLoose {
+		t.Errorf("expected body spanning %d cells to be loose", 100*100)
+	}
+}
+
+func TestSpace_Broadphase_AltitudeChangeReindexes(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	o := newTrackedObstacle("jumper", 0, 200, 16, 16, false)
+	s.AddBody(o)
+
+	// Mirror the beat-em-up movement model: raise the body and shift its
+	// collision shapes directly, without going through SetPosition.
+	o.SetAltitude(150)
+	for _, shape := range o.CollisionShapes() {
+		x16, y16 := shape.GetPosition16()
+		shape.SetPosition16(x16, y16-150*16)
+	}
+	if got := ids(s.Query(image.Rect(0, 50, 16, 66))); !reflect.DeepEqual(got, []string{"jumper"}) {
+		t.Errorf("Query at airborne screen position = %v", got)
+	}
+}
+
+func TestSpace_RemoveBody_DetachesObserver(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	o := newTrackedObstacle("o", 0, 0, 16, 16, false)
+	s.AddBody(o)
+	s.RemoveBody(o)
+
+	o.SetPosition(50, 50)
+	if len(s.dirty) != 0 {
+		t.Errorf("removed body still reports changes to the space")
+	}
+	if len(s.grid.cells) != 0 || len(s.grid.loose) != 0 {
+		t.Errorf("grid not empty after removal: %d cells, %d loose", len(s.grid.cells), len(s.grid.loose))
+	}
+}
+
+func TestSpace_Bodies_SortedAndStable(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	for _, id := range []string{"c", "a", "b"} {
+		s.AddBody(newTrackedObstacle(id, 0, 0, 8, 8, false))
+	}
+	before := s.Bodies()
+	if got := ids(before); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Fatalf("Bodies() = %v", got)
+	}
+
+	s.RemoveBody(s.Find("b"))
+	if got := ids(s.Bodies()); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("Bodies() after remove = %v", got)
+	}
+	if got := ids(before); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("earlier Bodies() slice was mutated: %v", got)
+	}
+}
+
+// bruteForceResolve mirrors ResolveCollisions without a broadphase: every
+// other body, in ID order.
+func bruteForceResolve(s *Space, b contractsbody.Collidable) (touching, blocking bool) {
+	for _, other := range s.Bodies() {
+		if other.ID() == b.ID() || !HasCollision(b, other) {
+			continue
+		}
+		touching = true
+		if other.IsObstructive() {
+			return true, true
+		}
+	}
+	return touching, false
+}
+
+func bruteForceQuery(s *Space, rect image.Rectangle) []string {
+	out := []string{}
+	for _, b := range s.Bodies() {
+		for _, r := range b.CollisionPosition() {
+			if r.Overlaps(rect) {
+				out = append(out, b.ID())
+				break
+			}
+		}
+	}
+	return out
+}
+
+func TestSpace_Broadphase_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := NewSpaceWithCellSize(32)
+
+	var movers []*bodyphysics.ObstacleRect
+	for i := 0; i < 300; i++ {
+		o := newTrackedObstacle(fmt.Sprintf("static_%03d", i),
+			rng.Intn(800)-100, rng.Intn(600)-100, 4+rng.Intn(40), 4+rng.Intn(40), rng.Intn(2) == 0)
+		s.AddBody(o)
+	}
+	for i := 0; i < 30; i++ {
+		o := newTrackedObstacle(fmt.Sprintf("mover_%02d", i), rng.Intn(800), rng.Intn(600), 16, 16, false)
+		s.AddBody(o)
+		movers = append(movers, o)
+	}
+
+	for round := 0; round < 20; round++ {
+		for _, m := range movers {
+			x, y := m.GetPositionMin()
+			m.SetPosition(x+rng.Intn(81)-40, y+rng.Intn(81)-40)
+		}
+		for _, m := range movers {
+			gotTouch, gotBlock := s.ResolveCollisions(m)
+			wantTouch, wantBlock := bruteForceResolve(s, m)
+			if gotTouch != wantTouch || gotBlock != wantBlock {
+				t.Fatalf("round %d %s: ResolveCollisions = (%v,%v), brute force = (%v,%v)",
+					round, m.ID(), gotTouch, gotBlock, wantTouch, wantBlock)
+			}
+		}
+		x, y := rng.Intn(800)-100, rng.Intn(600)-100
+		rect := image.Rect(x, y, x+rng.Intn(200)+1, y+rng.Intn(200)+1)
+		if got, want := ids(s.Query(rect)), bruteForceQuery(s, rect); !reflect.DeepEqual(got, want) {
+			t.Fatalf("round %d: Query(%v) = %v, brute force = %v", round, rect, got, want)
+		}
+	}
+}
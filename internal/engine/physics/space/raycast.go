@@ -0,0 +1,199 @@
+package space
+
+import (
+	"image"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
+)
+
+// Raycast traces the segment from → to (FP16 world coordinates) and returns
+// the first body whose collision rects it enters. Bodies that already contain
+// from are ignored, so a cast can start inside its caster. One-way platforms
+// only stop casts that travel downward onto their top face. All math is
+// integer, so results are identical across platforms and replays.
+func (s *Space) Raycast(from, to image.Point, filter body.QueryFilter) (body.Hit, bool) {
+	return s.cast(image.Rectangle{Min: from, Max: from}, to.Sub(from), filter)
+}
+
+// Sweep moves rect by delta, both FP16 world coordinates like Raycast's, and
+// returns the first body it would touch, with the rect's top-left corner at
+// contact. Bodies rect already overlaps are ignored so a body can sweep out
+// of contact. A pixel rect such as Body.Position must be scaled with
+// fp16.To16 first.
+func (s *Space) Sweep(rect image.Rectangle, delta image.Point, filter body.QueryFilter) (body.Hit, bool) {
+	return s.cast(rect, delta, filter)
+}
+
+// cast sweeps the FP16 rect r along d. A zero-size r is a ray.
+func (s *Space) cast(r image.Rectangle, d image.Point, filter body.QueryFilter) (body.Hit, bool) {
+	s.flushDirty()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.grid == nil {
+		return body.Hit{}, false
+	}
+
+	// Pixel bounds of the swept area. image.Rectangle.Union drops empty
+	// rects, so build it by hand to cover zero-size rays.
+	end := r.Add(d)
+	area := image.Rect(
+		floorDiv(min(r.Min.X, end.Min.X), 16), floorDiv(min(r.Min.Y, end.Min.Y), 16),
+		floorDiv(max(r.Max.X, end.Max.X), 16)+1, floorDiv(max(r.Max.Y, end.Max.Y), 16)+1,
+	)
+
+	var (
+		best    body.Hit
+		bestT   fraction
+		hasBest bool
+	)
+	for _, b := range s.grid.candidates(area) {
+		if !acceptsHit(filter, b) {
+			continue
+		}
+		oneWay := isOneWay(b)
+		for _, c := range collisionRects(b) {
+			// Minkowski-expand the target by the cast rect so the cast
+			// reduces to a ray from r.Min.
+			target := image.Rect(
+				fp16.To16(c.Min.X)-r.Dx(), fp16.To16(c.Min.Y)-r.Dy(),
+				fp16.To16(c.Max.X), fp16.To16(c.Max.Y),
+			)
+			t, normal, ok := rayEnter(r.Min, d, target, !r.Empty())
+			if !ok {
+				continue
+			}
+			if oneWay && normal != image.Pt(0, -1) {
+				continue
+			}
+			if !hasBest || t.less(bestT) {
+				hasBest = true
+				bestT = t
+				best = body.Hit{
+					Body:   b,
+					Point:  image.Pt(r.Min.X+t.scale(d.X), r.Min.Y+t.scale(d.Y)),
+					Normal: normal,
+				}
+			}
+		}
+	}
+	return best, hasBest
+}
+
+// fraction is an exact rational num/den with den > 0, used for cast times so
+// comparisons never round.
+type fraction struct{ num, den int64 }
+
+func (f fraction) less(o fraction) bool { return f.num*o.den < o.num*f.den }
+
+func (f fraction) scale(v int) int { return int(int64(v) * f.num / f.den) }
+
+// rayEnter returns the time in [0, 1] at which the ray p + t·d enters rect,
+// and the normal of the face it enters through. Rays that start inside rect
+// or only run along its edge do not count. open excludes the low edge too,
+// which is what a Minkowski-expanded sweep target needs: rects that merely
+// share an edge do not overlap.
+func rayEnter(p, d image.Point, rect image.Rectangle, open bool) (fraction, image.Point, bool) {
+	if rect.Empty() {
+		return fraction{}, image.Point{}, false
+	}
+	var (
+		enter, exit fraction
+		hasEnter    bool
+		normal      image.Point
+	)
+	exit = fraction{1, 1}
+
+	axes := [2]struct {
+		p, d, lo, hi int
+		n            image.Point
+	}{
+		{p.X, d.X, rect.Min.X, rect.Max.X, image.Pt(1, 0)},
+		{p.Y, d.Y, rect.Min.Y, rect.Max.Y, image.Pt(0, 1)},
+	}
+	for _, a := range axes {
+		if a.d == 0 {
+			if a.p < a.lo || a.p >= a.hi || (open && a.p == a.lo) {
+				return fraction{}, image.Point{}, false
+			}
+			continue
+		}
+		near := fraction{int64(a.lo - a.p), int64(a.d)}.normalize()
+		far := fraction{int64(a.hi - a.p), int64(a.d)}.normalize()
+		n := a.n.Mul(-1)
+		if a.d < 0 {
+			near, far = far, near
+			n = a.n
+		}
+		if !hasEnter || enter.less(near) {
+			hasEnter = true
+			enter = near
+			normal = n
+		}
+		if far.less(exit) {
+			exit = far
+		}
+	}
+	// A negative entry time means the ray started inside.
+	if !hasEnter || enter.num < 0 || !enter.less(exit) {
+		return fraction{}, image.Point{}, false
+	}
+	return enter, normal, true
+}
+
+func (f fraction) normalize() fraction {
+	if f.den < 0 {
+		return fraction{-f.num, -f.den}
+	}
+	return f
+}
+
+// acceptsHit applies filter to a candidate body.
+func acceptsHit(filter body.QueryFilter, b body.Collidable) bool {
+	if b == nil {
+		return false
+	}
+	if filter.Self != nil && b.ID() == filter.Self.ID() {
+		return false
+	}
+	if filter.ObstructiveOnly && !b.IsObstructive() {
+		return false
+	}
+	if p, ok := b.(body.OneWayPlatform); ok && p.IsOneWay() && filter.Self != nil && p.IsPassThrough(filter.Self) {
+		return false
+	}
+	if filter.Include != nil && !filter.Include(b) {
+		return false
+	}
+	return true
+}
+
+func isOneWay(b body.Collidable) bool {
+	p, ok := b.(body.OneWayPlatform)
+	return ok && p.IsOneWay()
+}
+
+// IgnoreFaction returns a QueryFilter.Include function that skips bodies
+// belonging to faction f, resolved on the body or its owner chain. Bodies
+// without a faction (walls, floors) are kept.
+func IgnoreFaction(f combat.Faction) func(body.Collidable) bool {
+	return func(b body.Collidable) bool {
+		bf, ok := factionOf(b)
+		return !ok || bf != f
+	}
+}
+
+func factionOf(b body.Collidable) (combat.Faction, bool) {
+	if fb, ok := b.(combat.Factioned); ok {
+		return fb.Faction(), true
+	}
+	for _, owner := range []interface{}{b.Owner(), b.LastOwner()} {
+		if fb, ok := owner.(combat.Factioned); ok {
+			return fb.Faction(), true
+		}
+	}
+	return combat.FactionNeutral, false
+}
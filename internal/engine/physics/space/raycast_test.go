@@ -0,0 +1,165 @@
+package space
+
+import (
+	"image"
+	"testing"
+
+	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+)
+
+func pt16(x, y int) image.Point { return image.Pt(x*16, y*16) }
+
+func rect16(x0, y0, x1, y1 int) image.Rectangle { return image.Rect(x0*16, y0*16, x1*16, y1*16) }
+
+type factionObstacle struct {
+	*bodyphysics.ObstacleRect
+	faction combat.Faction
+}
+
+func (f *factionObstacle) Faction() combat.Faction { return f.faction }
+
+type oneWayObstacle struct {
+	*bodyphysics.ObstacleRect
+	passing map[string]bool
+}
+
+func (p *oneWayObstacle) IsOneWay() bool { return true }
+func (p *oneWayObstacle) SetPassThrough(actor contractsbody.Collidable, _ int) {
+	p.passing[actor.ID()] = true
+}
+func (p *oneWayObstacle) IsPassThrough(actor contractsbody.Collidable) bool {
+	return p.passing[actor.ID()]
+}
+func (p *oneWayObstacle) Update() {}
+
+func TestSpace_Raycast_HitsNearestFace(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	s.AddBody(newTrackedObstacle("far", 200, 0, 16, 64, true))
+	s.AddBody(newTrackedObstacle("near", 100, 0, 16, 64, true))
+
+	hit, ok := s.Raycast(pt16(0, 10), pt16(300, 10), contractsbody.QueryFilter{})
+	if !ok || hit.Body.ID() != "near" {
+		t.Fatalf("Raycast = %+v, %v; want near", hit, ok)
+	}
+	if hit.Point != pt16(100, 10) || hit.Normal != image.Pt(-1, 0) {
+		t.Errorf("hit point %v normal %v", hit.Point, hit.Normal)
+	}
+
+	// Cast the other way: enters through the right face of "far".
+	hit, ok = s.Raycast(pt16(300, 10), pt16(0, 10), contractsbody.QueryFilter{})
+	if !ok || hit.Body.ID() != "far" || hit.Point != pt16(216, 10) || hit.Normal != image.Pt(1, 0) {
+		t.Errorf("reverse Raycast = %+v, %v", hit, ok)
+	}
+}
+
+func TestSpace_Raycast_StopsShortAndMisses(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	s.AddBody(newTrackedObstacle("wall", 100, 0, 16, 16, true))
+
+	if hit, ok := s.Raycast(pt16(0, 8), pt16(99, 8), contractsbody.QueryFilter{}); ok {
+		t.Errorf("segment ending before the wall hit %v", hit.Body.ID())
+	}
+	if hit, ok := s.Raycast(pt16(0, 40), pt16(300, 40), contractsbody.QueryFilter{}); ok {
+		t.Errorf("ray passing below the wall hit %v", hit.Body.ID())
+	}
+}
+
+func TestSpace_Raycast_Filters(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	caster := newTrackedObstacle("caster", 0, 0, 16, 16, true)
+	trigger := newTrackedObstacle("trigger", 40, 0, 16, 16, false)
+	ally := &factionObstacle{ObstacleRect: newTrackedObstacle("ally", 80, 0, 16, 16, true), faction: combat.FactionEnemy}
+	wall := newTrackedObstacle("wall", 120, 0, 16, 16, true)
+	for _, b := range []contractsbody.Collidable{caster, trigger, ally, wall} {
+		s.AddBody(b)
+	}
+	from, to := pt16(8, 8), pt16(200, 8)
+
+	hit, _ := s.Raycast(from, to, contractsbody.QueryFilter{})
+	if hit.Body == nil || hit.Body.ID() != "trigger" {
+		t.Errorf("unfiltered ray should ignore the caster it starts inside and hit trigger, got %+v", hit)
+	}
+	hit, _ = s.Raycast(from, to, contractsbody.QueryFilter{ObstructiveOnly: true})
+	if hit.Body == nil || hit.Body.ID() != "ally" {
+		t.Errorf("ObstructiveOnly should skip trigger, got %+v", hit)
+	}
+	hit, _ = s.Raycast(from, to, contractsbody.QueryFilter{
+		Self:            caster,
+		ObstructiveOnly: true,
+		Include:         IgnoreFaction(combat.FactionEnemy),
+	})
+	if hit.Body == nil || hit.Body.ID() != "wall" {
+		t.Errorf("IgnoreFaction should skip ally, got %+v", hit)
+	}
+}
+
+func TestSpace_Raycast_OneWayPlatform(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	platform := &oneWayObstacle{ObstacleRect: newTrackedObstacle("platform", 0, 100, 64, 8, true), passing: map[string]bool{}}
+	s.AddBody(platform)
+
+	if _, ok := s.Raycast(pt16(32, 150), pt16(32, 50), contractsbody.QueryFilter{}); ok {
+		t.Error("upward ray should pass through a one-way platform")
+	}
+	hit, ok := s.Raycast(pt16(32, 50), pt16(32, 150), contractsbody.QueryFilter{})
+	if !ok || hit.Normal != image.Pt(0, -1) || hit.Point != pt16(32, 100) {
+		t.Errorf("downward ray should land on top, got %+v, %v", hit, ok)
+	}
+
+	actor := newTrackedObstacle("actor", 32, 40, 8, 8, false)
+	platform.SetPassThrough(actor, 2)
+	if _, ok := s.Raycast(pt16(32, 50), pt16(32, 150), contractsbody.QueryFilter{Self: actor}); ok {
+		t.Error("ray cast for an actor dropping through should ignore the platform")
+	}
+}
+
+func TestSpace_Raycast_TiesResolveByID(t *testing.T) {
+	for _, order := range [][]string{{"a", "b"}, {"b", "a"}} {
+		s := NewSpaceWithCellSize(32)
+		for _, id := range order {
+			s.AddBody(newTrackedObstacle(id, 50, 0, 16, 16, true))
+		}
+		hit, ok := s.Raycast(pt16(0, 8), pt16(100, 8), contractsbody.QueryFilter{})
+		if !ok || hit.Body.ID() != "a" {
+			t.Errorf("insert order %v: hit %+v", order, hit)
+		}
+	}
+}
+
+func TestSpace_Sweep(t *testing.T) {
+	s := NewSpaceWithCellSize(32)
+	s.AddBody(newTrackedObstacle("wall", 100, 0, 16, 64, true))
+	box := rect16(0, 10, 20, 30)
+
+	hit, ok := s.Sweep(box, pt16(200, 0), contractsbody.QueryFilter{})
+	if !ok || hit.Body.ID() != "wall" || hit.Normal != image.Pt(-1, 0) {
+		t.Fatalf("Sweep = %+v, %v", hit, ok)
+	}
+	if hit.Point != pt16(80, 10) {
+		t.Errorf("contact top-left = %v, want %v (right edge flush with wall)", hit.Point, pt16(80, 10))
+	}
+	// The rect is FP16, so a sub-pixel start still ends flush with the wall.
+	hit, ok = s.Sweep(box.Add(image.Pt(8, 0)), pt16(200, 0), contractsbody.QueryFilter{})
+	if !ok || hit.Point != pt16(80, 10) {
+		t.Errorf("sub-pixel sweep = %+v, %v; want contact at %v", hit, ok, pt16(80, 10))
+	}
+
+	touching := rect16(80, 10, 100, 30)
+	if _, ok := s.Sweep(touching, pt16(0, 20), contractsbody.QueryFilter{}); ok {
+		t.Error("sliding along a wall should not hit it")
+	}
+	if _, ok := s.Sweep(touching, pt16(-10, 0), contractsbody.QueryFilter{}); ok {
+		t.Error("moving away from a wall should not hit it")
+	}
+	hit, ok = s.Sweep(touching, pt16(5, 0), contractsbody.QueryFilter{})
+	if !ok || hit.Point != pt16(80, 10) {
+		t.Errorf("pushing into a touching wall should hit at once, got %+v, %v", hit, ok)
+	}
+
+	overlapping := rect16(105, 10, 110, 30)
+	if _, ok := s.Sweep(overlapping, pt16(50, 0), contractsbody.QueryFilter{}); ok {
+		t.Error("a rect already overlapping a body should be able to sweep out")
+	}
+}
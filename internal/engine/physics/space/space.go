@@ -10,20 +10,49 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/tilemaplayer"
 )
 
-// Space centralizes physics bodies and collision resolution.
+// Space centralizes physics bodies and collision resolution. A uniform-grid
+// broadphase (see spatialGrid) limits ResolveCollisions and Query to bodies
+// near the queried area instead of scanning every body.
 type Space struct {
 	mu                        sync.RWMutex
 	bodies                    map[string]body.Collidable
+	entries                   map[string]*gridEntry
+	grid                      *spatialGrid
 	bodiesCache               []body.Collidable
-	cacheDirty                bool
+	cacheStale                bool // bodiesCache is rebuilt on the next Bodies call
 	toBeRemoved               []body.Collidable
 	tilemapDimensionsProvider tilemaplayer.TilemapDimensionsProvider
+
+	// dirtyMu guards dirty separately from mu: change observers fire from
+	// inside OnTouch/OnBlock callbacks while mu is read-locked.
+	dirtyMu sync.Mutex
+	dirty   []*gridEntry
 }
 
 func NewSpace() body.BodiesSpace {
+	return NewSpaceWithCellSize(DefaultCellSize)
+}
+
+// NewSpaceWithCellSize creates a Space whose broadphase uses cells of the
+// given size in pixels. Non-positive sizes fall back to DefaultCellSize.
+func NewSpaceWithCellSize(cellSize int) *Space {
 	return &Space{
-		bodies:     make(map[string]body.Collidable),
-		cacheDirty: true,
+		bodies:  make(map[string]body.Collidable),
+		entries: make(map[string]*gridEntry),
+		grid:    newSpatialGrid(cellSize),
+	}
+}
+
+// init lazily sets up a zero-value Space.
+func (s *Space) init() {
+	if s.bodies == nil {
+		s.bodies = make(map[string]body.Collidable)
+	}
+	if s.entries == nil {
+		s.entries = make(map[string]*gridEntry)
+	}
+	if s.grid == nil {
+		s.grid = newSpatialGrid(DefaultCellSize)
 	}
 }
 
@@ -39,20 +68,35 @@ func (s *Space) AddBody(b body.Collidable) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if s.bodies == nil {
-		s.bodies = make(map[string]body.Collidable)
+	s.init()
+	if _, exists := s.bodies[b.ID()]; exists {
+		s.removeLocked(b.ID())
 	}
 
 	s.bodies[b.ID()] = b
-	s.cacheDirty = true
+	e := &gridEntry{body: b}
+	s.entries[b.ID()] = e
+	if o, ok := b.(body.ChangeObservable); ok {
+		o.SetChangeObserver(func() { s.markDirty(e) })
+	}
+	s.grid.insert(e)
+	s.cacheStale = true
 }
 
 func (s *Space) Clear() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	for _, e := range s.entries {
+		detachObserver(e)
+	}
 	s.bodies = make(map[string]body.Collidable)
-	s.cacheDirty = true
+	s.entries = make(map[string]*gridEntry)
+	if s.grid != nil {
+		s.grid.clear()
+	}
+	s.bodiesCache = nil
+	s.cacheStale = false
 }
 
 func (s *Space) RemoveBody(body body.Collidable) {
@@ -67,8 +111,7 @@ func (s *Space) RemoveBody(body body.Collidable) {
 		return
 	}
 
-	delete(s.bodies, body.ID())
-	s.cacheDirty = true
+	s.removeLocked(body.ID())
 }
 
 func (s *Space) QueueForRemoval(body body.Collidable) {
@@ -87,19 +130,33 @@ func (s *Space) ProcessRemovals() {
 		if b == nil {
 			continue
 		}
-		delete(s.bodies, b.ID())
+		s.removeLocked(b.ID())
 	}
 	s.toBeRemoved = nil
-	s.cacheDirty = true
 }
 
-// Bodies returns a slice of all collidable bodies in the space.
-// This method uses a cache for performance. The returned slice is a direct
-// reference to the cache and MUST NOT be modified by the caller. If modifications
-// are needed, the caller should create a copy. The slice is sorted by body ID.
+// removeLocked drops the body with id from the map and the broadphase, and
+// marks the sorted cache stale. The caller must hold mu.
+func (s *Space) removeLocked(id string) {
+	if _, ok := s.bodies[id]; !ok {
+		return
+	}
+	delete(s.bodies, id)
+	if e, ok := s.entries[id]; ok {
+		delete(s.entries, id)
+		detachObserver(e)
+		s.grid.remove(e)
+	}
+	s.cacheStale = true
+}
+
+// Bodies returns a slice of all collidable bodies in the space, sorted by
+// body ID. The returned slice MUST NOT be modified by the caller. Adding or
+// removing bodies only marks it stale; the next call sorts a new slice once,
+// so a previously returned one stays valid for iteration.
 func (s *Space) Bodies() []body.Collidable {
 	s.mu.RLock()
-	if !s.cacheDirty {
+	if !s.cacheStale {
 		defer s.mu.RUnlock()
 		return s.bodiesCache
 	}
@@ -107,38 +164,72 @@ func (s *Space) Bodies() []body.Collidable {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	// Re-check condition, as another goroutine could have updated the cache
-	// between the RUnlock and Lock.
-	if s.cacheDirty {
-		s.bodiesCache = make([]body.Collidable, 0, len(s.bodies))
+	if s.cacheStale {
+		cache := make([]body.Collidable, 0, len(s.bodies))
 		for _, b := range s.bodies {
-			if b == nil {
-				continue
-			}
-			s.bodiesCache = append(s.bodiesCache, b)
+			cache = append(cache, b)
 		}
+		sort.Slice(cache, func(i, j int) bool { return cache[i].ID() < cache[j].ID() })
+		s.bodiesCache = cache
+		s.cacheStale = false
+	}
+	return s.bodiesCache
+}
 
-		// Sort the bodies by ID
-		sort.Slice(s.bodiesCache, func(i, j int) bool {
-			return s.bodiesCache[i].ID() < s.bodiesCache[j].ID()
-		})
+// markDirty records that e's body changed. Called from the body's change
+// observer; the grid is updated on the next query.
+func (s *Space) markDirty(e *gridEntry) {
+	s.dirtyMu.Lock()
+	defer s.dirtyMu.Unlock()
+	if e.dirty {
+		return
+	}
+	e.dirty = true
+	s.dirty = append(s.dirty, e)
+}
 
-		s.cacheDirty = false
+// flushDirty re-buckets every body that changed since the last query.
+func (s *Space) flushDirty() {
+	s.dirtyMu.Lock()
+	pending := s.dirty
+	s.dirty = nil
+	for _, e := range pending {
+		e.dirty = false
+	}
+	s.dirtyMu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range pending {
+		// Skip entries removed after they were marked.
+		if s.entries[e.body.ID()] != e {
+			continue
+		}
+		s.grid.update(e)
 	}
-	return s.bodiesCache
 }
 
-// ResolveCollisions compare a body parameter with all bodies in space.
-// Returns boolean values if is touching or blocking.
+// ResolveCollisions compare a body parameter with the nearby bodies in space.
+// Returns boolean values if is touching or blocking. Candidates are visited in
+// ID order, so the first blocking body is deterministic.
 func (s *Space) ResolveCollisions(body body.Collidable) (touching bool, blocking bool) {
 	if body == nil {
 		return false, false
 	}
 
+	s.flushDirty()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	for _, other := range s.bodies {
+	if s.grid == nil {
+		return false, false
+	}
+
+	for _, other := range s.grid.candidates(broadphaseBounds(body)) {
 		if other == nil || other.ID() == body.ID() {
 			continue
 		}
@@ -147,12 +238,12 @@ func (s *Space) ResolveCollisions(body body.Collidable) (touching bool, blocking
 			continue
 		}
 
-		body = s.Find(body.ID())
+		body = s.bodies[body.ID()]
 		body.OnTouch(other)
 		other.OnTouch(body)
 		touching = true
 
-		if other.IsObstructive() {
+		if other.IsObstructive() && !passesOneWay(body, other) && !isSlope(other) {
 			body.OnBlock(other)
 			other.OnBlock(body)
 			blocking = true
@@ -175,14 +266,21 @@ func (s *Space) Find(id string) body.Collidable {
 	return b
 }
 
-// Query returns all bodies that overlap with the given rectangle.
+// Query returns all bodies that overlap with the given rectangle, sorted by
+// ID.
 func (s *Space) Query(rect image.Rectangle) []body.Collidable {
+	s.flushDirty()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	if s.grid == nil {
+		return nil
+	}
+
 	var result []body.Collidable
 
-	for _, b := range s.bodies {
+	for _, b := range s.grid.candidates(rect) {
 		if b == nil {
 			continue
 		}
@@ -204,6 +302,12 @@ func (s *Space) Query(rect image.Rectangle) []body.Collidable {
 	return result
 }
 
+func detachObserver(e *gridEntry) {
+	if o, ok := e.body.(body.ChangeObservable); ok {
+		o.SetChangeObserver(nil)
+	}
+}
+
 // collisionRects returns the collision rectangles for a body.
 // If no specific collision shapes are defined, it falls back to the body's own position.
 // This is consistent with the pattern used in CheckGround.
@@ -263,6 +367,34 @@ func collisionRects(b body.Collidable) []image.Rectangle {
 	return rects
 }
 
+// passesOneWay reports whether b may overlap the one-way platform other
+// instead of being blocked. A one-way platform only blocks bodies entering it
+// through its top row, i.e. landing on it from above, unless they were told
+// to drop through.
+func passesOneWay(b, other body.Collidable) bool {
+	p, ok := other.(body.OneWayPlatform)
+	if !ok || !p.IsOneWay() {
+		return false
+	}
+	if p.IsPassThrough(b) {
+		return true
+	}
+	top := other.Position().Min.Y
+	for _, r := range collisionRects(b) {
+		if r.Max.Y > top+1 {
+			return true
+		}
+	}
+	return false
+}
+
+// isSlope reports whether b is a slope. Slopes only report touches here;
+// movement models walk bodies along their surface.
+func isSlope(b body.Collidable) bool {
+	s, ok := b.(body.Slope)
+	return ok && s.IsSlope()
+}
+
 // rectSlicesOverlap reports whether any rect in ra overlaps any rect in rb.
 func rectSlicesOverlap(ra, rb []image.Rectangle) bool {
 	for _, r := range ra {
@@ -331,3 +331,29 @@ func TestHasCollisionDepthLane(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveCollisionsOneWayBlocksOnlyFromAbove(t *testing.T) {
+	sp := NewSpace()
+	platform := newTrackedObstacle("platform", 0, 20, 32, 8, true)
+	platform.SetOneWay(true)
+	sp.AddBody(platform)
+
+	// Feet one pixel into the top row: landing from above.
+	landing := newTestCollidable("landing", image.Rect(4, 11, 12, 21), false)
+	sp.AddBody(landing)
+	if _, blocking := sp.ResolveCollisions(landing); !blocking {
+		t.Error("one-way platform did not block a body landing on it")
+	}
+
+	// Deeper overlap: jumping up through it or walking in from the side.
+	rising := newTestCollidable("rising", image.Rect(4, 16, 12, 26), false)
+	sp.AddBody(rising)
+	if touching, blocking := sp.ResolveCollisions(rising); !touching || blocking {
+		t.Errorf("rising body touching=%v blocking=%v, want touch only", touching, blocking)
+	}
+
+	platform.SetPassThrough(landing, 2)
+	if _, blocking := sp.ResolveCollisions(landing); blocking {
+		t.Error("dropping-through body was blocked")
+	}
+}
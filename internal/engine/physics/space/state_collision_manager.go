@@ -2,6 +2,7 @@ package space
 
 import (
 	"fmt"
+	"image"
 	"time"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
@@ -23,10 +24,31 @@ type StateBasedCollisioner[T StateEnum] interface {
 	Scale() float64
 }
 
+// AnimationFramer is implemented by owners that report which animation frame
+// of their state is shown. Without it, every state stays on frame 0.
+type AnimationFramer interface {
+	AnimationFrame() int
+}
+
+// frameBoxes holds the hurtboxes and hitboxes of the frames start..end of a
+// state's animation.
+type frameBoxes struct {
+	start, end int
+	hurtboxes  []body.Collidable
+	hitboxes   []image.Rectangle
+}
+
 // StateCollisionManager manages state-based collision bodies for an entity.
 type StateCollisionManager[T StateEnum] struct {
 	owner           StateBasedCollisioner[T]
 	collisionBodies map[T][]body.Collidable
+	frameBoxes      map[T][]frameBoxes
+
+	// appliedState and appliedBoxes record which hurtboxes are installed:
+	// the index into frameBoxes[appliedState], or -1 for the state's
+	// collision rects.
+	appliedState T
+	appliedBoxes int
 }
 
 // NewStateCollisionManager creates a new manager for state-based collisions.
@@ -34,6 +56,8 @@ func NewStateCollisionManager[T StateEnum](owner StateBasedCollisioner[T]) *Stat
 	return &StateCollisionManager[T]{
 		owner:           owner,
 		collisionBodies: make(map[T][]body.Collidable),
+		frameBoxes:      make(map[T][]frameBoxes),
+		appliedBoxes:    -1,
 	}
 }
 
@@ -42,10 +66,96 @@ func (m *StateCollisionManager[T]) AddCollisionRect(state T, rect body.Collidabl
 	m.collisionBodies[state] = append(m.collisionBodies[state], rect)
 }
 
-// RefreshCollisions updates the entity's collision bodies based on its current state.
+// AddFrameBoxes associates hurtboxes and hitboxes with the animation frames
+// start..end of a state. Hurtboxes replace the state's collision rects on
+// those frames. Hitbox rectangles are relative to the owner's position.
+func (m *StateCollisionManager[T]) AddFrameBoxes(state T, start, end int, hurtboxes []body.Collidable, hitboxes []image.Rectangle) {
+	m.frameBoxes[state] = append(m.frameBoxes[state], frameBoxes{
+		start:     start,
+		end:       end,
+		hurtboxes: hurtboxes,
+		hitboxes:  hitboxes,
+	})
+}
+
+// HasFrameHitboxes reports whether any frame of state has hitboxes.
+func (m *StateCollisionManager[T]) HasFrameHitboxes(state T) bool {
+	for _, fb := range m.frameBoxes[state] {
+		if len(fb.hitboxes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FrameHitboxes returns the hitboxes of the current state and animation
+// frame, relative to the owner's position and scaled like its hurtboxes.
+func (m *StateCollisionManager[T]) FrameHitboxes() []image.Rectangle {
+	frame := m.frame()
+	scale := m.owner.Scale()
+	var res []image.Rectangle
+	for _, fb := range m.frameBoxes[m.owner.State()] {
+		if frame < fb.start || frame > fb.end {
+			continue
+		}
+		for _, r := range fb.hitboxes {
+			if scale != 0 && scale != 1.0 {
+				r = image.Rect(
+					int(float64(r.Min.X)*scale), int(float64(r.Min.Y)*scale),
+					int(float64(r.Max.X)*scale), int(float64(r.Max.Y)*scale),
+				)
+			}
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// RefreshFrameCollisions swaps in the hurtboxes of the current animation
+// frame when they differ from the installed ones. Call once per frame.
+func (m *StateCollisionManager[T]) RefreshFrameCollisions() {
+	state := m.owner.State()
+	if len(m.frameBoxes[state]) == 0 {
+		return
+	}
+	if state == m.appliedState && m.hurtboxIndex(state) == m.appliedBoxes {
+		return
+	}
+	m.RefreshCollisions()
+}
+
+// frame returns the owner's current animation frame.
+func (m *StateCollisionManager[T]) frame() int {
+	if f, ok := m.owner.(AnimationFramer); ok {
+		return f.AnimationFrame()
+	}
+	return 0
+}
+
+// hurtboxIndex returns the index of the frame boxes whose hurtboxes apply to
+// the current frame of state, or -1 when its collision rects apply.
+func (m *StateCollisionManager[T]) hurtboxIndex(state T) int {
+	frame := m.frame()
+	for i, fb := range m.frameBoxes[state] {
+		if len(fb.hurtboxes) > 0 && frame >= fb.start && frame <= fb.end {
+			return i
+		}
+	}
+	return -1
+}
+
+// RefreshCollisions updates the entity's collision bodies based on its current
+// state and, when the state has frame boxes, its animation frame.
 func (m *StateCollisionManager[T]) RefreshCollisions() {
 	currentState := m.owner.State()
-	if rects, ok := m.collisionBodies[currentState]; ok {
+	rects, ok := m.collisionBodies[currentState]
+	index := m.hurtboxIndex(currentState)
+	if index >= 0 {
+		rects, ok = m.frameBoxes[currentState][index].hurtboxes, true
+	}
+	if ok {
+		m.appliedState = currentState
+		m.appliedBoxes = index
 		m.owner.ClearCollisions()
 		x, y := m.owner.GetPositionMin()
 		scale := m.owner.Scale()
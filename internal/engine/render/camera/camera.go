@@ -35,6 +35,17 @@ type Controller struct {
 	VerticalOnlyUpward bool
 	lastTargetY        float64
 	initialized        bool
+
+	zones     []Zone
+	zoneBlend zoneBlend
+	lookAhead LookAhead
+	lead      float64
+	targets   []body.Body
+	multi     MultiTarget
+	fitZoom   float64
+
+	lock             *image.Rectangle
+	scrollX, scrollY float64
 }
 
 func NewController(x, y float64) *Controller {
@@ -140,8 +151,23 @@ func (c *Controller) SetVerticalOnlyUpward(v bool) {
 }
 
 func (c *Controller) Update() {
-	var targetX, targetY float64
-	if c.isFollowing && c.followTarget != nil {
+	if c.AutoScrolling() {
+		c.updateAutoScroll()
+		return
+	}
+	if !c.isFollowing || (c.followTarget == nil && len(c.targets) == 0) {
+		targetX, targetY := c.clamp(c.centerX, c.centerY, 1, c.lockedBounds(c.bounds))
+		c.cam.LookAt(targetX, targetY)
+		// Update stored center to match actual camera position
+		c.centerX = targetX
+		c.centerY = targetY
+		return
+	}
+
+	var targetX, targetY, fitZoom float64
+	if len(c.targets) > 0 {
+		targetX, targetY, fitZoom = c.frameTargets()
+	} else {
 		x, y := c.followTarget.GetPositionMin()
 		// Use center of target for following
 		w, h := c.followTarget.GetShape().Width(), c.followTarget.GetShape().Height()
@@ -157,43 +183,61 @@ func (c *Controller) Update() {
 			}
 			c.initialized = true
 		}
-	} else {
-		targetX = c.centerX
-		targetY = c.centerY
 	}
 
-	if c.bounds != nil {
-		// Calculate viewport half-dimensions
-		halfW := c.screenWidth / 2
-		halfH := c.screenHeight / 2
+	zone := c.zoneAt(targetX, targetY)
+	targetX += c.updateLookAhead()
+	x, y, zoom := c.frame(zone, targetX, targetY, fitZoom)
+	x, y, zoom = c.blend(zone, x, y, zoom)
 
-		// Calculate min and max center positions
-		minX := float64(c.bounds.Min.X) + halfW
-		maxX := float64(c.bounds.Max.X) - halfW
-		minY := float64(c.bounds.Min.Y) + halfH
-		maxY := float64(c.bounds.Max.Y) - halfH
+	if c.managesZoom() {
+		c.cam.ZoomFactor = zoom
+	}
+	c.cam.LookAt(x, y)
+	c.centerX = x
+	c.centerY = y
+}
 
-		// Clamp targetX
-		if targetX < minX {
-			targetX = minX
-		}
-		if targetX > maxX {
-			targetX = maxX
-		}
+// clamp keeps a camera center with the given zoom inside bounds, if any.
+func (c *Controller) clamp(targetX, targetY, zoom float64, bounds *image.Rectangle) (float64, float64) {
+	if bounds == nil {
+		return targetX, targetY
+	}
+	// Calculate viewport half-dimensions
+	halfW := c.screenWidth / 2 / zoom
+	halfH := c.screenHeight / 2 / zoom
+
+	// Calculate min and max center positions
+	minX := float64(bounds.Min.X) + halfW
+	maxX := float64(bounds.Max.X) - halfW
+	minY := float64(bounds.Min.Y) + halfH
+	maxY := float64(bounds.Max.Y) - halfH
+
+	// Clamp targetX
+	if targetX < minX {
+		targetX = minX
+	}
+	if targetX > maxX {
+		targetX = maxX
+	}
 
-		// Clamp targetY
-		if targetY < minY {
-			targetY = minY
-		}
-		if targetY > maxY {
-			targetY = maxY
-		}
+	// Clamp targetY
+	if targetY < minY {
+		targetY = minY
+	}
+	if targetY > maxY {
+		targetY = maxY
 	}
+	return targetX, targetY
+}
 
-	c.cam.LookAt(targetX, targetY)
-	// Update stored center to match actual camera position
-	c.centerX = targetX
-	c.centerY = targetY
+// Zoom returns the camera zoom factor: above 1 shows less of the world,
+// below 1 shows more.
+func (c *Controller) Zoom() float64 {
+	if c.cam.ZoomFactor <= 0 {
+		return 1
+	}
+	return c.cam.ZoomFactor
 }
 
 func (c *Controller) Draw(
@@ -202,6 +246,14 @@ func (c *Controller) Draw(
 	c.cam.Draw(src, options, dst)
 }
 
+// hitboxer is implemented by bodies with hitboxes authored per animation
+// frame, such as actors.Character.
+type hitboxer interface {
+	Hitboxes() []image.Rectangle
+}
+
+// DrawCollisionBox renders the collision rects of b, red when obstructive and
+// green otherwise, followed by its current hitboxes in orange.
 func (c *Controller) DrawCollisionBox(screen *ebiten.Image, b body.Collidable) {
 	isObstructive := b.IsObstructive()
 	for _, rect := range b.CollisionPosition() {
@@ -231,6 +283,11 @@ func (c *Controller) DrawCollisionBox(screen *ebiten.Image, b body.Collidable) {
 			c.Draw(collisionBoxImage, opts, screen)
 		}
 	}
+	if hb, ok := b.(hitboxer); ok {
+		for _, rect := range hb.Hitboxes() {
+			c.DrawHitboxRect(screen, rect)
+		}
+	}
 }
 
 // DrawHitboxRect renders an orange debug rectangle in world space.
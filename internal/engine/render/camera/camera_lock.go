@@ -0,0 +1,125 @@
+package camera
+
+import (
+	"image"
+	"math"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
+)
+
+// Lock keeps the camera inside area until Unlock, whatever it follows: the
+// beat 'em up arena that holds the screen until a wave is beaten. The lock
+// replaces the controller and zone bounds while it lasts.
+func (c *Controller) Lock(area image.Rectangle) {
+	c.lock = &area
+}
+
+// Unlock releases a Lock, so the camera goes back to following freely.
+func (c *Controller) Unlock() {
+	c.lock = nil
+}
+
+// Locked returns the area the camera is locked to, if any.
+func (c *Controller) Locked() (image.Rectangle, bool) {
+	if c.lock == nil {
+		return image.Rectangle{}, false
+	}
+	return *c.lock, true
+}
+
+// lockedBounds returns the lock area when locked, or bounds otherwise.
+func (c *Controller) lockedBounds(bounds *image.Rectangle) *image.Rectangle {
+	if c.lock != nil {
+		return c.lock
+	}
+	return bounds
+}
+
+// SetAutoScroll moves the camera at a constant speed, in pixels per frame,
+// from where it is now, ignoring the follow target, until it reaches the
+// bounds or StopAutoScroll is called. Zero speeds stop it.
+func (c *Controller) SetAutoScroll(vx, vy float64) {
+	if !c.AutoScrolling() {
+		c.centerX, c.centerY = c.cam.Center()
+	}
+	c.scrollX, c.scrollY = vx, vy
+}
+
+// StopAutoScroll stops auto-scrolling; the camera follows its target again.
+func (c *Controller) StopAutoScroll() {
+	c.SetAutoScroll(0, 0)
+}
+
+// AutoScrolling reports whether the camera is auto-scrolling.
+func (c *Controller) AutoScrolling() bool {
+	return c.scrollX != 0 || c.scrollY != 0
+}
+
+// updateAutoScroll advances an auto-scrolling camera by one frame.
+func (c *Controller) updateAutoScroll() {
+	x, y := c.clamp(c.centerX+c.scrollX, c.centerY+c.scrollY, c.Zoom(), c.lockedBounds(c.bounds))
+	c.cam.LookAt(x, y)
+	c.centerX = x
+	c.centerY = y
+}
+
+// VisibleRect returns the world area the camera shows.
+func (c *Controller) VisibleRect() image.Rectangle {
+	x, y := c.cam.Center()
+	zoom := c.Zoom()
+	hw, hh := c.screenWidth/2/zoom, c.screenHeight/2/zoom
+	return image.Rect(
+		int(math.Floor(x-hw)), int(math.Floor(y-hh)),
+		int(math.Ceil(x+hw)), int(math.Ceil(y+hh)),
+	)
+}
+
+// KeepInView pushes b back inside the visible area while the camera is
+// locked or auto-scrolling, so the player can't walk out of an arena or be
+// left behind by the scroll. It pushes horizontally, and also vertically
+// while auto-scrolling on Y. It reports whether b is crushed: it had to be
+// pushed but a wall in space stopped it. Scenes call it every frame after
+// moving b; it does nothing while the camera moves freely.
+func (c *Controller) KeepInView(b body.Collidable, space body.BodiesSpace) bool {
+	if c.lock == nil && !c.AutoScrolling() {
+		return false
+	}
+	view, pos := c.VisibleRect(), b.Position()
+	var dx, dy int
+	if pos.Dx() <= view.Dx() {
+		dx = pushInto(pos.Min.X, pos.Max.X, view.Min.X, view.Max.X)
+	}
+	if c.scrollY != 0 && pos.Dy() <= view.Dy() {
+		dy = pushInto(pos.Min.Y, pos.Max.Y, view.Min.Y, view.Max.Y)
+	}
+	if dx == 0 && dy == 0 {
+		return false
+	}
+	if space == nil {
+		b.SetPosition(pos.Min.X+dx, pos.Min.Y+dy)
+		return false
+	}
+	crushed := false
+	if dx != 0 {
+		_, _, blocked := b.ApplyValidPosition(fp16.To16(dx), true, space)
+		crushed = crushed || blocked
+	}
+	if dy != 0 {
+		_, _, blocked := b.ApplyValidPosition(fp16.To16(dy), false, space)
+		crushed = crushed || blocked
+	}
+	return crushed
+}
+
+// pushInto returns how far the span lo..hi must move to lie inside
+// viewLo..viewHi, which is at least as long.
+func pushInto(lo, hi, viewLo, viewHi int) int {
+	switch {
+	case lo < viewLo:
+		return viewLo - lo
+	case hi > viewHi:
+		return viewHi - hi
+	}
+	return 0
+}
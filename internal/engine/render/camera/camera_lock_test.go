@@ -0,0 +1,131 @@
+package camera
+
+import (
+	"image"
+	"testing"
+
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
+)
+
+func TestControllerLockHoldsCameraUntilUnlock(t *testing.T) {
+	ctrl, _ := newFollowingController(t)
+	ctrl.Lock(image.Rect(0, 0, 400, 240))
+
+	ctrl.Update()
+	if x, _ := ctrl.GetCenter(); x != 240 {
+		t.Errorf("locked: center x %v, want 240", x)
+	}
+	if area, ok := ctrl.Locked(); !ok || area != image.Rect(0, 0, 400, 240) {
+		t.Errorf("Locked() = %v, %v", area, ok)
+	}
+
+	ctrl.Unlock()
+	ctrl.Update()
+	if x, _ := ctrl.GetCenter(); x != 500 {
+		t.Errorf("unlocked: center x %v, want 500", x)
+	}
+	if _, ok := ctrl.Locked(); ok {
+		t.Error("still locked after Unlock")
+	}
+}
+
+func TestControllerAutoScrollStopsAtBounds(t *testing.T) {
+	ctrl, _ := newFollowingController(t)
+	bounds := image.Rect(0, 0, 600, 240)
+	ctrl.SetBounds(&bounds)
+	ctrl.SetCenter(160, 120)
+	ctrl.SetAutoScroll(2, 0)
+
+	for range 10 {
+		ctrl.Update()
+	}
+	if x, _ := ctrl.GetCenter(); x != 180 {
+		t.Errorf("after 10 frames: center x %v, want 180", x)
+	}
+	for range 200 {
+		ctrl.Update()
+	}
+	if x, _ := ctrl.GetCenter(); x != 440 {
+		t.Errorf("at the end: center x %v, want 440", x)
+	}
+	if got := ctrl.VisibleRect(); got != image.Rect(280, 0, 600, 240) {
+		t.Errorf("VisibleRect() = %v", got)
+	}
+
+	ctrl.StopAutoScroll()
+	if ctrl.AutoScrolling() {
+		t.Error("still auto-scrolling")
+	}
+}
+
+func TestControllerKeepInViewPushesAndCrushes(t *testing.T) {
+	ctrl, _ := newFollowingController(t)
+	ctrl.SetCenter(260, 120) // view spans x 100..420
+
+	player := bodyphysics.NewCollidableBodyFromRect(bodyphysics.NewRect(0, 0, 16, 16))
+	player.SetID("player")
+	player.SetPosition(80, 100)
+	sp := space.NewSpace()
+	sp.AddBody(player)
+
+	if ctrl.KeepInView(player, sp) {
+		t.Fatal("free camera crushed the player")
+	}
+	if x, _ := player.GetPositionMin(); x != 80 {
+		t.Fatalf("free camera moved the player to x %d", x)
+	}
+
+	ctrl.SetAutoScroll(1, 0)
+	if ctrl.KeepInView(player, sp) {
+		t.Error("pushed with room to move, but crushed")
+	}
+	if x, _ := player.GetPositionMin(); x != 100 {
+		t.Errorf("pushed to x %d, want 100", x)
+	}
+
+	wall := bodyphysics.NewCollidableBodyFromRect(bodyphysics.NewRect(0, 0, 16, 64))
+	wall.SetID("wall")
+	wall.SetIsObstructive(true)
+	wall.SetPosition(96, 80)
+	sp.AddBody(wall)
+	player.SetPosition(80, 100)
+	if !ctrl.KeepInView(player, sp) {
+		t.Error("pinned against a wall, but not crushed")
+	}
+}
+
+func TestControllerKeepInViewPushesVerticallyOnlyWhenScrollingY(t *testing.T) {
+	ctrl, _ := newFollowingController(t)
+	ctrl.SetCenter(260, 120) // view spans y 0..240
+
+	player := bodyphysics.NewCollidableBodyFromRect(bodyphysics.NewRect(0, 0, 16, 16))
+	player.SetID("player")
+	player.SetPosition(200, 250)
+	sp := space.NewSpace()
+	sp.AddBody(player)
+
+	ctrl.SetAutoScroll(1, 0)
+	ctrl.KeepInView(player, sp)
+	if _, y := player.GetPositionMin(); y != 250 {
+		t.Fatalf("horizontal scroll moved the player to y %d", y)
+	}
+
+	ctrl.SetAutoScroll(0, -1)
+	if ctrl.KeepInView(player, sp) {
+		t.Error("pushed with room to move, but crushed")
+	}
+	if _, y := player.GetPositionMin(); y != 224 {
+		t.Errorf("pushed to y %d, want 224", y)
+	}
+
+	ceiling := bodyphysics.NewCollidableBodyFromRect(bodyphysics.NewRect(0, 0, 64, 16))
+	ceiling.SetID("ceiling")
+	ceiling.SetIsObstructive(true)
+	ceiling.SetPosition(180, 220)
+	sp.AddBody(ceiling)
+	player.SetPosition(200, 250)
+	if !ctrl.KeepInView(player, sp) {
+		t.Error("pinned under a ceiling, but not crushed")
+	}
+}
@@ -0,0 +1,136 @@
+package camera
+
+import (
+	"image"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
+)
+
+// defaultLookAheadSpeed is the fraction of the way to its goal the look-ahead
+// lead moves each frame when LookAhead.Speed is unset.
+const defaultLookAheadSpeed = 0.05
+
+// fitZoomSpeed is the fraction of the way to the zoom that fits all targets
+// the multi-target zoom moves each frame.
+const fitZoomSpeed = 0.1
+
+// LookAhead shifts the view horizontally ahead of the followed body, so the
+// player sees more of where they are heading.
+type LookAhead struct {
+	// Distance is how far ahead, in pixels, the view leads in the direction
+	// the body faces.
+	Distance float64
+	// VelocityScale adds this many pixels of lead per pixel per frame of
+	// horizontal velocity.
+	VelocityScale float64
+	// Speed is the fraction of the way to its goal the lead moves each
+	// frame, so turning around pans smoothly. 0 means 0.05.
+	Speed float64
+}
+
+// SetLookAhead configures look-ahead for the single follow target. The zero
+// LookAhead turns it off.
+func (c *Controller) SetLookAhead(l LookAhead) {
+	c.lookAhead = l
+	if l == (LookAhead{}) {
+		c.lead = 0
+	}
+}
+
+// updateLookAhead moves the look-ahead lead towards its goal and returns it.
+func (c *Controller) updateLookAhead() float64 {
+	l := c.lookAhead
+	if l == (LookAhead{}) || len(c.targets) > 0 {
+		return 0
+	}
+	mv, ok := c.followTarget.(body.Movable)
+	if !ok {
+		return 0
+	}
+	dir := 1.0
+	if mv.FaceDirection() == animation.FaceDirectionLeft {
+		dir = -1
+	}
+	vx16, _ := mv.Velocity()
+	goal := dir*l.Distance + float64(vx16)/float64(fp16.To16(1))*l.VelocityScale
+
+	speed := l.Speed
+	if speed <= 0 {
+		speed = defaultLookAheadSpeed
+	}
+	c.lead += (goal - c.lead) * min(speed, 1)
+	return c.lead
+}
+
+// MultiTarget configures how a camera framing several targets zooms.
+type MultiTarget struct {
+	// Padding is the margin, in pixels, kept around the targets.
+	Padding float64
+	// MinZoom is how far the camera may zoom out to fit the targets; 0
+	// means 0.5.
+	MinZoom float64
+	// MaxZoom is how far it may zoom in when they are close together; 0
+	// means 1, never closer than normal.
+	MaxZoom float64
+}
+
+// SetTargets makes a following camera frame all the given bodies at once,
+// centering on them and zooming out as far as MultiTarget allows to fit
+// them, e.g. every enemy in a beat 'em up arena plus the player. Calling it
+// with no bodies goes back to the single follow target, and outside zones
+// to the normal zoom.
+func (c *Controller) SetTargets(targets ...body.Body) {
+	if len(targets) == 0 && len(c.targets) > 0 && len(c.zones) == 0 {
+		c.cam.ZoomFactor = 1
+	}
+	c.targets = targets
+	if len(targets) == 0 {
+		c.fitZoom = 0
+	}
+}
+
+// Targets returns the bodies the camera is framing, if any.
+func (c *Controller) Targets() []body.Body {
+	return c.targets
+}
+
+// SetMultiTarget configures multi-target framing.
+func (c *Controller) SetMultiTarget(m MultiTarget) {
+	c.multi = m
+}
+
+// frameTargets returns the center of the targets' bounding box and the zoom
+// that fits it on screen.
+func (c *Controller) frameTargets() (float64, float64, float64) {
+	var box image.Rectangle
+	for _, t := range c.targets {
+		if t != nil {
+			box = box.Union(t.Position())
+		}
+	}
+	pad := c.multi.Padding
+	w, h := float64(box.Dx())+2*pad, float64(box.Dy())+2*pad
+	x := float64(box.Min.X) + float64(box.Dx())/2
+	y := float64(box.Min.Y) + float64(box.Dy())/2
+
+	minZoom, maxZoom := c.multi.MinZoom, c.multi.MaxZoom
+	if minZoom <= 0 {
+		minZoom = 0.5
+	}
+	if maxZoom <= 0 {
+		maxZoom = 1
+	}
+	zoom := maxZoom
+	if w > 0 && h > 0 {
+		zoom = min(c.screenWidth/w, c.screenHeight/h, maxZoom)
+	}
+	zoom = max(zoom, minZoom)
+	// Ease the zoom so targets spreading out or bunching up don't pump it.
+	if c.fitZoom > 0 {
+		zoom = c.fitZoom + (zoom-c.fitZoom)*fitZoomSpeed
+	}
+	c.fitZoom = zoom
+	return x, y, zoom
+}
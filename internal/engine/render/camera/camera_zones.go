@@ -0,0 +1,134 @@
+package camera
+
+import (
+	"image"
+)
+
+// Zone is an area of the level that changes how a following camera frames
+// its target while the target is inside it: a boss room with tighter
+// bounds, a vista that zooms out, a corridor that looks further up.
+type Zone struct {
+	// Area is where the zone applies, tested against the target's center.
+	Area image.Rectangle
+	// Bounds, when set, replaces the controller bounds inside the zone.
+	Bounds *image.Rectangle
+	// Zoom is the zoom factor inside the zone; 0 means 1.
+	Zoom float64
+	// OffsetX and OffsetY shift the view away from the target, in pixels.
+	OffsetX, OffsetY float64
+	// BlendFrames is how many frames the camera takes to move from the
+	// previous framing to this zone's, and back out of it. 0 cuts.
+	BlendFrames int
+}
+
+// zoneBlend tracks the transition between the framings of two zones. The
+// camera eases from where it was when the zone changed, so entering a new
+// zone halfway through a blend doesn't jump.
+type zoneBlend struct {
+	zone          int
+	frame, frames int
+	fromX, fromY  float64
+	fromZoom      float64
+	lastX, lastY  float64
+	lastZoom      float64
+	started       bool
+}
+
+// SetZones sets the camera zones, replacing any previous ones. When zones
+// overlap, the first one containing the target wins.
+func (c *Controller) SetZones(zones []Zone) {
+	c.zones = zones
+	c.zoneBlend = zoneBlend{zone: -1}
+}
+
+// Zones returns the camera zones.
+func (c *Controller) Zones() []Zone {
+	return c.zones
+}
+
+// ActiveZone returns the zone the target is in, or nil.
+func (c *Controller) ActiveZone() *Zone {
+	if !c.zoneBlend.started || c.zoneBlend.zone < 0 {
+		return nil
+	}
+	return &c.zones[c.zoneBlend.zone]
+}
+
+// zoneAt returns the index of the first zone containing (x, y), or -1.
+func (c *Controller) zoneAt(x, y float64) int {
+	p := image.Pt(int(x), int(y))
+	for i, z := range c.zones {
+		if p.In(z.Area) {
+			return i
+		}
+	}
+	return -1
+}
+
+// frame returns the camera center and zoom for a target at (x, y) under the
+// given zone's framing, or the controller's own outside zones; a Lock
+// overrides both bounds. A positive fitZoom, from framing several targets,
+// caps the zoom so they all fit.
+func (c *Controller) frame(zone int, x, y, fitZoom float64) (float64, float64, float64) {
+	zoom, bounds := 1.0, c.bounds
+	if zone >= 0 {
+		z := c.zones[zone]
+		if z.Zoom > 0 {
+			zoom = z.Zoom
+		}
+		if z.Bounds != nil {
+			bounds = z.Bounds
+		}
+		x += z.OffsetX
+		y += z.OffsetY
+	}
+	if fitZoom > 0 {
+		zoom = min(zoom, fitZoom)
+	}
+	x, y = c.clamp(x, y, zoom, c.lockedBounds(bounds))
+	return x, y, zoom
+}
+
+// blend eases from the framing in effect when the target last changed zone
+// to the current one, over the BlendFrames of the zone entered, or of the
+// zone left when leaving to no zone.
+func (c *Controller) blend(zone int, x, y, zoom float64) (float64, float64, float64) {
+	b := &c.zoneBlend
+	if !b.started {
+		*b = zoneBlend{zone: zone, started: true}
+	} else if zone != b.zone {
+		frames := 0
+		if zone >= 0 {
+			frames = c.zones[zone].BlendFrames
+		} else if b.zone >= 0 {
+			frames = c.zones[b.zone].BlendFrames
+		}
+		b.zone, b.frame, b.frames = zone, 0, frames
+		b.fromX, b.fromY, b.fromZoom = b.lastX, b.lastY, b.lastZoom
+	}
+	if b.frame < b.frames {
+		b.frame++
+		t := smoothStep(float64(b.frame) / float64(b.frames))
+		x = lerp(b.fromX, x, t)
+		y = lerp(b.fromY, y, t)
+		zoom = lerp(b.fromZoom, zoom, t)
+	}
+	b.lastX, b.lastY, b.lastZoom = x, y, zoom
+	return x, y, zoom
+}
+
+// managesZoom reports whether Update drives the zoom factor. Without zones
+// or several targets, the zoom is left alone for CamDebug and callers of
+// Kamera.
+func (c *Controller) managesZoom() bool {
+	return len(c.zones) > 0 || len(c.targets) > 0
+}
+
+func lerp(from, to, t float64) float64 {
+	return from + (to-from)*t
+}
+
+func smoothStep(t float64) float64 {
+	t = min(max(t, 0), 1)
+	return t * t * (3 - 2*t)
+}
@@ -0,0 +1,108 @@
+package camera
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
+	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+)
+
+func newFollowingController(t *testing.T) (*Controller, *bodyphysics.ObstacleRect) {
+	t.Helper()
+	originalConfig := config.Get()
+	t.Cleanup(func() {
+		config.Set(originalConfig)
+	})
+	config.Set(&config.AppConfig{ScreenWidth: 320, ScreenHeight: 240})
+
+	ctrl := NewController(0, 0)
+	ctrl.DisableSmoothing()
+	target := bodyphysics.NewObstacleRect(bodyphysics.NewRect(0, 0, 32, 16))
+	target.SetPosition(484, 112) // centered on (500, 120)
+	ctrl.SetFollowTarget(target)
+	ctrl.SetFollowing(true)
+	return ctrl, target
+}
+
+func TestControllerZoneBlendsBoundsAndZoom(t *testing.T) {
+	ctrl, target := newFollowingController(t)
+	area := image.Rect(1000, 0, 1400, 240)
+	ctrl.SetZones([]Zone{{Area: area, Bounds: &area, Zoom: 2, BlendFrames: 10}})
+
+	ctrl.Update()
+	if x, _ := ctrl.GetCenter(); x != 500 || ctrl.Zoom() != 1 || ctrl.ActiveZone() != nil {
+		t.Fatalf("outside the zone: center x %v zoom %v", x, ctrl.Zoom())
+	}
+
+	// Entering next to the zone's left edge: at zoom 2 the view is 160px
+	// wide, so the center clamps to 1080.
+	target.SetPosition(1004, 112)
+	ctrl.Update()
+	x, _ := ctrl.GetCenter()
+	if x <= 500 || x >= 1080 || ctrl.Zoom() <= 1 || ctrl.Zoom() >= 2 {
+		t.Errorf("first blend frame: center x %v zoom %v, want between", x, ctrl.Zoom())
+	}
+	for range 9 {
+		ctrl.Update()
+	}
+	if x, y := ctrl.GetCenter(); x != 1080 || y != 120 || ctrl.Zoom() != 2 {
+		t.Errorf("after the blend: center %v,%v zoom %v, want 1080,120 zoom 2", x, y, ctrl.Zoom())
+	}
+	if ctrl.ActiveZone() == nil || ctrl.ActiveZone().Area != area {
+		t.Error("zone not active")
+	}
+}
+
+func TestControllerLookAheadLeadsFacingDirection(t *testing.T) {
+	ctrl, target := newFollowingController(t)
+	ctrl.SetLookAhead(LookAhead{Distance: 40, Speed: 1})
+
+	ctrl.Update()
+	if x, _ := ctrl.GetCenter(); x != 540 {
+		t.Errorf("facing right: center x %v, want 540", x)
+	}
+	target.SetFaceDirection(animation.FaceDirectionLeft)
+	ctrl.Update()
+	if x, _ := ctrl.GetCenter(); x != 460 {
+		t.Errorf("facing left: center x %v, want 460", x)
+	}
+
+	ctrl.SetLookAhead(LookAhead{Distance: 40})
+	target.SetFaceDirection(animation.FaceDirectionRight)
+	ctrl.Update()
+	if x, _ := ctrl.GetCenter(); x <= 460 || x >= 540 {
+		t.Errorf("default speed: center x %v, want easing between 460 and 540", x)
+	}
+}
+
+func TestControllerFramesMultipleTargets(t *testing.T) {
+	ctrl, player := newFollowingController(t)
+	enemy := bodyphysics.NewObstacleRect(bodyphysics.NewRect(0, 0, 32, 16))
+	enemy.SetPosition(1084, 112)
+	ctrl.SetTargets(player, enemy)
+
+	ctrl.Update()
+	// The targets span 484..1116: 632px, so the zoom fits 320/632.
+	x, y := ctrl.GetCenter()
+	if x != 800 || y != 120 {
+		t.Errorf("center = %v,%v, want 800,120", x, y)
+	}
+	if want := 320.0 / 632; math.Abs(ctrl.Zoom()-want) > 1e-9 {
+		t.Errorf("zoom = %v, want %v", ctrl.Zoom(), want)
+	}
+
+	enemy.SetPosition(5000, 112)
+	ctrl.Update()
+	if ctrl.Zoom() < 0.5 {
+		t.Errorf("zoom = %v, want no lower than the 0.5 default minimum", ctrl.Zoom())
+	}
+
+	ctrl.SetTargets()
+	ctrl.Update()
+	if x, _ := ctrl.GetCenter(); x != 500 || ctrl.Zoom() != 1 {
+		t.Errorf("back to single target: center x %v zoom %v", x, ctrl.Zoom())
+	}
+}
@@ -16,6 +16,31 @@ type Sprite struct {
 	Loop  bool
 }
 
+// FrameIndex returns the frame shown count ticks into the animation, for
+// frames width pixels wide that advance every frameRate ticks. Looping
+// sprites wrap around; others hold their last frame.
+func (s *Sprite) FrameIndex(width, count, frameRate int) int {
+	if s == nil || s.Image == nil || width <= 0 {
+		return 0
+	}
+	frameCount := s.Image.Bounds().Dx() / width
+	if frameCount <= 1 {
+		return 0
+	}
+	if frameRate <= 0 {
+		frameRate = 1
+	}
+
+	frameNum := count / frameRate
+	if s.Loop {
+		return frameNum % frameCount
+	}
+	if frameNum >= frameCount {
+		return frameCount - 1
+	}
+	return frameNum
+}
+
 type SpriteEntity struct {
 	sprites   SpriteMap
 	frameRate int
@@ -51,26 +76,11 @@ func (s *SpriteEntity) AnimatedSpriteImage(sprite *Sprite, rect image.Rectangle,
 	width := rect.Dx()
 	height := rect.Dy()
 
-	elementWidth := sprite.Image.Bounds().Dx()
-
-	if width <= 0 {
-		return sprite.Image
-	}
-
-	frameCount := elementWidth / width
-	if frameCount <= 1 {
+	if width <= 0 || sprite.Image.Bounds().Dx()/width <= 1 {
 		return sprite.Image
 	}
 
-	frameNum := count / frameRate
-	if sprite.Loop {
-		frameNum = frameNum % frameCount
-	} else {
-		if frameNum >= frameCount {
-			frameNum = frameCount - 1
-		}
-	}
-
+	frameNum := sprite.FrameIndex(width, count, frameRate)
 	sx, sy := frameOX+frameNum*width, frameOY
 
 	return sprite.Image.SubImage(
@@ -136,8 +136,11 @@ func TestAnimatedSpriteImage_EdgeCases(t *testing.T) {
 			sprite: sprite, rect: image.Rect(0, 0, 32, 32),
 			count: -10, rate: 1, wantNil: false,
 		},
-		// Note: zero frame rate causes divide by zero in production code
-		// This is a known limitation that should be fixed in sprites.go
+		{
+			name:   "zero frame rate",
+			sprite: sprite, rect: image.Rect(0, 0, 32, 32),
+			count: 3, rate: 0, wantNil: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -153,6 +156,33 @@ func TestAnimatedSpriteImage_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestSprite_FrameIndex(t *testing.T) {
+	looping := &Sprite{Image: ebiten.NewImage(96, 32), Loop: true}
+	once := &Sprite{Image: ebiten.NewImage(96, 32)}
+
+	tests := []struct {
+		name   string
+		sprite *Sprite
+		count  int
+		rate   int
+		want   int
+	}{
+		{"first frame", looping, 0, 4, 0},
+		{"advances every rate ticks", looping, 9, 4, 2},
+		{"looping wraps", looping, 13, 4, 0},
+		{"non-looping holds last frame", once, 13, 4, 2},
+		{"zero rate counts ticks", once, 1, 0, 1},
+		{"nil sprite", nil, 5, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sprite.FrameIndex(32, tt.count, tt.rate); got != tt.want {
+				t.Errorf("FrameIndex = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSpriteEntityGetters(t *testing.T) {
 	sprite := &Sprite{Image: ebiten.NewImage(32, 32), Loop: true}
 	sprites := SpriteMap{"idle": sprite}
@@ -1,10 +1,14 @@
 package tilemap
 
 import (
+	"encoding/json"
 	"fmt"
+	"image"
 	_ "image/png"
 	"log"
 	"math"
+	"slices"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -27,15 +31,25 @@ func extractGIDAndFlags(rawID int) (int, bool, bool, bool) {
 }
 
 type Tilemap struct {
-	Height       int        `json:"height"`
-	Width        int        `json:"width"`
-	Infinite     bool       `json:"infinite"`
-	Layers       []*Layer   `json:"layers"`
-	Tileheight   int        `json:"tileheight"`
-	Tilewidth    int        `json:"tilewidth"`
-	Tilesets     []*Tileset `json:"tilesets"`
-	image        *ebiten.Image
-	imageOptions *ebiten.DrawImageOptions
+	Height     int        `json:"height"`
+	Width      int        `json:"width"`
+	Infinite   bool       `json:"infinite"`
+	Layers     []*Layer   `json:"layers"`
+	Tileheight int        `json:"tileheight"`
+	Tilewidth  int        `json:"tilewidth"`
+	Tilesets   []*Tileset `json:"tilesets"`
+	// ParallaxOriginX/Y is the camera position at which parallax layers sit
+	// at their nominal offset.
+	ParallaxOriginX float64 `json:"parallaxoriginx"`
+	ParallaxOriginY float64 `json:"parallaxoriginy"`
+	image           *ebiten.Image
+	imageOptions    *ebiten.DrawImageOptions
+	// frame is the game frame animated tiles are drawn at; see SetFrame.
+	frame uint64
+	// chunkTiles and streamMargin configure chunked rendering; see
+	// SetChunkTiles and SetStreamMargin.
+	chunkTiles   int
+	streamMargin int
 }
 
 type Property struct {
@@ -44,18 +58,71 @@ type Property struct {
 	Value string `json:"value"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler. Tiled writes bool, int and float
+// properties as bare JSON values; they are kept as their literal text (e.g.
+// "true", "3") so Value stays a string.
+func (p *Property) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name  string          `json:"name"`
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.Name, p.Type = raw.Name, raw.Type
+	p.Value = ""
+	if len(raw.Value) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw.Value, &p.Value); err != nil {
+		p.Value = string(raw.Value)
+	}
+	return nil
+}
+
 type Layer struct {
 	Data    []int       `json:"data"`
 	Height  int         `json:"height"`
 	Id      int         `json:"id"`
 	Name    string      `json:"name"`
-	Opacity int         `json:"opacity"`
+	Class   string      `json:"class"`
+	Opacity float64     `json:"opacity"`
 	Type    string      `json:"type"`
 	Visible bool        `json:"visible"`
 	Width   int         `json:"width"`
 	X       int         `json:"x"`
 	Y       int         `json:"y"`
+	OffsetX float64     `json:"offsetx"`
+	OffsetY float64     `json:"offsety"`
 	Objects []*Obstacle `json:"objects"`
+	// Chunks hold the tiles of infinite maps; LoadTilemap flattens them into
+	// Data.
+	Chunks []*Chunk `json:"chunks"`
+	// ParallaxX/Y are nil when Tiled omits them, meaning a factor of 1.
+	ParallaxX  *float64   `json:"parallaxx"`
+	ParallaxY  *float64   `json:"parallaxy"`
+	Properties []Property `json:"properties"`
+
+	// Image layers
+	Image       string        `json:"image"`
+	RepeatX     bool          `json:"repeatx"`
+	RepeatY     bool          `json:"repeaty"`
+	EbitenImage *ebiten.Image `json:"-"`
+
+	// chunks caches the pre-rendered chunks of a tile layer around the
+	// camera, keyed by their position in chunks.
+	chunks map[image.Point]*layerChunk
+}
+
+// Chunk is a rectangular block of tiles of an infinite map layer, positioned
+// in tiles.
+type Chunk struct {
+	Data   []int `json:"data"`
+	X      int   `json:"x"`
+	Y      int   `json:"y"`
+	Width  int   `json:"width"`
+	Height int   `json:"height"`
 }
 
 type Obstacle struct {
@@ -70,22 +137,34 @@ type Obstacle struct {
 	X          float64    `json:"x"`
 	Y          float64    `json:"y"`
 	Properties []Property `json:"properties"`
+	// Polygon holds the points of polygon objects, relative to X and Y.
+	Polygon []Point `json:"polygon"`
+}
+
+// Point is a polygon vertex.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
 }
 
 type Tileset struct {
-	Columns          int           `json:"columns"`
-	Firstgid         int           `json:"firstgid"`
-	Image            string        `json:"image"`
-	Imageheight      int           `json:"imageheight"`
-	Imagewidth       int           `json:"imagewidth"`
-	Margin           int           `json:"margin"`
-	Name             string        `json:"name"`
-	Spacing          int           `json:"spacing"`
-	Tilecount        int           `json:"tilecount"`
-	Tileheight       int           `json:"tileheight"`
-	Tilewidth        int           `json:"tilewidth"`
-	Transparentcolor string        `json:"transparentcolor"`
-	EbitenImage      *ebiten.Image `json:"-"`
+	Columns          int     `json:"columns"`
+	Firstgid         int     `json:"firstgid"`
+	Image            string  `json:"image"`
+	Imageheight      int     `json:"imageheight"`
+	Imagewidth       int     `json:"imagewidth"`
+	Margin           int     `json:"margin"`
+	Name             string  `json:"name"`
+	Spacing          int     `json:"spacing"`
+	Tilecount        int     `json:"tilecount"`
+	Tileheight       int     `json:"tileheight"`
+	Tilewidth        int     `json:"tilewidth"`
+	Transparentcolor string  `json:"transparentcolor"`
+	Tiles            []*Tile `json:"tiles"`
+	// Source names an external tileset file (.tsx or .tsj), relative to the
+	// map. LoadTilemap replaces the entry with the file's contents.
+	Source      string        `json:"source"`
+	EbitenImage *ebiten.Image `json:"-"`
 }
 
 func (t *Tilemap) Image(screen *ebiten.Image) (*ebiten.Image, error) {
@@ -239,6 +318,9 @@ type EnemyPosition struct {
 	X, Y      int
 	EnemyType string
 	ID        string
+	// Tags come from the comma-separated "tags" property. Kill-all goals
+	// can target only the enemies with a given tag.
+	Tags []string
 }
 
 func (t *Tilemap) GetEnemiesPositionID() []*EnemyPosition {
@@ -267,6 +349,7 @@ func (t *Tilemap) GetEnemiesPositionID() []*EnemyPosition {
 		y16 := int(math.Round(yValue))
 
 		var id, enemyType string
+		var tags []string
 		for _, p := range obj.Properties {
 			if p.Name == "body_id" {
 				id = p.Value
@@ -274,6 +357,9 @@ func (t *Tilemap) GetEnemiesPositionID() []*EnemyPosition {
 			if p.Name == "enemy_type" {
 				enemyType = p.Value
 			}
+			if p.Name == "tags" {
+				tags = splitTags(p.Value)
+			}
 		}
 
 		if id == "" {
@@ -284,12 +370,28 @@ func (t *Tilemap) GetEnemiesPositionID() []*EnemyPosition {
 			enemyCount++
 		}
 
-		res = append(res, &EnemyPosition{X: x16, Y: y16, EnemyType: enemyType, ID: id})
+		res = append(res, &EnemyPosition{X: x16, Y: y16, EnemyType: enemyType, ID: id, Tags: tags})
 	}
 
 	return res
 }
 
+// HasTag reports whether the enemy carries tag.
+func (e *EnemyPosition) HasTag(tag string) bool {
+	return slices.Contains(e.Tags, tag)
+}
+
+// splitTags splits a comma-separated property into trimmed, non-empty tags.
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 type NpcPosition struct {
 	X, Y    int
 	NpcType string
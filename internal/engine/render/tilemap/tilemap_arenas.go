@@ -0,0 +1,47 @@
+package tilemap
+
+import (
+	"image"
+)
+
+// Arena is a rectangle object of the "Arenas" object layer: when the player
+// walks into Area, the camera locks to it until the enemies inside are
+// beaten.
+type Arena struct {
+	// ID is the object name, or its Tiled id when it has none. It names the
+	// arena in the "arena_cleared" event.
+	ID   string
+	Area image.Rectangle
+	// Sequence is the "sequence" property: a sequence played when the arena
+	// locks, typically spawning its wave. The arena stays locked while it
+	// plays.
+	Sequence string
+}
+
+// GetArenas returns the arenas of the "Arenas" object layer, in map order. It
+// is empty when the map has none.
+func (t *Tilemap) GetArenas() []*Arena {
+	if t == nil {
+		return nil
+	}
+	layer, found := t.FindLayerByName("Arenas")
+	if !found {
+		return nil
+	}
+
+	var arenas []*Arena
+	for _, obj := range layer.Objects {
+		area, ok := objectArea(obj)
+		if !ok {
+			continue
+		}
+		a := &Arena{ID: objectName(obj), Area: area}
+		for _, p := range obj.Properties {
+			if p.Name == "sequence" {
+				a.Sequence = p.Value
+			}
+		}
+		arenas = append(arenas, a)
+	}
+	return arenas
+}
@@ -0,0 +1,66 @@
+package tilemap
+
+import (
+	"image"
+	"math"
+	"strconv"
+	"time"
+)
+
+// CameraZone is a rectangle object of the "CameraZones" object layer. While
+// the followed body is inside Area, the camera uses the zone's framing.
+type CameraZone struct {
+	Area image.Rectangle
+	// Zoom is the "zoom" float property; 0 when unset.
+	Zoom float64
+	// OffsetX and OffsetY are the "offset_x" and "offset_y" properties.
+	OffsetX, OffsetY float64
+	// Blend is the "blend" property, in milliseconds: how long the camera
+	// takes to ease into the zone.
+	Blend time.Duration
+	// Clamp keeps the camera inside Area. It is on unless the "clamp" bool
+	// property is false.
+	Clamp bool
+}
+
+// GetCameraZones returns the camera zones of the "CameraZones" object layer,
+// in map order. It is empty when the map has none.
+func (t *Tilemap) GetCameraZones() []*CameraZone {
+	if t == nil {
+		return nil
+	}
+	layer, found := t.FindLayerByName("CameraZones")
+	if !found {
+		return nil
+	}
+
+	var zones []*CameraZone
+	for _, obj := range layer.Objects {
+		x, y := int(math.Round(obj.X)), int(math.Round(obj.Y))
+		w, h := int(math.Round(obj.Width)), int(math.Round(obj.Height))
+		if w <= 0 || h <= 0 {
+			continue
+		}
+		z := &CameraZone{Area: image.Rect(x, y, x+w, y+h), Clamp: true}
+		for _, p := range obj.Properties {
+			switch p.Name {
+			case "zoom":
+				z.Zoom, _ = strconv.ParseFloat(p.Value, 64)
+			case "offset_x":
+				z.OffsetX, _ = strconv.ParseFloat(p.Value, 64)
+			case "offset_y":
+				z.OffsetY, _ = strconv.ParseFloat(p.Value, 64)
+			case "blend":
+				if ms, err := strconv.Atoi(p.Value); err == nil {
+					z.Blend = time.Duration(ms) * time.Millisecond
+				}
+			case "clamp":
+				if v, err := strconv.ParseBool(p.Value); err == nil {
+					z.Clamp = v
+				}
+			}
+		}
+		zones = append(zones, z)
+	}
+	return zones
+}
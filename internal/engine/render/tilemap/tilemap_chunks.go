@@ -0,0 +1,75 @@
+package tilemap
+
+import "image"
+
+// PixelSize returns the map size in pixels. It uses the map's width and
+// height in tiles, falling back to the largest tile layer for maps that
+// don't set them.
+func (t *Tilemap) PixelSize() (int, int) {
+	w, h := t.Width, t.Height
+	if w <= 0 || h <= 0 {
+		for _, layer := range t.Layers {
+			if layer.Type == "tilelayer" || len(layer.Data) > 0 {
+				w, h = max(w, layer.Width), max(h, layer.Height)
+			}
+		}
+	}
+	return w * t.Tilewidth, h * t.Tileheight
+}
+
+// flattenChunks turns the chunked layers of an infinite map into regular
+// layers covering the bounds of every chunk. Chunks can sit at negative
+// coordinates, so the whole map (objects, image layers and parallax origin
+// included) is shifted to put the top-left chunk at the origin, which is
+// where the rest of the engine expects a map to start.
+func (t *Tilemap) flattenChunks() {
+	var bounds image.Rectangle
+	for _, layer := range t.Layers {
+		for _, c := range layer.Chunks {
+			r := image.Rect(c.X, c.Y, c.X+c.Width, c.Y+c.Height)
+			if bounds.Empty() {
+				bounds = r
+			} else {
+				bounds = bounds.Union(r)
+			}
+		}
+	}
+	if bounds.Empty() {
+		return
+	}
+
+	w, h := bounds.Dx(), bounds.Dy()
+	for _, layer := range t.Layers {
+		if layer.Chunks == nil {
+			continue
+		}
+		data := make([]int, w*h)
+		for _, c := range layer.Chunks {
+			for i, gid := range c.Data {
+				x := c.X - bounds.Min.X + i%c.Width
+				y := c.Y - bounds.Min.Y + i/c.Width
+				data[y*w+x] = gid
+			}
+		}
+		layer.Data, layer.Chunks = data, nil
+		layer.Width, layer.Height = w, h
+	}
+	t.Width, t.Height = w, h
+
+	dx := float64(-bounds.Min.X * t.Tilewidth)
+	dy := float64(-bounds.Min.Y * t.Tileheight)
+	for _, layer := range t.Layers {
+		switch layer.Type {
+		case "objectgroup":
+			for _, obj := range layer.Objects {
+				obj.X += dx
+				obj.Y += dy
+			}
+		case "imagelayer":
+			layer.OffsetX += dx
+			layer.OffsetY += dy
+		}
+	}
+	t.ParallaxOriginX += dx
+	t.ParallaxOriginY += dy
+}
@@ -2,7 +2,9 @@ package tilemap
 
 import (
 	"fmt"
+	"image"
 	"log"
+	"math"
 	"strings"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
@@ -105,24 +107,13 @@ func (t *Tilemap) CreateCollisionBodies(space body.BodiesSpace, endpointTriggerF
 		if strings.Contains(layer.Name, "Obstacles") {
 			foundObstacles = true
 			if layer.Type == "tilelayer" {
-				for i, tileID := range layer.Data {
-					if tileID == 0 {
-						continue
-					}
-
-					// Calculate the x and y coordinates of the tile
-					x := (i % layer.Width) * t.Tilewidth
-					y := (i / layer.Width) * t.Tileheight
-
-					rect := bodyphysics.NewRect(x, y, t.Tilewidth, t.Tileheight)
-					obstacle := bodyphysics.NewObstacleRect(rect)
-					obstacle.SetPosition(x, y)
-					// Generate a unique ID for the obstacle based on its position
-					obstacle.SetID(fmt.Sprintf("OBSTACLE_%d_%d", x, y))
-					obstacle.AddCollisionBodies()
-					obstacle.SetIsObstructive(true)
-					space.AddBody(obstacle)
-				}
+				// Every tile here is solid; tile properties can still make it
+				// one-way, slippery, sloped or harmful.
+				t.addTileBodies(space, layer, "OBSTACLE", func(gid int) (TileProperties, bool) {
+					props := t.TileProperties(gid)
+					props.Solid = true
+					return props, true
+				})
 			} else {
 				for _, obj := range layer.Objects {
 					obstacle := t.NewObstacleRect(obj, "OBSTACLE", true)
@@ -132,6 +123,10 @@ func (t *Tilemap) CreateCollisionBodies(space body.BodiesSpace, endpointTriggerF
 		}
 	}
 
+	if t.createTilePropertyBodies(space) {
+		foundObstacles = true
+	}
+
 	if !foundEndpoint {
 		log.Printf("Endpoint layer not found in tilemap")
 	}
@@ -141,12 +136,19 @@ func (t *Tilemap) CreateCollisionBodies(space body.BodiesSpace, endpointTriggerF
 	}
 }
 
+// NewObstacleRect creates a body for an object. Polygon objects get their
+// bounding box, and obstructive polygons with a sloped top edge, such as
+// triangles drawn as hills, become slopes.
 func (t *Tilemap) NewObstacleRect(obj *Obstacle, prefix string, isObstructive bool) *bodyphysics.ObstacleRect {
-	y := int(obj.Y)
+	bounds, leftY, rightY := obj.polygonShape()
+	x, y := int(obj.X)+bounds.Min.X, int(obj.Y)+bounds.Min.Y
 
-	rect := bodyphysics.NewRect(int(obj.X), y, int(obj.Width), int(obj.Height))
+	rect := bodyphysics.NewRect(x, y, bounds.Dx(), bounds.Dy())
 	o := bodyphysics.NewObstacleRect(rect)
-	o.SetPosition(int(obj.X), y)
+	o.SetPosition(x, y)
+	if isObstructive && leftY != rightY {
+		o.SetSlope(leftY, rightY)
+	}
 	var id string
 	for _, p := range obj.Properties {
 		if p.Name == "body_id" {
@@ -159,3 +161,63 @@ func (t *Tilemap) NewObstacleRect(obj *Obstacle, prefix string, isObstructive bo
 	o.SetIsObstructive(isObstructive)
 	return o
 }
+
+// createTilePropertyBodies adds bodies for tiles whose tileset marks them
+// solid, one_way, sloped or hazard, on visible tile layers other than the
+// Endpoint and Obstacles ones. It reports whether any blocking body was
+// added.
+func (t *Tilemap) createTilePropertyBodies(space body.BodiesSpace) bool {
+	blocking := false
+	for _, layer := range t.Layers {
+		if !layer.Visible || layer.Type != "tilelayer" ||
+			strings.Contains(layer.Name, "Endpoint") || strings.Contains(layer.Name, "Obstacles") {
+			continue
+		}
+		added := t.addTileBodies(space, layer, fmt.Sprintf("TILE_%d", layer.Id), func(gid int) (TileProperties, bool) {
+			props := t.TileProperties(gid)
+			return props, props.Solid || props.OneWay || props.Hazard || props.Slope
+		})
+		blocking = blocking || added
+	}
+	return blocking
+}
+
+// applyTileProperties configures a tile obstacle from its tileset properties.
+func applyTileProperties(o *bodyphysics.ObstacleRect, props TileProperties) {
+	o.SetIsObstructive(props.Solid || props.OneWay || props.Slope)
+	if props.OneWay {
+		o.SetOneWay(true)
+	}
+	if props.Friction != nil {
+		o.SetFriction(*props.Friction)
+	}
+	if props.Hazard {
+		o.SetTouchable(bodyphysics.NewHazardTrigger(props.Damage))
+	}
+}
+
+// polygonShape returns the object's bounding box relative to its position
+// and the top edge's offset below the box top at the left and right sides.
+// Objects without a polygon are their plain rectangle.
+func (obj *Obstacle) polygonShape() (image.Rectangle, int, int) {
+	if len(obj.Polygon) < 3 {
+		return image.Rect(0, 0, int(obj.Width), int(obj.Height)), 0, 0
+	}
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, p := range obj.Polygon {
+		minX, maxX = min(minX, p.X), max(maxX, p.X)
+		minY, maxY = min(minY, p.Y), max(maxY, p.Y)
+	}
+	leftY, rightY := maxY, maxY
+	for _, p := range obj.Polygon {
+		if p.X == minX {
+			leftY = min(leftY, p.Y)
+		}
+		if p.X == maxX {
+			rightY = min(rightY, p.Y)
+		}
+	}
+	bounds := image.Rect(int(math.Round(minX)), int(math.Round(minY)), int(math.Round(maxX)), int(math.Round(maxY)))
+	return bounds, int(math.Round(leftY - minY)), int(math.Round(rightY - minY))
+}
@@ -1,7 +1,6 @@
 package tilemap
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"image"
@@ -18,9 +17,7 @@ func (t *Tilemap) ParseToImage(screen *ebiten.Image) (*ebiten.Image, error) {
 		return nil, err
 	}
 
-	// Use the first layer to determine map dimensions. This assumes all layers are the same size.
-	mapWidth := t.Layers[0].Width * t.Tilewidth
-	mapHeight := t.Layers[0].Height * t.Tileheight
+	mapWidth, mapHeight := t.PixelSize()
 	result := ebiten.NewImage(mapWidth, mapHeight)
 
 	for _, layer := range t.Layers {
@@ -110,38 +107,57 @@ func (t *Tilemap) ParseBase(layer *Layer, result *ebiten.Image) {
 	if layer == nil || result == nil {
 		return
 	}
+	t.drawCells(layer, result, image.Rect(0, 0, layer.Width, layer.rows()), image.Point{}, false)
+}
 
-	for i, rawID := range layer.Data {
-		if rawID == 0 {
-			continue
-		}
-
-		tileID, flipH, flipV, flipD := extractGIDAndFlags(rawID)
-		if tileID == 0 {
-			continue
-		}
-
-		ts := t.findTileset(tileID)
-		if ts == nil || ts.EbitenImage == nil {
-			continue
+// drawCells draws the tiles of layer's cells within the given rectangle (in
+// tiles) onto dst, whose top-left corner is at origin in layer pixels.
+// Animated tiles are drawn at their first frame, or, with skipAnimated, left
+// out and returned so they can be drawn every frame instead. It reports
+// whether any tile was drawn.
+func (t *Tilemap) drawCells(layer *Layer, dst *ebiten.Image, cells image.Rectangle, origin image.Point, skipAnimated bool) ([]animatedCell, bool) {
+	var animated []animatedCell
+	drawn := false
+	for y := cells.Min.Y; y < cells.Max.Y; y++ {
+		for x := cells.Min.X; x < cells.Max.X; x++ {
+			i := y*layer.Width + x
+			rawID := layer.Data[i]
+			if rawID == 0 {
+				continue
+			}
+
+			tileID, flipH, flipV, flipD := extractGIDAndFlags(rawID)
+			if tileID == 0 {
+				continue
+			}
+
+			if skipAnimated && t.isAnimated(tileID) {
+				animated = append(animated, animatedCell{index: i, raw: rawID})
+				continue
+			}
+
+			ts := t.findTileset(tileID)
+			if ts == nil {
+				continue
+			}
+			tile := ts.tileImage(tileID)
+			if tile == nil {
+				continue
+			}
+
+			op := &ebiten.DrawImageOptions{}
+			tw, th := tile.Bounds().Dx(), tile.Bounds().Dy()
+			applyFlips(op, flipH, flipV, flipD, float64(tw), float64(th))
+
+			// Then translate to position
+			dx, dy := t.cellPosition(ts, tile, x, y)
+			op.GeoM.Translate(dx-float64(origin.X), dy-float64(origin.Y))
+
+			dst.DrawImage(tile, op)
+			drawn = true
 		}
-
-		srcRect := tilesetSourceRect(ts, tileID)
-
-		tile := ts.EbitenImage.SubImage(srcRect).(*ebiten.Image)
-
-		op := &ebiten.DrawImageOptions{}
-		applyFlips(op, flipH, flipV, flipD, float64(ts.Tilewidth), float64(ts.Tileheight))
-
-		// Then translate to position
-		x := i % layer.Width
-		y := i / layer.Width
-		dx := float64(x * ts.Tilewidth)
-		dy := float64(y * ts.Tileheight)
-		op.GeoM.Translate(dx, dy)
-
-		result.DrawImage(tile, op)
 	}
+	return animated, drawn
 }
 
 func (t *Tilemap) ParseItems(layer *Layer, result *ebiten.Image) {
@@ -161,15 +177,16 @@ func (t *Tilemap) ParseItems(layer *Layer, result *ebiten.Image) {
 		}
 
 		ts := t.findTileset(gid)
-		if ts == nil || ts.EbitenImage == nil {
+		if ts == nil {
+			continue
+		}
+		tileImg := ts.tileImage(gid)
+		if tileImg == nil {
 			continue
 		}
-
-		srcRect := tilesetSourceRect(ts, gid)
-		tileImg := ts.EbitenImage.SubImage(srcRect).(*ebiten.Image)
 
 		op := &ebiten.DrawImageOptions{}
-		applyFlips(op, flipH, flipV, flipD, float64(ts.Tilewidth), float64(ts.Tileheight))
+		applyFlips(op, flipH, flipV, flipD, float64(tileImg.Bounds().Dx()), float64(tileImg.Bounds().Dy()))
 		op.GeoM.Translate(obj.X, obj.Y-obj.Height)
 
 		result.DrawImage(tileImg, op)
@@ -192,14 +209,29 @@ func LoadTilemap(fsys fs.FS, path string) (*Tilemap, error) {
 	}
 	tilemap.imageOptions = &ebiten.DrawImageOptions{}
 
-	// After loading the tilemap structure, load the associated tileset images.
+	// After loading the tilemap structure, resolve external tilesets and load
+	// the associated tileset images.
 	for _, ts := range tilemap.Tilesets {
-		imagePath := filepath.Join(filepath.Dir(path), ts.Image)
+		if err := loadTileset(fsys, filepath.Dir(path), ts); err != nil {
+			return nil, err
+		}
+	}
+	if tilemap.Infinite {
+		tilemap.flattenChunks()
+	}
+	if err := tilemap.validateSlopeFlips(); err != nil {
+		return nil, fmt.Errorf("tilemap %s: %w", path, err)
+	}
+	for _, layer := range tilemap.Layers {
+		if layer.Type != "imagelayer" || layer.Image == "" {
+			continue
+		}
+		imagePath := filepath.Join(filepath.Dir(path), layer.Image)
 		img, err := loadImage(fsys, imagePath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load tileset image %s: %w", imagePath, err)
+			return nil, fmt.Errorf("failed to load image layer %s: %w", imagePath, err)
 		}
-		ts.EbitenImage = img
+		layer.EbitenImage = img
 	}
 
 	return &tilemap, nil
@@ -207,17 +239,7 @@ func LoadTilemap(fsys fs.FS, path string) (*Tilemap, error) {
 
 // loadImage is a helper function to load an image from a file path.
 func loadImage(fsys fs.FS, path string) (*ebiten.Image, error) {
-	data, err := fs.ReadFile(fsys, path)
-	if err != nil {
-		return nil, err
-	}
-
-	img, _, err := image.Decode(bytes.NewReader(data))
-	if err != nil {
-		return nil, err
-	}
-
-	return ebiten.NewImageFromImage(img), nil
+	return loadImageKeyed(fsys, path, "")
 }
 
 func (t *Tilemap) isTilemapValid() (bool, error) {
@@ -0,0 +1,146 @@
+package tilemap
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// foregroundClass is the Tiled layer class (or the name of a true bool
+// property) that marks a layer as drawn over actors.
+const foregroundClass = "foreground"
+
+// Camera is what layer rendering needs from the scene camera;
+// camera.Controller implements it.
+type Camera interface {
+	Draw(src *ebiten.Image, options *ebiten.DrawImageOptions, dst *ebiten.Image)
+	GetActualCenter() (float64, float64)
+	Width() float64
+	Height() float64
+}
+
+// zoomer is implemented by cameras that zoom, such as camera.Controller.
+type zoomer interface {
+	Zoom() float64
+}
+
+// viewSize is the size of the world area the camera shows: its screen size
+// divided by its zoom.
+func viewSize(cam Camera) (float64, float64) {
+	w, h := cam.Width(), cam.Height()
+	if z, ok := cam.(zoomer); ok && z.Zoom() > 0 {
+		w, h = w/z.Zoom(), h/z.Zoom()
+	}
+	return w, h
+}
+
+// IsForeground reports whether the layer draws in front of actors. Tag a
+// layer in Tiled with the class "foreground" or a bool property
+// "foreground" = true; every other layer is background.
+func (l *Layer) IsForeground() bool {
+	if l.Class == foregroundClass {
+		return true
+	}
+	for _, p := range l.Properties {
+		if p.Name == foregroundClass {
+			v, _ := strconv.ParseBool(p.Value)
+			return v
+		}
+	}
+	return false
+}
+
+// ParallaxFactor returns the layer's Tiled parallax factor. 1 scrolls with
+// the map, 0 stays fixed on screen, and values in between lag behind.
+func (l *Layer) ParallaxFactor() (float64, float64) {
+	px, py := 1.0, 1.0
+	if l.ParallaxX != nil {
+		px = *l.ParallaxX
+	}
+	if l.ParallaxY != nil {
+		py = *l.ParallaxY
+	}
+	return px, py
+}
+
+// DrawBackground draws the visible tile and image layers that are not tagged
+// foreground, in map order. Call it before drawing actors.
+func (t *Tilemap) DrawBackground(screen *ebiten.Image, cam Camera) {
+	t.drawLayers(screen, cam, false)
+}
+
+// DrawForeground draws the layers tagged foreground, in map order. Call it
+// after drawing actors so grass, pillars and the like cover them.
+func (t *Tilemap) DrawForeground(screen *ebiten.Image, cam Camera) {
+	t.drawLayers(screen, cam, true)
+}
+
+func (t *Tilemap) drawLayers(screen *ebiten.Image, cam Camera, foreground bool) {
+	if t == nil || cam == nil {
+		return
+	}
+	cx, cy := cam.GetActualCenter()
+	for _, layer := range t.Layers {
+		if !layer.Visible || layer.Opacity <= 0 || layer.IsForeground() != foreground {
+			continue
+		}
+		x, y := t.layerPosition(layer, cx, cy)
+		switch layer.Type {
+		case "tilelayer":
+			t.drawChunks(screen, cam, layer, x, y)
+		case "imagelayer":
+			t.drawImageLayer(screen, cam, layer, x, y, cx, cy)
+		}
+	}
+}
+
+// layerPosition is the world position of a layer's origin for a camera
+// centered at (cx, cy). It follows Tiled: parallax shifts the layer by
+// (camera - parallax origin) * (1 - factor) on top of its pixel offset.
+func (t *Tilemap) layerPosition(layer *Layer, cx, cy float64) (float64, float64) {
+	px, py := layer.ParallaxFactor()
+	x := layer.OffsetX + (cx-t.ParallaxOriginX)*(1-px)
+	y := layer.OffsetY + (cy-t.ParallaxOriginY)*(1-py)
+	return x, y
+}
+
+func layerDrawOptions(layer *Layer, x, y float64) *ebiten.DrawImageOptions {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, y)
+	if layer.Opacity < 1 {
+		op.ColorScale.ScaleAlpha(float32(layer.Opacity))
+	}
+	return op
+}
+
+// drawImageLayer draws a Tiled image layer, tiling it across the view along
+// the axes it repeats on.
+func (t *Tilemap) drawImageLayer(screen *ebiten.Image, cam Camera, layer *Layer, x, y, cx, cy float64) {
+	img := layer.EbitenImage
+	if img == nil {
+		return
+	}
+	w, h := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+	viewW, viewH := viewSize(cam)
+	halfW, halfH := viewW/2, viewH/2
+	for _, ty := range repeatStarts(y, h, cy-halfH, cy+halfH, layer.RepeatY) {
+		for _, tx := range repeatStarts(x, w, cx-halfW, cx+halfW, layer.RepeatX) {
+			cam.Draw(img, layerDrawOptions(layer, tx, ty), screen)
+		}
+	}
+}
+
+// repeatStarts returns where copies of an image of the given size start so
+// they cover [viewMin, viewMax] when repeating, or just pos otherwise.
+func repeatStarts(pos, size, viewMin, viewMax float64, repeat bool) []float64 {
+	if !repeat || size <= 0 {
+		return []float64{pos}
+	}
+	first := pos + math.Floor((viewMin-pos)/size)*size
+	var starts []float64
+	for s := first; s < viewMax; s += size {
+		starts = append(starts, s)
+	}
+	return starts
+}
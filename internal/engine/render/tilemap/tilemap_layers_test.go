@@ -0,0 +1,158 @@
+package tilemap
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// recordingCamera is a fixed camera that records where each image is drawn.
+type recordingCamera struct {
+	cx, cy float64
+	draws  []recordedDraw
+}
+
+type recordedDraw struct {
+	img   *ebiten.Image
+	x, y  float64
+	alpha float32
+}
+
+func (c *recordingCamera) Draw(src *ebiten.Image, op *ebiten.DrawImageOptions, _ *ebiten.Image) {
+	c.draws = append(c.draws, recordedDraw{
+		img:   src,
+		x:     op.GeoM.Element(0, 2),
+		y:     op.GeoM.Element(1, 2),
+		alpha: op.ColorScale.A(),
+	})
+}
+
+func (c *recordingCamera) GetActualCenter() (float64, float64) { return c.cx, c.cy }
+func (c *recordingCamera) Width() float64                      { return 100 }
+func (c *recordingCamera) Height() float64                     { return 100 }
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	img.Set(0, 0, color.White)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const layeredMap = `{
+  "width": 2, "height": 1, "tilewidth": 16, "tileheight": 16,
+  "parallaxoriginx": 10,
+  "tilesets": [{"firstgid": 1, "columns": 1, "image": "tiles.png", "tilewidth": 16, "tileheight": 16}],
+  "layers": [
+    {"type": "imagelayer", "name": "sky", "image": "sky.png", "repeatx": true,
+     "parallaxx": 0.5, "parallaxy": 0, "opacity": 1, "visible": true},
+    {"type": "tilelayer", "name": "ground", "data": [1, 1], "width": 2, "height": 1,
+     "offsetx": 4, "opacity": 0.5, "visible": true},
+    {"type": "tilelayer", "name": "grass", "class": "foreground", "data": [1, 0], "width": 2, "height": 1,
+     "opacity": 1, "visible": true},
+    {"type": "tilelayer", "name": "pillars", "data": [0, 1], "width": 2, "height": 1,
+     "properties": [{"name": "foreground", "type": "bool", "value": true}],
+     "opacity": 1, "visible": true},
+    {"type": "tilelayer", "name": "hidden", "data": [1, 1], "width": 2, "height": 1,
+     "opacity": 1, "visible": false}
+  ]
+}`
+
+func loadLayeredMap(t *testing.T) *Tilemap {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"maps/level.tmj": {Data: []byte(layeredMap)},
+		"maps/tiles.png": {Data: pngBytes(t, 16, 16)},
+		"maps/sky.png":   {Data: pngBytes(t, 40, 20)},
+	}
+	tm, err := LoadTilemap(fsys, "maps/level.tmj")
+	if err != nil {
+		t.Fatalf("LoadTilemap: %v", err)
+	}
+	return tm
+}
+
+func TestLayerTagsAndParallaxFromJSON(t *testing.T) {
+	tm := loadLayeredMap(t)
+	var foreground []string
+	for _, l := range tm.Layers {
+		if l.IsForeground() {
+			foreground = append(foreground, l.Name)
+		}
+	}
+	if !slices.Equal(foreground, []string{"grass", "pillars"}) {
+		t.Errorf("foreground layers = %v", foreground)
+	}
+	if got := tm.Layers[3].Properties[0].Value; got != "true" {
+		t.Errorf("bool property value = %q, want \"true\"", got)
+	}
+	if px, py := tm.Layers[0].ParallaxFactor(); px != 0.5 || py != 0 {
+		t.Errorf("sky parallax = %v,%v", px, py)
+	}
+	if px, py := tm.Layers[1].ParallaxFactor(); px != 1 || py != 1 {
+		t.Errorf("default parallax = %v,%v, want 1,1", px, py)
+	}
+	if tm.Layers[0].EbitenImage == nil {
+		t.Error("image layer image not loaded")
+	}
+}
+
+func TestDrawBackgroundHonorsOffsetOpacityAndParallax(t *testing.T) {
+	tm := loadLayeredMap(t)
+	cam := &recordingCamera{cx: 110, cy: 50}
+	tm.DrawBackground(ebiten.NewImage(1, 1), cam)
+
+	// Sky: x = (110-10)*(1-0.5) = 50, repeated across the 60..160 view;
+	// y = 50*(1-0) = 50.
+	var skyX []float64
+	var ground []recordedDraw
+	for _, d := range cam.draws {
+		if d.img == tm.Layers[0].EbitenImage {
+			skyX = append(skyX, d.x)
+			if d.y != 50 {
+				t.Errorf("sky y = %v, want 50", d.y)
+			}
+		} else {
+			ground = append(ground, d)
+		}
+	}
+	if !slices.Equal(skyX, []float64{50, 90, 130}) {
+		t.Errorf("sky copies at %v, want [50 90 130]", skyX)
+	}
+	if len(ground) != 1 {
+		t.Fatalf("expected only the ground tile layer, got %d draws", len(ground))
+	}
+	if g := ground[0]; g.x != 4 || g.y != 0 || g.alpha != 0.5 {
+		t.Errorf("ground drawn at %v,%v alpha %v, want 4,0 alpha 0.5", g.x, g.y, g.alpha)
+	}
+}
+
+func TestDrawForegroundOnlyDrawsTaggedLayers(t *testing.T) {
+	tm := loadLayeredMap(t)
+	cam := &recordingCamera{}
+	tm.DrawForeground(ebiten.NewImage(1, 1), cam)
+	if len(cam.draws) != 2 {
+		t.Fatalf("foreground draws = %d, want 2", len(cam.draws))
+	}
+	if cam.draws[0].img != tm.Layers[2].chunks[image.Point{}].image || cam.draws[1].img != tm.Layers[3].chunks[image.Point{}].image {
+		t.Error("foreground layers drawn out of map order")
+	}
+}
+
+func TestRepeatStarts(t *testing.T) {
+	if got := repeatStarts(5, 10, 0, 30, false); !slices.Equal(got, []float64{5}) {
+		t.Errorf("no repeat = %v", got)
+	}
+	if got := repeatStarts(5, 10, 0, 30, true); !slices.Equal(got, []float64{-5, 5, 15, 25}) {
+		t.Errorf("repeat = %v", got)
+	}
+}
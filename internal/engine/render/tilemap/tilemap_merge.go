@@ -0,0 +1,134 @@
+package tilemap
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+)
+
+// tileKey groups tiles that behave the same; only tiles with equal keys are
+// merged into one body.
+type tileKey struct {
+	solid, oneWay, hazard bool
+	damage                int
+	friction              float64
+	hasFriction           bool
+}
+
+func newTileKey(p TileProperties) tileKey {
+	k := tileKey{solid: p.Solid, oneWay: p.OneWay, hazard: p.Hazard, damage: p.Damage}
+	if p.Friction != nil {
+		k.friction, k.hasFriction = *p.Friction, true
+	}
+	return k
+}
+
+// tileBlock is a rectangle of cells, in tiles, that becomes one body.
+type tileBlock struct {
+	cells image.Rectangle
+	props TileProperties
+}
+
+// mergeTiles greedily merges the cells of a w×h layer into blocks. Each
+// unmerged cell grows right while the next cell has the same key, then down
+// while the whole row below matches. One-way platforms only grow sideways,
+// since only their top row blocks. Slopes are never merged.
+func mergeTiles(w, h int, cell func(i int) (TileProperties, bool)) []tileBlock {
+	done := make([]bool, w*h)
+	keyAt := func(x, y int) (tileKey, bool) {
+		if x >= w || y >= h || done[y*w+x] {
+			return tileKey{}, false
+		}
+		p, ok := cell(y*w + x)
+		if !ok || p.Slope {
+			return tileKey{}, false
+		}
+		return newTileKey(p), true
+	}
+
+	var blocks []tileBlock
+	for y := range h {
+		for x := range w {
+			i := y*w + x
+			if done[i] {
+				continue
+			}
+			props, ok := cell(i)
+			if !ok {
+				continue
+			}
+			if props.Slope {
+				done[i] = true
+				blocks = append(blocks, tileBlock{cells: image.Rect(x, y, x+1, y+1), props: props})
+				continue
+			}
+			key := newTileKey(props)
+			x1 := x + 1
+			for k, ok := keyAt(x1, y); ok && k == key; k, ok = keyAt(x1, y) {
+				x1++
+			}
+			y1 := y + 1
+			for !props.OneWay && rowMatches(x, x1, y1, key, keyAt) {
+				y1++
+			}
+			for cy := y; cy < y1; cy++ {
+				for cx := x; cx < x1; cx++ {
+					done[cy*w+cx] = true
+				}
+			}
+			blocks = append(blocks, tileBlock{cells: image.Rect(x, y, x1, y1), props: props})
+		}
+	}
+	return blocks
+}
+
+func rowMatches(x0, x1, y int, key tileKey, keyAt func(x, y int) (tileKey, bool)) bool {
+	for x := x0; x < x1; x++ {
+		if k, ok := keyAt(x, y); !ok || k != key {
+			return false
+		}
+	}
+	return true
+}
+
+// addTileBodies adds one body per merged block of the tile layer's cells
+// that cell accepts, with IDs of the form <prefix>_<x>_<y> from the block's
+// top-left pixel. It reports whether any body blocks movement.
+func (t *Tilemap) addTileBodies(space body.BodiesSpace, layer *Layer, prefix string, cell func(gid int) (TileProperties, bool)) bool {
+	if layer.Width <= 0 {
+		return false
+	}
+	blocking := false
+	blocks := mergeTiles(layer.Width, len(layer.Data)/layer.Width, func(i int) (TileProperties, bool) {
+		if layer.Data[i] == 0 {
+			return TileProperties{}, false
+		}
+		return cell(layer.Data[i])
+	})
+	for _, b := range blocks {
+		x, y := b.cells.Min.X*t.Tilewidth, b.cells.Min.Y*t.Tileheight
+		w, h := b.cells.Dx()*t.Tilewidth, b.cells.Dy()*t.Tileheight
+
+		obstacle := bodyphysics.NewObstacleRect(bodyphysics.NewRect(x, y, w, h))
+		obstacle.SetPosition(x, y)
+		obstacle.SetID(fmt.Sprintf("%s_%d_%d", prefix, x, y))
+		obstacle.AddCollisionBodies()
+		applyTileProperties(obstacle, b.props)
+		if b.props.Slope {
+			obstacle.SetSlope(slopeOffset(b.props.SlopeLeft, h), slopeOffset(b.props.SlopeRight, h))
+		}
+		space.AddBody(obstacle)
+		blocking = blocking || obstacle.IsObstructive()
+	}
+	return blocking
+}
+
+// slopeOffset converts a surface height given as a fraction of the tile
+// (0 bottom, 1 top) to pixels below the tile's top.
+func slopeOffset(height float64, tileHeight int) int {
+	height = min(max(height, 0), 1)
+	return int(math.Round((1 - height) * float64(tileHeight)))
+}
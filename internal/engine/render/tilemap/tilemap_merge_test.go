@@ -0,0 +1,126 @@
+package tilemap
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
+)
+
+func TestMergeTiles(t *testing.T) {
+	solid := TileProperties{Solid: true}
+	oneWay := TileProperties{OneWay: true}
+	slope := TileProperties{Slope: true, SlopeRight: 1}
+	// S S S .
+	// S S S O
+	// / . . O
+	cells := []TileProperties{
+		solid, solid, solid, {},
+		solid, solid, solid, oneWay,
+		slope, {}, {}, oneWay,
+	}
+	blocks := mergeTiles(4, 3, func(i int) (TileProperties, bool) {
+		return cells[i], cells[i] != TileProperties{}
+	})
+
+	var got []image.Rectangle
+	for _, b := range blocks {
+		got = append(got, b.cells)
+	}
+	want := []image.Rectangle{
+		image.Rect(0, 0, 3, 2),
+		image.Rect(3, 1, 4, 2),
+		image.Rect(0, 2, 1, 3),
+		image.Rect(3, 2, 4, 3),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("blocks = %v, want %v", got, want)
+	}
+}
+
+func TestObstaclesLayerMergesTiles(t *testing.T) {
+	tm := &Tilemap{Tilewidth: 16, Tileheight: 16, Layers: []*Layer{{
+		Name: "Obstacles", Type: "tilelayer", Visible: true, Width: 4,
+		Data: []int{
+			1, 1, 1, 1,
+			1, 1, 1, 1,
+		},
+	}}}
+	sp := space.NewSpace()
+	tm.CreateCollisionBodies(sp, func(string) body.Touchable { return nil })
+
+	if n := len(sp.Bodies()); n != 1 {
+		t.Fatalf("bodies = %d, want the layer merged into 1", n)
+	}
+	o := sp.Find("OBSTACLE_0_0")
+	if o == nil || o.Position() != image.Rect(0, 0, 64, 32) || !o.IsObstructive() {
+		t.Errorf("merged body = %v", o)
+	}
+}
+
+func TestSlopeTileBecomesSlopeBody(t *testing.T) {
+	tm := loadTileMap(t)
+	tm.Tilesets[0].Tiles = append(tm.Tilesets[0].Tiles, &Tile{ID: 5, Properties: []*Property{
+		{Name: "slope_left", Type: "float", Value: "0"},
+		{Name: "slope_right", Type: "float", Value: "0.5"},
+	}})
+	tm.Layers[0].Data = []int{6, 6, 0, 0}
+	sp := space.NewSpace()
+	tm.CreateCollisionBodies(sp, nil)
+
+	for _, id := range []string{"TILE_7_0_0", "TILE_7_16_0"} {
+		o, ok := sp.Find(id).(*bodyphysics.ObstacleRect)
+		if !ok || !o.IsSlope() || !o.IsObstructive() {
+			t.Fatalf("%s = %v, want a separate slope body", id, o)
+		}
+		left, right := o.Position().Min.X, o.Position().Max.X
+		if o.SurfaceY(left) != 16 || o.SurfaceY(right) != 8 {
+			t.Errorf("%s surface %d → %d, want 16 → 8", id, o.SurfaceY(left), o.SurfaceY(right))
+		}
+	}
+}
+
+func TestFlippedSlopeTileMirrorsRamp(t *testing.T) {
+	tm := loadTileMap(t)
+	tm.Tilesets[0].Tiles = append(tm.Tilesets[0].Tiles, &Tile{ID: 5, Properties: []*Property{
+		{Name: "slope_left", Type: "float", Value: "0"},
+		{Name: "slope_right", Type: "float", Value: "1"},
+	}})
+	tm.Layers[0].Data = []int{6 | flippedHorizontallyFlag, 0, 0, 0}
+	sp := space.NewSpace()
+	tm.CreateCollisionBodies(sp, nil)
+
+	o, ok := sp.Find("TILE_7_0_0").(*bodyphysics.ObstacleRect)
+	if !ok || !o.IsSlope() {
+		t.Fatalf("TILE_7_0_0 = %v, want a slope body", o)
+	}
+	if left, right := o.SurfaceY(0), o.SurfaceY(16); left != 0 || right != 16 {
+		t.Errorf("mirrored ramp surface %d → %d, want 0 → 16", left, right)
+	}
+
+	for _, flag := range []int{flippedVerticallyFlag, flippedDiagonallyFlag} {
+		tm.Layers[0].Data[0] = 6 | flag
+		if err := tm.validateSlopeFlips(); err == nil {
+			t.Errorf("flag %#x: expected an error for a flipped slope tile", flag)
+		}
+	}
+	tm.Layers[0].Data[0] = 3 | flippedVerticallyFlag
+	if err := tm.validateSlopeFlips(); err != nil {
+		t.Errorf("vertically flipped solid tile rejected: %v", err)
+	}
+}
+
+func TestPolygonObstacleBecomesSlope(t *testing.T) {
+	obj := &Obstacle{X: 32, Y: 48, Polygon: []Point{{0, 16}, {16, 0}, {16, 16}}}
+	o := (&Tilemap{}).NewObstacleRect(obj, "OBSTACLE", true)
+
+	if o.Position() != image.Rect(32, 48, 48, 64) || !o.IsSlope() {
+		t.Fatalf("polygon obstacle at %v, slope %v", o.Position(), o.IsSlope())
+	}
+	if o.SurfaceY(32) != 64 || o.SurfaceY(48) != 48 {
+		t.Errorf("surface %d → %d, want 64 → 48", o.SurfaceY(32), o.SurfaceY(48))
+	}
+}
@@ -0,0 +1,107 @@
+package tilemap
+
+import (
+	"image"
+	"math"
+	"strconv"
+)
+
+// Room is a rectangle object of the "Camera" object layer: one screen-flip
+// room of the map.
+type Room struct {
+	// Name is the object name, or its Tiled id when it has none.
+	Name string
+	Area image.Rectangle
+}
+
+// GetRooms returns the rooms of the "Camera" object layer, in map order. It
+// is empty when the map has none; point objects are skipped.
+func (t *Tilemap) GetRooms() []*Room {
+	if t == nil {
+		return nil
+	}
+	layer, found := t.FindLayerByName("Camera")
+	if !found {
+		return nil
+	}
+
+	var rooms []*Room
+	for _, obj := range layer.Objects {
+		area, ok := objectArea(obj)
+		if !ok {
+			continue
+		}
+		rooms = append(rooms, &Room{Name: objectName(obj), Area: area})
+	}
+	return rooms
+}
+
+// Door is a rectangle object of the "Doors" object layer. Walking into it
+// takes the player to another room, possibly in another map.
+type Door struct {
+	// Name is the object name, or its Tiled id when it has none.
+	Name string
+	Area image.Rectangle
+	// TargetMap is the "target_map" property: the path of the map the door
+	// leads to. Empty means this map.
+	TargetMap string
+	// TargetRoom is the "target_room" property: the name of the room the
+	// door leads to.
+	TargetRoom string
+	// TargetDoor is the "target_door" property: the door the player comes
+	// out of. When empty, it is the door of TargetRoom leading back here.
+	TargetDoor string
+}
+
+// GetDoors returns the doors of the "Doors" object layer, in map order. It is
+// empty when the map has none.
+func (t *Tilemap) GetDoors() []*Door {
+	if t == nil {
+		return nil
+	}
+	layer, found := t.FindLayerByName("Doors")
+	if !found {
+		return nil
+	}
+
+	var doors []*Door
+	for _, obj := range layer.Objects {
+		area, ok := objectArea(obj)
+		if !ok {
+			continue
+		}
+		d := &Door{Name: objectName(obj), Area: area}
+		for _, p := range obj.Properties {
+			switch p.Name {
+			case "target_map":
+				d.TargetMap = p.Value
+			case "target_room":
+				d.TargetRoom = p.Value
+			case "target_door":
+				d.TargetDoor = p.Value
+			}
+		}
+		doors = append(doors, d)
+	}
+	return doors
+}
+
+// objectArea returns the rectangle of a rectangle object, or false for
+// points.
+func objectArea(obj *Obstacle) (image.Rectangle, bool) {
+	x, y := int(math.Round(obj.X)), int(math.Round(obj.Y))
+	w, h := int(math.Round(obj.Width)), int(math.Round(obj.Height))
+	if w <= 0 || h <= 0 {
+		return image.Rectangle{}, false
+	}
+	return image.Rect(x, y, x+w, y+h), true
+}
+
+// objectName returns the name of an object, or its Tiled id when it has
+// none.
+func objectName(obj *Obstacle) string {
+	if obj.Name != "" {
+		return obj.Name
+	}
+	return strconv.Itoa(obj.Id)
+}
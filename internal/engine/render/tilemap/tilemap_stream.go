@@ -0,0 +1,213 @@
+package tilemap
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultChunkTiles is the side, in tiles, of the square chunks tile layers
+// are pre-rendered in.
+const DefaultChunkTiles = 16
+
+// DefaultStreamMargin is how many chunks around the camera view are kept
+// rendered, and the bodies on them simulated.
+const DefaultStreamMargin = 1
+
+// layerChunk is one pre-rendered chunk of a tile layer. image is nil when the
+// chunk has no static tiles; animated lists the chunk's cells that are left
+// out of image because their tiles are animated.
+type layerChunk struct {
+	image    *ebiten.Image
+	animated []animatedCell
+}
+
+// SetChunkTiles sets the side, in tiles, of the chunks tile layers are
+// rendered in, and drops the chunks cached so far. Non-positive sizes fall
+// back to DefaultChunkTiles.
+func (t *Tilemap) SetChunkTiles(tiles int) {
+	t.chunkTiles = tiles
+	for _, layer := range t.Layers {
+		layer.evictChunks(image.Rectangle{})
+	}
+}
+
+// SetStreamMargin sets how many chunks beyond the camera view stay cached.
+// Non-positive values fall back to DefaultStreamMargin.
+func (t *Tilemap) SetStreamMargin(chunks int) {
+	t.streamMargin = chunks
+}
+
+// ActiveBounds returns the world area, in pixels, whose chunks are kept
+// rendered around the camera: the view grown by the stream margin and
+// snapped to the chunk grid. Scenes only simulate bodies that overlap it, so
+// a level costs the same however long it is.
+func (t *Tilemap) ActiveBounds(cam Camera) image.Rectangle {
+	r := t.chunkRange(viewRect(cam), t.margin())
+	cw, ch := t.chunkPixels()
+	return image.Rect(r.Min.X*cw, r.Min.Y*ch, r.Max.X*cw, r.Max.Y*ch)
+}
+
+func (t *Tilemap) chunkSide() int {
+	if t.chunkTiles <= 0 {
+		return DefaultChunkTiles
+	}
+	return t.chunkTiles
+}
+
+func (t *Tilemap) margin() int {
+	if t.streamMargin <= 0 {
+		return DefaultStreamMargin
+	}
+	return t.streamMargin
+}
+
+// chunkPixels is the size of a chunk in pixels.
+func (t *Tilemap) chunkPixels() (int, int) {
+	n := t.chunkSide()
+	return max(n*t.Tilewidth, 1), max(n*t.Tileheight, 1)
+}
+
+// viewRect is the world area the camera shows.
+func viewRect(cam Camera) image.Rectangle {
+	cx, cy := cam.GetActualCenter()
+	w, h := viewSize(cam)
+	hw, hh := w/2, h/2
+	return image.Rect(
+		int(math.Floor(cx-hw)), int(math.Floor(cy-hh)),
+		int(math.Ceil(cx+hw)), int(math.Ceil(cy+hh)),
+	)
+}
+
+// chunkRange returns the chunks, in chunk coordinates, that overlap area
+// (in pixels from the layer origin), grown by margin chunks on every side.
+func (t *Tilemap) chunkRange(area image.Rectangle, margin int) image.Rectangle {
+	cw, ch := t.chunkPixels()
+	return image.Rect(
+		floorDiv(area.Min.X, cw)-margin, floorDiv(area.Min.Y, ch)-margin,
+		floorDiv(area.Max.X-1, cw)+1+margin, floorDiv(area.Max.Y-1, ch)+1+margin,
+	)
+}
+
+// layerChunks is the range of chunks a layer has.
+func (t *Tilemap) layerChunks(layer *Layer) image.Rectangle {
+	n := t.chunkSide()
+	return image.Rect(0, 0, (layer.Width+n-1)/n, (layer.rows()+n-1)/n)
+}
+
+func (l *Layer) rows() int {
+	if l.Width <= 0 {
+		return 0
+	}
+	return len(l.Data) / l.Width
+}
+
+// drawChunks draws the chunks of a tile layer, with its origin at (x, y),
+// that the camera sees, followed by their animated tiles. Chunks within the
+// stream margin are built ahead of time and those further out are evicted.
+func (t *Tilemap) drawChunks(screen *ebiten.Image, cam Camera, layer *Layer, x, y float64) {
+	all := t.layerChunks(layer)
+	view := viewRect(cam).Sub(image.Pt(int(math.Floor(x)), int(math.Floor(y))))
+	// Tiles taller or wider than the grid reach into the chunks above and to
+	// the right of their own.
+	over := t.overhang()
+	view.Min.X -= over.X
+	view.Max.Y += over.Y
+
+	visible := t.chunkRange(view, 0).Intersect(all)
+	keep := t.chunkRange(view, t.margin()).Intersect(all)
+	for cy := keep.Min.Y; cy < keep.Max.Y; cy++ {
+		for cx := keep.Min.X; cx < keep.Max.X; cx++ {
+			t.chunk(layer, image.Pt(cx, cy))
+		}
+	}
+	// Keep one more ring than is built so a camera hovering over a chunk
+	// border doesn't rebuild the same chunks every frame.
+	layer.evictChunks(keep.Inset(-1))
+
+	cw, ch := t.chunkPixels()
+	for cy := visible.Min.Y; cy < visible.Max.Y; cy++ {
+		for cx := visible.Min.X; cx < visible.Max.X; cx++ {
+			c := layer.chunks[image.Pt(cx, cy)]
+			if c == nil || c.image == nil {
+				continue
+			}
+			op := layerDrawOptions(layer, x+float64(cx*cw), y+float64(cy*ch-over.Y))
+			cam.Draw(c.image, op, screen)
+		}
+	}
+	for cy := visible.Min.Y; cy < visible.Max.Y; cy++ {
+		for cx := visible.Min.X; cx < visible.Max.X; cx++ {
+			if c := layer.chunks[image.Pt(cx, cy)]; c != nil {
+				t.drawAnimatedTiles(screen, cam, layer, c.animated, x, y)
+			}
+		}
+	}
+}
+
+// chunk returns the chunk of layer at key, rendering it on first use.
+func (t *Tilemap) chunk(layer *Layer, key image.Point) *layerChunk {
+	if c, ok := layer.chunks[key]; ok {
+		return c
+	}
+	n := t.chunkSide()
+	cells := image.Rect(key.X*n, key.Y*n, (key.X+1)*n, (key.Y+1)*n).
+		Intersect(image.Rect(0, 0, layer.Width, layer.rows()))
+
+	c := &layerChunk{}
+	if !cells.Empty() {
+		over := t.overhang()
+		img := ebiten.NewImage(cells.Dx()*t.Tilewidth+over.X, cells.Dy()*t.Tileheight+over.Y)
+		origin := image.Pt(cells.Min.X*t.Tilewidth, cells.Min.Y*t.Tileheight-over.Y)
+		var drawn bool
+		c.animated, drawn = t.drawCells(layer, img, cells, origin, true)
+		if drawn {
+			c.image = img
+		} else {
+			img.Deallocate()
+		}
+	}
+	if layer.chunks == nil {
+		layer.chunks = make(map[image.Point]*layerChunk)
+	}
+	layer.chunks[key] = c
+	return c
+}
+
+// evictChunks frees the cached chunks outside keep.
+func (l *Layer) evictChunks(keep image.Rectangle) {
+	for key, c := range l.chunks {
+		if key.In(keep) {
+			continue
+		}
+		if c.image != nil {
+			c.image.Deallocate()
+		}
+		delete(l.chunks, key)
+	}
+}
+
+// overhang is how far, in pixels, tile images reach beyond their map cell:
+// right for wider tiles and up for taller ones.
+func (t *Tilemap) overhang() image.Point {
+	var w, h int
+	for _, ts := range t.Tilesets {
+		w, h = max(w, ts.Tilewidth), max(h, ts.Tileheight)
+		for _, tile := range ts.Tiles {
+			if tile.EbitenImage != nil {
+				b := tile.EbitenImage.Bounds()
+				w, h = max(w, b.Dx()), max(h, b.Dy())
+			}
+		}
+	}
+	return image.Pt(max(w-t.Tilewidth, 0), max(h-t.Tileheight, 0))
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
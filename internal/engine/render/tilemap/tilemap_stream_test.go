@@ -0,0 +1,113 @@
+package tilemap
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// stripMap is a 64×4 tile strip, 16 chunks of 4×4 tiles wide.
+func stripMap() *Tilemap {
+	data := make([]int, 64*4)
+	for i := range data {
+		data[i] = 1
+	}
+	tm := &Tilemap{
+		Tilewidth: 16, Tileheight: 16,
+		Tilesets: []*Tileset{{Firstgid: 1, Columns: 1, Tilewidth: 16, Tileheight: 16, EbitenImage: ebiten.NewImage(16, 16)}},
+		Layers:   []*Layer{{Type: "tilelayer", Visible: true, Opacity: 1, Width: 64, Data: data}},
+	}
+	tm.SetChunkTiles(4)
+	return tm
+}
+
+func cachedChunks(layer *Layer) []int {
+	var xs []int
+	for key := range layer.chunks {
+		xs = append(xs, key.X)
+	}
+	slices.Sort(xs)
+	return xs
+}
+
+func TestDrawChunksOnlyDrawsVisibleChunks(t *testing.T) {
+	tm := stripMap()
+	// The 100px view spans x 150..250: chunks 2 and 3 of 64px each.
+	cam := &recordingCamera{cx: 200, cy: 32}
+	tm.DrawBackground(ebiten.NewImage(1, 1), cam)
+
+	var xs []float64
+	for _, d := range cam.draws {
+		xs = append(xs, d.x)
+	}
+	if !slices.Equal(xs, []float64{128, 192}) {
+		t.Errorf("chunks drawn at %v, want [128 192]", xs)
+	}
+	if got := cachedChunks(tm.Layers[0]); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("cached chunks = %v, want the visible ones plus a margin of 1", got)
+	}
+}
+
+func TestDrawChunksEvictsChunksLeftBehind(t *testing.T) {
+	tm := stripMap()
+	cam := &recordingCamera{cx: 200, cy: 32}
+	tm.DrawBackground(ebiten.NewImage(1, 1), cam)
+
+	cam.cx = 1000
+	tm.DrawBackground(ebiten.NewImage(1, 1), cam)
+	if got := cachedChunks(tm.Layers[0]); !slices.Equal(got, []int{13, 14, 15}) {
+		t.Errorf("cached chunks = %v, want [13 14 15]", got)
+	}
+}
+
+func TestActiveBoundsSnapsViewToChunks(t *testing.T) {
+	tm := stripMap()
+	cam := &recordingCamera{cx: 200, cy: 32}
+	if got, want := tm.ActiveBounds(cam), image.Rect(64, -128, 320, 192); got != want {
+		t.Errorf("ActiveBounds = %v, want %v", got, want)
+	}
+	tm.SetStreamMargin(2)
+	if got, want := tm.ActiveBounds(cam), image.Rect(0, -192, 384, 256); got != want {
+		t.Errorf("ActiveBounds with margin 2 = %v, want %v", got, want)
+	}
+}
+
+func TestTallTilesReachIntoChunkAbove(t *testing.T) {
+	tm := &Tilemap{
+		Tilewidth: 16, Tileheight: 16,
+		Tilesets: []*Tileset{{Firstgid: 1, Tilewidth: 16, Tileheight: 32,
+			Tiles: []*Tile{{ID: 0, EbitenImage: ebiten.NewImage(16, 32)}}}},
+		Layers: []*Layer{{Type: "tilelayer", Visible: true, Opacity: 1, Width: 1, Data: []int{0, 1}}},
+	}
+	tm.SetChunkTiles(1)
+	cam := &recordingCamera{cx: 8, cy: 50}
+	tm.DrawBackground(ebiten.NewImage(1, 1), cam)
+
+	// Only the second row's chunk has a tile; its image starts a row higher
+	// so the tall tile isn't cut off.
+	if len(cam.draws) != 1 || cam.draws[0].y != 0 {
+		t.Fatalf("draws = %+v, want one chunk at y 0", cam.draws)
+	}
+	if h := cam.draws[0].img.Bounds().Dy(); h != 32 {
+		t.Errorf("chunk image height = %d, want 32", h)
+	}
+}
+
+type zoomedCamera struct {
+	recordingCamera
+	zoom float64
+}
+
+func (c *zoomedCamera) Zoom() float64 { return c.zoom }
+
+func TestDrawChunksCoversZoomedOutView(t *testing.T) {
+	tm := stripMap()
+	// At zoom 0.5 the 100px camera shows 200px: x 100..300, chunks 1 to 4.
+	cam := &zoomedCamera{recordingCamera: recordingCamera{cx: 200, cy: 32}, zoom: 0.5}
+	tm.DrawBackground(ebiten.NewImage(1, 1), cam)
+	if len(cam.draws) != 4 {
+		t.Errorf("chunks drawn = %d, want 4", len(cam.draws))
+	}
+}
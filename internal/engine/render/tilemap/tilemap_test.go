@@ -1,7 +1,9 @@
 package tilemap
 
 import (
+	"image"
 	"testing"
+	"time"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
@@ -151,3 +153,127 @@ func TestGetItemsEnemiesNpcsPositions(t *testing.T) {
 		t.Fatalf("unexpected npcs: %#v", npcs)
 	}
 }
+
+func TestGetEnemiesPositionIDTags(t *testing.T) {
+	tm := &Tilemap{
+		Tilewidth:  16,
+		Tileheight: 16,
+		Layers: []*Layer{{
+			Name:    "Enemies",
+			Visible: true,
+			Type:    "objectgroup",
+			Objects: []*Obstacle{
+				{
+					Gid: 1, X: 30, Y: 40, Width: 16, Height: 16,
+					Properties: []Property{{Name: "enemy_type", Value: "bat"}, {Name: "body_id", Value: "E1"}, {Name: "tags", Value: "boss, wave_1"}},
+				},
+				{
+					Gid: 1, X: 60, Y: 40, Width: 16, Height: 16,
+					Properties: []Property{{Name: "enemy_type", Value: "bat"}, {Name: "body_id", Value: "E2"}},
+				},
+			},
+		}},
+		Tilesets: []*Tileset{{Firstgid: 1, Columns: 1, Tilewidth: 16, Tileheight: 16}},
+	}
+
+	enemies := tm.GetEnemiesPositionID()
+	if len(enemies) != 2 {
+		t.Fatalf("unexpected enemies: %#v", enemies)
+	}
+	if !enemies[0].HasTag("boss") || !enemies[0].HasTag("wave_1") || enemies[0].HasTag("wave_2") {
+		t.Errorf("unexpected enemy tags: %q", enemies[0].Tags)
+	}
+	if len(enemies[1].Tags) != 0 || enemies[1].HasTag("boss") {
+		t.Errorf("untagged enemy has tags: %q", enemies[1].Tags)
+	}
+}
+
+func TestGetCameraZones(t *testing.T) {
+	tm := &Tilemap{
+		Tilesets: []*Tileset{{Firstgid: 1}},
+		Layers: []*Layer{{Name: "CameraZones", Type: "objectgroup", Visible: true, Objects: []*Obstacle{
+			{X: 100, Y: 0, Width: 320, Height: 240, Properties: []Property{
+				{Name: "zoom", Value: "1.5"},
+				{Name: "offset_y", Value: "-24"},
+				{Name: "blend", Value: "500"},
+			}},
+			{X: 500, Y: 0, Width: 64, Height: 64, Properties: []Property{{Name: "clamp", Value: "false"}}},
+			{X: 0, Y: 0}, // a point, not a zone
+		}}},
+	}
+
+	zones := tm.GetCameraZones()
+	if len(zones) != 2 {
+		t.Fatalf("zones = %d, want 2", len(zones))
+	}
+	z := zones[0]
+	if z.Area != image.Rect(100, 0, 420, 240) || z.Zoom != 1.5 || z.OffsetY != -24 ||
+		z.Blend != 500*time.Millisecond || !z.Clamp {
+		t.Errorf("zone 0 = %+v", z)
+	}
+	if zones[1].Clamp {
+		t.Error("clamp=false was ignored")
+	}
+}
+
+func TestGetArenas(t *testing.T) {
+	tm := &Tilemap{
+		Tilesets: []*Tileset{{Firstgid: 1}},
+		Layers: []*Layer{{Name: "Arenas", Type: "objectgroup", Visible: true, Objects: []*Obstacle{
+			{Id: 7, Name: "docks", X: 640, Y: 0, Width: 320, Height: 240, Properties: []Property{
+				{Name: "sequence", Value: "assets/sequences/docks_wave.json"},
+			}},
+			{Id: 9, X: 1280, Y: 0, Width: 320, Height: 240},
+			{Id: 10, X: 0, Y: 0}, // a point, not an arena
+		}}},
+	}
+
+	arenas := tm.GetArenas()
+	if len(arenas) != 2 {
+		t.Fatalf("arenas = %d, want 2", len(arenas))
+	}
+	if a := arenas[0]; a.ID != "docks" || a.Area != image.Rect(640, 0, 960, 240) ||
+		a.Sequence != "assets/sequences/docks_wave.json" {
+		t.Errorf("arena 0 = %+v", a)
+	}
+	if arenas[1].ID != "9" {
+		t.Errorf("unnamed arena ID = %q, want the Tiled id", arenas[1].ID)
+	}
+}
+
+func TestGetRoomsAndDoors(t *testing.T) {
+	tm := &Tilemap{
+		Tilesets: []*Tileset{{Firstgid: 1}},
+		Layers: []*Layer{
+			{Name: "Camera", Type: "objectgroup", Visible: true, Objects: []*Obstacle{
+				{Id: 1, X: 0, Y: 0}, // the camera start point
+				{Id: 2, Name: "hall", X: 0, Y: 0, Width: 320, Height: 240},
+				{Id: 3, X: 320, Y: 0, Width: 320, Height: 240},
+			}},
+			{Name: "Doors", Type: "objectgroup", Visible: true, Objects: []*Obstacle{
+				{Id: 4, Name: "to_crypt", X: 600, Y: 160, Width: 40, Height: 64, Properties: []Property{
+					{Name: "target_map", Value: "assets/maps/crypt.tmj"},
+					{Name: "target_room", Value: "stairs"},
+					{Name: "target_door", Value: "from_hall"},
+				}},
+			}},
+		},
+	}
+
+	rooms := tm.GetRooms()
+	if len(rooms) != 2 {
+		t.Fatalf("rooms = %d, want 2", len(rooms))
+	}
+	if rooms[0].Name != "hall" || rooms[0].Area != image.Rect(0, 0, 320, 240) || rooms[1].Name != "3" {
+		t.Errorf("rooms = %+v, %+v", rooms[0], rooms[1])
+	}
+
+	doors := tm.GetDoors()
+	if len(doors) != 1 {
+		t.Fatalf("doors = %d, want 1", len(doors))
+	}
+	if d := doors[0]; d.Name != "to_crypt" || d.Area != image.Rect(600, 160, 640, 224) ||
+		d.TargetMap != "assets/maps/crypt.tmj" || d.TargetRoom != "stairs" || d.TargetDoor != "from_hall" {
+		t.Errorf("door = %+v", d)
+	}
+}
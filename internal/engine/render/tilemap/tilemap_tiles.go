@@ -0,0 +1,196 @@
+package tilemap
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tile is an entry of a Tiled tileset's "tiles" array: extra data for one
+// tile of the tileset, such as an animation or custom properties.
+type Tile struct {
+	ID         int         `json:"id"`
+	Animation  []TileFrame `json:"animation"`
+	Properties []*Property `json:"properties"`
+	// Image is set on tiles of an image-collection tileset, which have an
+	// image each instead of a cell of a shared tileset image.
+	Image       string        `json:"image"`
+	Imagewidth  int           `json:"imagewidth"`
+	Imageheight int           `json:"imageheight"`
+	EbitenImage *ebiten.Image `json:"-"`
+}
+
+// TileFrame is one frame of a Tiled tile animation.
+type TileFrame struct {
+	TileID int `json:"tileid" xml:"tileid,attr"`
+	// Duration is in milliseconds.
+	Duration int `json:"duration" xml:"duration,attr"`
+}
+
+// TileProperties are the per-tile custom properties the engine understands.
+// Set them on tiles in the Tiled tileset editor.
+type TileProperties struct {
+	// Solid tiles block movement on any layer.
+	Solid bool
+	// OneWay tiles are platforms that only block from above.
+	OneWay bool
+	// Hazard tiles damage actors that touch them. Damage defaults to 1.
+	Hazard bool
+	Damage int
+	// Friction scales ground friction on the tile; nil keeps the default.
+	Friction *float64
+	// Slope tiles are ramps whose surface runs straight from SlopeLeft at the
+	// left edge to SlopeRight at the right edge, as fractions of the tile
+	// height above its bottom: a 45° ramp up to the right is 0 → 1, and a
+	// gentle two-tile ramp is 0 → 0.5 followed by 0.5 → 1.
+	Slope                 bool
+	SlopeLeft, SlopeRight float64
+}
+
+// tile returns the tileset entry for gid, or nil when the tile has none.
+func (ts *Tileset) tile(gid int) *Tile {
+	id := tilesetSourceID(ts, gid)
+	for _, tile := range ts.Tiles {
+		if tile.ID == id {
+			return tile
+		}
+	}
+	return nil
+}
+
+// TileProperties returns the collision properties of the tile with the given
+// raw gid. A horizontally flipped slope tile mirrors its ramp; LoadTilemap
+// rejects slope tiles flipped vertically or diagonally, and their other flip
+// flags are ignored.
+func (t *Tilemap) TileProperties(rawGID int) TileProperties {
+	gid, flipH, _, _ := extractGIDAndFlags(rawGID)
+	ts := t.findTileset(gid)
+	if ts == nil {
+		return TileProperties{}
+	}
+	tile := ts.tile(gid)
+	if tile == nil {
+		return TileProperties{}
+	}
+	var props TileProperties
+	for _, p := range tile.Properties {
+		switch p.Name {
+		case "solid":
+			props.Solid, _ = strconv.ParseBool(p.Value)
+		case "one_way":
+			props.OneWay, _ = strconv.ParseBool(p.Value)
+		case "hazard":
+			props.Hazard, _ = strconv.ParseBool(p.Value)
+		case "damage":
+			props.Damage, _ = strconv.Atoi(p.Value)
+		case "friction":
+			if f, err := strconv.ParseFloat(p.Value, 64); err == nil {
+				props.Friction = &f
+			}
+		case "slope_left":
+			props.SlopeLeft, _ = strconv.ParseFloat(p.Value, 64)
+			props.Slope = true
+		case "slope_right":
+			props.SlopeRight, _ = strconv.ParseFloat(p.Value, 64)
+			props.Slope = true
+		}
+	}
+	if props.Slope && flipH {
+		props.SlopeLeft, props.SlopeRight = props.SlopeRight, props.SlopeLeft
+	}
+	if props.Damage > 0 {
+		props.Hazard = true
+	}
+	if props.Hazard && props.Damage <= 0 {
+		props.Damage = 1
+	}
+	return props
+}
+
+// validateSlopeFlips reports every slope tile placed flipped vertically or
+// diagonally: ramps only have a walkable top, so those flips have no meaning.
+func (t *Tilemap) validateSlopeFlips() error {
+	var errs []error
+	for _, layer := range t.Layers {
+		if layer.Type != "tilelayer" || layer.Width <= 0 {
+			continue
+		}
+		for i, raw := range layer.Data {
+			if _, _, flipV, flipD := extractGIDAndFlags(raw); (flipV || flipD) && t.TileProperties(raw).Slope {
+				errs = append(errs, fmt.Errorf("layer %q cell (%d,%d): slope tiles can only be flipped horizontally",
+					layer.Name, i%layer.Width, i/layer.Width))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetFrame sets the game frame tile animations are shown at. Scenes call it
+// every update with AppContext.FrameCount.
+func (t *Tilemap) SetFrame(frame uint64) {
+	t.frame = frame
+}
+
+// animatedGID returns the gid to draw for an animated tile at the current
+// frame, or gid itself when the tile is not animated.
+func (t *Tilemap) animatedGID(ts *Tileset, gid int) int {
+	tile := ts.tile(gid)
+	if tile == nil || len(tile.Animation) == 0 {
+		return gid
+	}
+	total := 0
+	for _, f := range tile.Animation {
+		total += f.Duration
+	}
+	if total <= 0 {
+		return gid
+	}
+	elapsed := int(timing.ToDuration(int(t.frame)).Milliseconds()) % total
+	for _, f := range tile.Animation {
+		if elapsed < f.Duration {
+			return ts.Firstgid + f.TileID
+		}
+		elapsed -= f.Duration
+	}
+	return gid
+}
+
+// animatedCell is a tile layer cell whose tile is animated.
+type animatedCell struct {
+	index int
+	raw   int
+}
+
+// isAnimated reports whether the tile with the given gid has an animation.
+func (t *Tilemap) isAnimated(gid int) bool {
+	ts := t.findTileset(gid)
+	if ts == nil {
+		return false
+	}
+	tile := ts.tile(gid)
+	return tile != nil && len(tile.Animation) > 0
+}
+
+// drawAnimatedTiles draws the current frame of the given animated cells of
+// layer, with the layer origin at (x, y).
+func (t *Tilemap) drawAnimatedTiles(screen *ebiten.Image, cam Camera, layer *Layer, cells []animatedCell, x, y float64) {
+	for _, cell := range cells {
+		gid, flipH, flipV, flipD := extractGIDAndFlags(cell.raw)
+		ts := t.findTileset(gid)
+		if ts == nil {
+			continue
+		}
+		img := ts.tileImage(t.animatedGID(ts, gid))
+		if img == nil {
+			continue
+		}
+		op := layerDrawOptions(layer, 0, 0)
+		applyFlips(op, flipH, flipV, flipD, float64(img.Bounds().Dx()), float64(img.Bounds().Dy()))
+		dx, dy := t.cellPosition(ts, img, cell.index%layer.Width, cell.index/layer.Width)
+		op.GeoM.Translate(x+dx, y+dy)
+		cam.Draw(img, op, screen)
+	}
+}
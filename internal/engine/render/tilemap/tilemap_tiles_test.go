@@ -0,0 +1,147 @@
+package tilemap
+
+import (
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"image"
+	"testing"
+	"testing/fstest"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// tileMap has a water tile animated over tiles 0 and 1 (100 ms each), a solid
+// tile 2, a slippery one-way tile 3 and a spike tile 4.
+const tileMap = `{
+  "width": 4, "height": 1, "tilewidth": 16, "tileheight": 16,
+  "tilesets": [{"firstgid": 1, "columns": 1, "image": "tiles.png", "tilewidth": 16, "tileheight": 16,
+    "tiles": [
+      {"id": 0, "animation": [{"tileid": 0, "duration": 100}, {"tileid": 1, "duration": 100}]},
+      {"id": 2, "properties": [{"name": "solid", "type": "bool", "value": true}]},
+      {"id": 3, "properties": [{"name": "one_way", "type": "bool", "value": true},
+                               {"name": "friction", "type": "float", "value": 0.25}]},
+      {"id": 4, "properties": [{"name": "damage", "type": "int", "value": 3}]}
+    ]}],
+  "layers": [
+    {"type": "tilelayer", "id": 7, "name": "ground", "data": [1, 3, 4, 5], "width": 4, "height": 1,
+     "opacity": 1, "visible": true}
+  ]
+}`
+
+func loadTileMap(t *testing.T) *Tilemap {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"maps/level.tmj": {Data: []byte(tileMap)},
+		"maps/tiles.png": {Data: pngBytes(t, 16, 80)},
+	}
+	tm, err := LoadTilemap(fsys, "maps/level.tmj")
+	if err != nil {
+		t.Fatalf("LoadTilemap: %v", err)
+	}
+	return tm
+}
+
+func TestTilePropertiesFromTileset(t *testing.T) {
+	tm := loadTileMap(t)
+
+	if p := tm.TileProperties(3); !p.Solid || p.OneWay || p.Hazard {
+		t.Errorf("tile 2 = %+v, want solid", p)
+	}
+	p := tm.TileProperties(4 | int(flippedHorizontallyFlag))
+	if !p.OneWay || p.Friction == nil || *p.Friction != 0.25 {
+		t.Errorf("flipped tile 3 = %+v, want one-way with friction 0.25", p)
+	}
+	if p := tm.TileProperties(5); !p.Hazard || p.Damage != 3 || p.Solid {
+		t.Errorf("tile 4 = %+v, want non-solid hazard dealing 3", p)
+	}
+	if p := tm.TileProperties(1); p != (TileProperties{}) {
+		t.Errorf("animated tile without properties = %+v", p)
+	}
+}
+
+func TestAnimatedTileFollowsFrameCounter(t *testing.T) {
+	ebiten.SetTPS(60)
+	tm := loadTileMap(t)
+	ts := tm.Tilesets[0]
+
+	for _, tc := range []struct {
+		frame uint64
+		want  int
+	}{{0, 1}, {5, 1}, {6, 2}, {11, 2}, {12, 1}} {
+		tm.SetFrame(tc.frame)
+		if got := tm.animatedGID(ts, 1); got != tc.want {
+			t.Errorf("frame %d: gid %d, want %d", tc.frame, got, tc.want)
+		}
+	}
+	if got := tm.animatedGID(ts, 3); got != 3 {
+		t.Errorf("static tile gid = %d, want 3", got)
+	}
+}
+
+func TestDrawBackgroundDrawsAnimatedCellsEachFrame(t *testing.T) {
+	tm := loadTileMap(t)
+	cam := &recordingCamera{}
+	tm.DrawBackground(ebiten.NewImage(1, 1), cam)
+
+	if len(cam.draws) != 2 {
+		t.Fatalf("draws = %d, want the layer image plus one animated cell", len(cam.draws))
+	}
+	c := tm.Layers[0].chunks[image.Point{}]
+	if cam.draws[0].img != c.image {
+		t.Error("layer image not drawn first")
+	}
+	if len(c.animated) != 1 || c.animated[0].index != 0 {
+		t.Errorf("animated cells = %+v, want cell 0", c.animated)
+	}
+}
+
+type spyDamageable struct {
+	*bodyphysics.CollidableBody
+	taken int
+}
+
+func (s *spyDamageable) TakeDamage(info combat.DamageInfo) { s.taken += info.Amount }
+
+func TestCreateCollisionBodiesFromTileProperties(t *testing.T) {
+	tm := loadTileMap(t)
+	sp := space.NewSpace()
+	tm.CreateCollisionBodies(sp, nil)
+
+	if sp.Find("TILE_7_0_0") != nil {
+		t.Error("tile without properties got a body")
+	}
+	if b := sp.Find("TILE_7_16_0"); b == nil || !b.IsObstructive() {
+		t.Errorf("solid tile body = %v, want obstructive", b)
+	}
+	oneWay, ok := sp.Find("TILE_7_32_0").(*bodyphysics.ObstacleRect)
+	if !ok || !oneWay.IsOneWay() || !oneWay.IsObstructive() || oneWay.Friction() != 0.25 {
+		t.Errorf("one-way tile body = %v", oneWay)
+	}
+
+	hazard := sp.Find("TILE_7_48_0")
+	if hazard == nil || hazard.IsObstructive() {
+		t.Fatalf("hazard tile body = %v, want non-obstructive", hazard)
+	}
+	victim := &spyDamageable{CollidableBody: bodyphysics.NewCollidableBodyFromRect(bodyphysics.NewRect(0, 0, 4, 4))}
+	hazard.OnTouch(victim)
+	if victim.taken != 3 {
+		t.Errorf("hazard dealt %d, want 3", victim.taken)
+	}
+}
+
+func TestObstaclesLayerTilesHonorOneWay(t *testing.T) {
+	tm := loadTileMap(t)
+	tm.Layers[0].Name = "Obstacles"
+	sp := space.NewSpace()
+	tm.CreateCollisionBodies(sp, func(string) body.Touchable { return nil })
+
+	o, ok := sp.Find("OBSTACLE_32_0").(*bodyphysics.ObstacleRect)
+	if !ok || !o.IsOneWay() {
+		t.Errorf("Obstacles tile with one_way = %v, want one-way platform", o)
+	}
+	if sp.Find("TILE_7_16_0") != nil {
+		t.Error("Obstacles layer tiles were added twice")
+	}
+}
@@ -0,0 +1,213 @@
+package tilemap
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// tsxTileset mirrors the XML of an external Tiled tileset (.tsx).
+type tsxTileset struct {
+	Name       string    `xml:"name,attr"`
+	TileWidth  int       `xml:"tilewidth,attr"`
+	TileHeight int       `xml:"tileheight,attr"`
+	Spacing    int       `xml:"spacing,attr"`
+	Margin     int       `xml:"margin,attr"`
+	TileCount  int       `xml:"tilecount,attr"`
+	Columns    int       `xml:"columns,attr"`
+	Image      *tsxImage `xml:"image"`
+	Tiles      []tsxTile `xml:"tile"`
+}
+
+type tsxImage struct {
+	Source string `xml:"source,attr"`
+	Trans  string `xml:"trans,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+}
+
+type tsxTile struct {
+	ID         int           `xml:"id,attr"`
+	Image      *tsxImage     `xml:"image"`
+	Properties []tsxProperty `xml:"properties>property"`
+	Animation  []TileFrame   `xml:"animation>frame"`
+}
+
+// tsxProperty holds a property value from the value attribute, or from the
+// element text for multi-line strings.
+type tsxProperty struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"`
+}
+
+// parseTSX decodes an external XML tileset into a Tileset. Firstgid is left
+// zero; it comes from the referencing map.
+func parseTSX(data []byte) (*Tileset, error) {
+	var x tsxTileset
+	if err := xml.Unmarshal(data, &x); err != nil {
+		return nil, err
+	}
+	ts := &Tileset{
+		Name:       x.Name,
+		Tilewidth:  x.TileWidth,
+		Tileheight: x.TileHeight,
+		Spacing:    x.Spacing,
+		Margin:     x.Margin,
+		Tilecount:  x.TileCount,
+		Columns:    x.Columns,
+	}
+	if x.Image != nil {
+		ts.Image = x.Image.Source
+		ts.Imagewidth = x.Image.Width
+		ts.Imageheight = x.Image.Height
+		ts.Transparentcolor = x.Image.Trans
+	}
+	for _, xt := range x.Tiles {
+		tile := &Tile{ID: xt.ID, Animation: xt.Animation}
+		if xt.Image != nil {
+			tile.Image = xt.Image.Source
+			tile.Imagewidth = xt.Image.Width
+			tile.Imageheight = xt.Image.Height
+		}
+		for _, p := range xt.Properties {
+			value := p.Value
+			if value == "" {
+				value = p.Text
+			}
+			tile.Properties = append(tile.Properties, &Property{Name: p.Name, Type: p.Type, Value: value})
+		}
+		ts.Tiles = append(ts.Tiles, tile)
+	}
+	return ts, nil
+}
+
+// loadTileset resolves an external tileset reference and loads the tileset's
+// images. Paths are relative to the file that names them: the map for
+// embedded tilesets, the tileset file for external ones.
+func loadTileset(fsys fs.FS, mapDir string, ts *Tileset) error {
+	dir := mapDir
+	if ts.Source != "" {
+		source := path.Join(mapDir, ts.Source)
+		data, err := fs.ReadFile(fsys, source)
+		if err != nil {
+			return fmt.Errorf("failed to read tileset %s: %w", source, err)
+		}
+		var ext *Tileset
+		switch strings.ToLower(path.Ext(source)) {
+		case ".tsj", ".json":
+			ext = &Tileset{}
+			err = json.Unmarshal(data, ext)
+		default:
+			ext, err = parseTSX(data)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse tileset %s: %w", source, err)
+		}
+		ext.Firstgid, ext.Source = ts.Firstgid, ts.Source
+		*ts = *ext
+		dir = path.Dir(source)
+	}
+
+	if ts.Image != "" {
+		imagePath := path.Join(dir, ts.Image)
+		img, err := loadImageKeyed(fsys, imagePath, ts.Transparentcolor)
+		if err != nil {
+			return fmt.Errorf("failed to load tileset image %s: %w", imagePath, err)
+		}
+		ts.EbitenImage = img
+	}
+	for _, tile := range ts.Tiles {
+		if tile.Image == "" {
+			continue
+		}
+		imagePath := path.Join(dir, tile.Image)
+		img, err := loadImageKeyed(fsys, imagePath, ts.Transparentcolor)
+		if err != nil {
+			return fmt.Errorf("failed to load tile image %s: %w", imagePath, err)
+		}
+		tile.EbitenImage = img
+	}
+	return nil
+}
+
+// tileImage returns the image for gid: a cell of the tileset image, or the
+// tile's own image in an image-collection tileset. It is nil when the image
+// is missing.
+func (ts *Tileset) tileImage(gid int) *ebiten.Image {
+	if ts.EbitenImage != nil && ts.Columns > 0 {
+		return ts.EbitenImage.SubImage(tilesetSourceRect(ts, gid)).(*ebiten.Image)
+	}
+	if tile := ts.tile(gid); tile != nil {
+		return tile.EbitenImage
+	}
+	return nil
+}
+
+// cellPosition returns where a tile image is drawn for cell (x, y) of a tile
+// layer. Like Tiled, images are aligned to the bottom-left of the cell, so
+// tiles taller than the map grid extend upwards.
+func (t *Tilemap) cellPosition(ts *Tileset, img *ebiten.Image, x, y int) (float64, float64) {
+	cellW, cellH := t.Tilewidth, t.Tileheight
+	if cellW <= 0 || cellH <= 0 {
+		cellW, cellH = ts.Tilewidth, ts.Tileheight
+	}
+	return float64(x * cellW), float64((y+1)*cellH - img.Bounds().Dy())
+}
+
+// loadImageKeyed loads an image and makes pixels of the transparent color
+// (Tiled's "#rrggbb" or "rrggbb") fully transparent.
+func loadImageKeyed(fsys fs.FS, path, transparent string) (*ebiten.Image, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := parseHexColor(transparent); ok {
+		img = applyColorKey(img, key)
+	}
+	return ebiten.NewImageFromImage(img), nil
+}
+
+func parseHexColor(s string) (color.NRGBA, bool) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) == 8 {
+		// Tiled writes #aarrggbb when alpha is set; the key ignores it.
+		s = s[2:]
+	}
+	if len(s) != 6 {
+		return color.NRGBA{}, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	return color.NRGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, true
+}
+
+func applyColorKey(src image.Image, key color.NRGBA) image.Image {
+	b := src.Bounds()
+	dst := image.NewNRGBA(b)
+	draw.Draw(dst, b, src, b.Min, draw.Src)
+	for i := 0; i < len(dst.Pix); i += 4 {
+		p := dst.Pix[i : i+4 : i+4]
+		if p[0] == key.R && p[1] == key.G && p[2] == key.B {
+			p[0], p[1], p[2], p[3] = 0, 0, 0, 0
+		}
+	}
+	return dst
+}
@@ -0,0 +1,180 @@
+package tilemap
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+const externalTSX = `<?xml version="1.0" encoding="UTF-8"?>
+<tileset version="1.10" name="props" tilewidth="16" tileheight="16" spacing="1" tilecount="2" columns="1">
+ <image source="../art/props.png" trans="ff00f6" width="16" height="33"/>
+ <tile id="1">
+  <properties>
+   <property name="solid" type="bool" value="true"/>
+   <property name="note">multi
+line</property>
+  </properties>
+  <animation>
+   <frame tileid="0" duration="50"/>
+   <frame tileid="1" duration="150"/>
+  </animation>
+ </tile>
+</tileset>`
+
+const externalTilesetMap = `{
+  "width": 2, "height": 1, "tilewidth": 16, "tileheight": 16,
+  "tilesets": [{"firstgid": 1, "source": "../tilesets/props.tsx"}],
+  "layers": [{"type": "tilelayer", "data": [1, 2], "width": 2, "height": 1, "opacity": 1, "visible": true}]
+}`
+
+func TestLoadTilemapResolvesExternalTSX(t *testing.T) {
+	fsys := fstest.MapFS{
+		"maps/level.tmj":     {Data: []byte(externalTilesetMap)},
+		"tilesets/props.tsx": {Data: []byte(externalTSX)},
+		"art/props.png":      {Data: pngBytes(t, 16, 33)},
+	}
+	tm, err := LoadTilemap(fsys, "maps/level.tmj")
+	if err != nil {
+		t.Fatalf("LoadTilemap: %v", err)
+	}
+	ts := tm.Tilesets[0]
+	if ts.Firstgid != 1 || ts.Name != "props" || ts.Columns != 1 || ts.Spacing != 1 || ts.Tilewidth != 16 {
+		t.Errorf("tileset = %+v", ts)
+	}
+	if ts.EbitenImage == nil || ts.Transparentcolor != "ff00f6" {
+		t.Errorf("tileset image %v, transparent color %q", ts.EbitenImage, ts.Transparentcolor)
+	}
+	if !tm.TileProperties(2).Solid {
+		t.Error("tile properties from the .tsx were not read")
+	}
+	if got := ts.tile(2).Properties[1].Value; got != "multi\nline" {
+		t.Errorf("multi-line property = %q", got)
+	}
+	if !slices.Equal(ts.tile(2).Animation, []TileFrame{{0, 50}, {1, 150}}) {
+		t.Errorf("animation = %v", ts.tile(2).Animation)
+	}
+}
+
+func TestLoadTilemapMissingExternalTileset(t *testing.T) {
+	fsys := fstest.MapFS{"maps/level.tmj": {Data: []byte(externalTilesetMap)}}
+	if _, err := LoadTilemap(fsys, "maps/level.tmj"); err == nil {
+		t.Fatal("expected an error for a missing .tsx")
+	}
+}
+
+func TestParseTSXSampleAssets(t *testing.T) {
+	for _, name := range []string{"sample-enemies.tsx", "sample-rewards.tsx"} {
+		data, err := os.ReadFile("../../../../assets/tilemap/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts, err := parseTSX(data)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if ts.Image == "" || ts.Columns == 0 || ts.Tilewidth == 0 {
+			t.Errorf("%s parsed as %+v", name, ts)
+		}
+	}
+}
+
+const collectionMap = `{
+  "width": 2, "height": 2, "tilewidth": 16, "tileheight": 16,
+  "tilesets": [{"firstgid": 1, "columns": 0, "tilewidth": 16, "tileheight": 32,
+    "tiles": [
+      {"id": 0, "image": "tree.png", "imagewidth": 16, "imageheight": 32},
+      {"id": 5, "image": "rock.png", "imagewidth": 16, "imageheight": 16}
+    ]}],
+  "layers": [{"type": "tilelayer", "data": [0, 0, 1, 6], "width": 2, "height": 2, "opacity": 1, "visible": true}]
+}`
+
+func TestImageCollectionTileset(t *testing.T) {
+	fsys := fstest.MapFS{
+		"maps/level.tmj": {Data: []byte(collectionMap)},
+		"maps/tree.png":  {Data: pngBytes(t, 16, 32)},
+		"maps/rock.png":  {Data: pngBytes(t, 16, 16)},
+	}
+	tm, err := LoadTilemap(fsys, "maps/level.tmj")
+	if err != nil {
+		t.Fatalf("LoadTilemap: %v", err)
+	}
+	ts := tm.Tilesets[0]
+	tree, rock := ts.tileImage(1), ts.tileImage(6)
+	if tree == nil || rock == nil || tree.Bounds().Dy() != 32 {
+		t.Fatalf("collection images tree=%v rock=%v", tree, rock)
+	}
+	// The tall tree in cell (0,1) is bottom-aligned, so it reaches row 0.
+	if x, y := tm.cellPosition(ts, tree, 0, 1); x != 0 || y != 0 {
+		t.Errorf("tree drawn at %v,%v, want 0,0", x, y)
+	}
+	if x, y := tm.cellPosition(ts, rock, 1, 1); x != 16 || y != 16 {
+		t.Errorf("rock drawn at %v,%v, want 16,16", x, y)
+	}
+}
+
+func TestApplyColorKey(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, color.NRGBA{R: 0xff, B: 0xf6, A: 0xff})
+	src.Set(1, 0, color.NRGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff})
+
+	key, ok := parseHexColor("#ff00f6")
+	if !ok {
+		t.Fatal("parseHexColor rejected #ff00f6")
+	}
+	out := applyColorKey(src, key)
+	if _, _, _, a := out.At(0, 0).RGBA(); a != 0 {
+		t.Error("key color pixel is not transparent")
+	}
+	if _, _, _, a := out.At(1, 0).RGBA(); a == 0 {
+		t.Error("other pixel became transparent")
+	}
+	if _, ok := parseHexColor(""); ok {
+		t.Error("empty color parsed as a key")
+	}
+}
+
+const infiniteMap = `{
+  "infinite": true, "tilewidth": 16, "tileheight": 16, "parallaxoriginx": 0,
+  "tilesets": [{"firstgid": 1, "columns": 1, "image": "tiles.png", "tilewidth": 16, "tileheight": 16}],
+  "layers": [
+    {"type": "tilelayer", "name": "ground", "opacity": 1, "visible": true, "startx": -2, "starty": 0,
+     "chunks": [
+       {"x": -2, "y": 0, "width": 2, "height": 1, "data": [1, 2]},
+       {"x": 0, "y": 1, "width": 2, "height": 1, "data": [3, 4]}
+     ]},
+    {"type": "objectgroup", "name": "PlayerStart", "visible": true,
+     "objects": [{"id": 1, "x": 8, "y": 16}]}
+  ]
+}`
+
+func TestLoadTilemapFlattensInfiniteChunks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"maps/level.tmj": {Data: []byte(infiniteMap)},
+		"maps/tiles.png": {Data: pngBytes(t, 16, 64)},
+	}
+	tm, err := LoadTilemap(fsys, "maps/level.tmj")
+	if err != nil {
+		t.Fatalf("LoadTilemap: %v", err)
+	}
+	ground := tm.Layers[0]
+	if ground.Width != 4 || ground.Height != 2 || ground.Chunks != nil {
+		t.Fatalf("flattened layer %dx%d chunks=%v", ground.Width, ground.Height, ground.Chunks)
+	}
+	if want := []int{1, 2, 0, 0, 0, 0, 3, 4}; !slices.Equal(ground.Data, want) {
+		t.Errorf("data = %v, want %v", ground.Data, want)
+	}
+	if w, h := tm.PixelSize(); w != 64 || h != 32 {
+		t.Errorf("PixelSize = %d,%d, want 64,32", w, h)
+	}
+	// Everything moves right by the two tiles left of the origin.
+	if obj := tm.Layers[1].Objects[0]; obj.X != 40 || obj.Y != 16 {
+		t.Errorf("object at %v,%v, want 40,16", obj.X, obj.Y)
+	}
+	if tm.ParallaxOriginX != 32 {
+		t.Errorf("parallax origin x = %v, want 32", tm.ParallaxOriginX)
+	}
+}
@@ -0,0 +1,186 @@
+package replay
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	"github.com/boilerplate/ebiten-template/internal/engine/input"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/rng"
+)
+
+// Mode selects what a Controller does each frame.
+type Mode int
+
+const (
+	// ModeRecord samples live input and appends it to the recording.
+	ModeRecord Mode = iota
+	// ModePlayback feeds recorded input back through input.CommandsReader.
+	ModePlayback
+	// ModeVerify plays back like ModePlayback and also compares actor
+	// position hashes against the recorded ones, stopping at the first
+	// mismatch.
+	ModeVerify
+)
+
+// Divergence reports the first frame whose actor hash differs from the
+// recording.
+type Divergence struct {
+	Frame int
+	Want  uint64
+	Got   uint64
+}
+
+// Controller records or replays PlayerCommands one frame at a time. Input is
+// sampled once in BeginFrame and served to every CommandsReader call in that
+// frame, so skills that read input several times per tick agree with each
+// other both live and on playback.
+type Controller struct {
+	mode      Mode
+	rec       *Recording
+	frame     int
+	current   input.PlayerCommands
+	source    func() input.PlayerCommands
+	installed bool
+
+	divergence *Divergence
+	// OnDivergence, when set, is called once when ModeVerify detects the first
+	// divergent frame.
+	OnDivergence func(Divergence)
+}
+
+// NewRecorder creates a controller that records a run starting at phaseID.
+// When hash is true, actor position hashes are recorded too so the file can
+// later be replayed in ModeVerify.
+func NewRecorder(phaseID int, seed int64, hash bool) *Controller {
+	rec := &Recording{PhaseID: phaseID, Seed: seed}
+	if hash {
+		rec.Hashes = []uint64{}
+	}
+	return &Controller{mode: ModeRecord, rec: rec}
+}
+
+// NewPlayer creates a controller that replays rec. ModeVerify requires a
+// recording with hashes; without them it degrades to plain playback.
+func NewPlayer(rec *Recording, mode Mode) *Controller {
+	if mode == ModeVerify && rec.Hashes == nil {
+		mode = ModePlayback
+	}
+	return &Controller{mode: mode, rec: rec}
+}
+
+// Install seeds the gameplay RNG with the recording's seed and routes
+// input.CommandsReader through the controller.
+func (c *Controller) Install() {
+	if c.installed {
+		return
+	}
+	rng.Seed(c.rec.Seed)
+	c.source = input.CommandsReader
+	input.CommandsReader = c.read
+	c.installed = true
+}
+
+// Uninstall restores the CommandsReader that was active before Install.
+func (c *Controller) Uninstall() {
+	if !c.installed {
+		return
+	}
+	input.CommandsReader = c.source
+	c.installed = false
+}
+
+func (c *Controller) read() input.PlayerCommands {
+	return c.current
+}
+
+// BeginFrame samples (record) or loads (playback) this frame's commands. Call
+// it once per tick before any actor reads input.
+func (c *Controller) BeginFrame() {
+	switch c.mode {
+	case ModeRecord:
+		c.current = c.source()
+		c.rec.Frames = append(c.rec.Frames, c.current)
+	default:
+		if c.frame < len(c.rec.Frames) {
+			c.current = c.rec.Frames[c.frame]
+		} else {
+			c.current = input.PlayerCommands{}
+		}
+	}
+}
+
+// EndFrame hashes actor state (record with hashes, verify) and advances the
+// frame counter. Call it once per tick after the simulation step.
+func (c *Controller) EndFrame(am *actors.Manager) {
+	switch {
+	case c.mode == ModeRecord && c.rec.Hashes != nil:
+		c.rec.Hashes = append(c.rec.Hashes, HashActors(am))
+	case c.mode == ModeVerify && c.divergence == nil && c.frame < len(c.rec.Hashes):
+		if got, want := HashActors(am), c.rec.Hashes[c.frame]; got != want {
+			c.divergence = &Divergence{Frame: c.frame, Want: want, Got: got}
+			if c.OnDivergence != nil {
+				c.OnDivergence(*c.divergence)
+			}
+		}
+	}
+	c.frame++
+}
+
+// Mode returns the controller's mode.
+func (c *Controller) Mode() Mode {
+	return c.mode
+}
+
+// Frame returns the number of completed frames.
+func (c *Controller) Frame() int {
+	return c.frame
+}
+
+// Finished reports whether playback has consumed every recorded frame. It is
+// always false while recording.
+func (c *Controller) Finished() bool {
+	return c.mode != ModeRecord && c.frame >= len(c.rec.Frames)
+}
+
+// Recording returns the recording being written or replayed.
+func (c *Controller) Recording() *Recording {
+	return c.rec
+}
+
+// Divergence returns the first divergent frame found in ModeVerify.
+func (c *Controller) Divergence() (Divergence, bool) {
+	if c.divergence == nil {
+		return Divergence{}, false
+	}
+	return *c.divergence, true
+}
+
+// HashActors returns an FNV-1a hash of every actor's ID, FP16 position and
+// velocity, visited in ID order so map iteration order does not matter.
+func HashActors(am *actors.Manager) uint64 {
+	h := fnv.New64a()
+	if am == nil {
+		return h.Sum64()
+	}
+	var list []actors.ActorEntity
+	am.ForEach(func(a actors.ActorEntity) { list = append(list, a) })
+	sort.Slice(list, func(i, j int) bool { return list[i].ID() < list[j].ID() })
+
+	var buf [8]byte
+	writeInt := func(v int) {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		h.Write(buf[:])
+	}
+	for _, a := range list {
+		h.Write([]byte(a.ID()))
+		x16, y16 := a.GetPosition16()
+		vx16, vy16 := a.Velocity()
+		writeInt(x16)
+		writeInt(y16)
+		writeInt(vx16)
+		writeInt(vy16)
+	}
+	return h.Sum64()
+}
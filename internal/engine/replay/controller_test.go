@@ -0,0 +1,109 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	"github.com/boilerplate/ebiten-template/internal/engine/input"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/rng"
+)
+
+func withCommandsReader(t *testing.T, f func() input.PlayerCommands) {
+	t.Helper()
+	orig := input.CommandsReader
+	input.CommandsReader = f
+	t.Cleanup(func() { input.CommandsReader = orig })
+}
+
+func TestController_RecordThenPlayback(t *testing.T) {
+	live := []input.PlayerCommands{{Right: true}, {Right: true, Jump: true}, {}}
+	i := 0
+	withCommandsReader(t, func() input.PlayerCommands { return live[i] })
+
+	am := actors.NewManager()
+	rec := NewRecorder(2, 99, true)
+	rec.Install()
+	for i = range live {
+		rec.BeginFrame()
+		// Skills may read input several times in one tick.
+		if a, b := input.CommandsReader(), input.CommandsReader(); a != live[i] || b != live[i] {
+			t.Fatalf("frame %d: recorder served %+v/%+v, want %+v", i, a, b, live[i])
+		}
+		rec.EndFrame(am)
+	}
+	rec.Uninstall()
+
+	r := rec.Recording()
+	if r.PhaseID != 2 || r.Seed != 99 || len(r.Frames) != 3 || len(r.Hashes) != 3 {
+		t.Fatalf("unexpected recording %+v", r)
+	}
+
+	withCommandsReader(t, func() input.PlayerCommands {
+		t.Fatal("playback must not read live input")
+		return input.PlayerCommands{}
+	})
+	rng.Seed(1)
+	p := NewPlayer(r, ModeVerify)
+	p.Install()
+	if rng.CurrentSeed() != 99 {
+		t.Errorf("Install should seed rng with 99, got %d", rng.CurrentSeed())
+	}
+	for f := 0; !p.Finished(); f++ {
+		p.BeginFrame()
+		if got := input.CommandsReader(); got != live[f] {
+			t.Errorf("frame %d: got %+v, want %+v", f, got, live[f])
+		}
+		p.EndFrame(am)
+	}
+	p.Uninstall()
+	if _, diverged := p.Divergence(); diverged {
+		t.Error("unexpected divergence")
+	}
+	if p.Frame() != 3 {
+		t.Errorf("Frame() = %d, want 3", p.Frame())
+	}
+}
+
+func TestController_ReportsFirstDivergence(t *testing.T) {
+	am := actors.NewManager()
+	h := HashActors(am)
+	r := &Recording{
+		Frames: make([]input.PlayerCommands, 4),
+		Hashes: []uint64{h, h, h + 1, h + 2},
+	}
+
+	var reported []Divergence
+	p := NewPlayer(r, ModeVerify)
+	p.OnDivergence = func(d Divergence) { reported = append(reported, d) }
+	for !p.Finished() {
+		p.BeginFrame()
+		p.EndFrame(am)
+	}
+
+	d, ok := p.Divergence()
+	if !ok || d.Frame != 2 || d.Want != h+1 || d.Got != h {
+		t.Errorf("Divergence() = %+v, %v; want frame 2", d, ok)
+	}
+	if len(reported) != 1 {
+		t.Errorf("OnDivergence called %d times, want 1", len(reported))
+	}
+}
+
+func TestNewPlayer_VerifyWithoutHashesFallsBackToPlayback(t *testing.T) {
+	p := NewPlayer(&Recording{Frames: make([]input.PlayerCommands, 1)}, ModeVerify)
+	if p.Mode() != ModePlayback {
+		t.Errorf("Mode() = %v, want ModePlayback", p.Mode())
+	}
+}
+
+func TestController_PlaybackPastEndIsIdle(t *testing.T) {
+	p := NewPlayer(&Recording{Frames: []input.PlayerCommands{{Jump: true}}}, ModePlayback)
+	p.Install()
+	defer p.Uninstall()
+	p.BeginFrame()
+	p.EndFrame(nil)
+	p.BeginFrame()
+	if got := input.CommandsReader(); got != (input.PlayerCommands{}) {
+		t.Errorf("expected idle commands after the recording ends, got %+v", got)
+	}
+}
@@ -0,0 +1,223 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/input"
+)
+
+// fileMagic prefixes every recording file.
+const fileMagic = "FFRP"
+
+// formatVersion is bumped whenever the binary layout changes.
+const formatVersion = 1
+
+// maxFrames caps the frames a recording may decode to, about four hours at
+// 60 ticks per second, so a corrupt run length cannot exhaust memory.
+const maxFrames = 4 * 60 * 60 * 60
+
+// Recording is one captured run: the phase it started in, the gameplay RNG
+// seed, the sampled PlayerCommands of every frame and, optionally, a hash of
+// actor positions after every frame for divergence checks.
+type Recording struct {
+	PhaseID int
+	Seed    int64
+	Frames  []input.PlayerCommands
+	Hashes  []uint64
+}
+
+// WriteTo encodes r in the compact binary format:
+//
+//	"FFRP" | version | varint phase | varint seed |
+//	uvarint runs, then (uvarint length, uvarint command mask) per run |
+//	uvarint hash count, then uint64 LE per hash
+//
+// Consecutive identical frames collapse into one run, so held inputs and idle
+// stretches cost a few bytes regardless of length.
+func (r *Recording) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw}
+	cw.write([]byte(fileMagic))
+	cw.write([]byte{formatVersion})
+	cw.varint(int64(r.PhaseID))
+	cw.varint(r.Seed)
+
+	type run struct {
+		length uint64
+		mask   uint64
+	}
+	var runs []run
+	for _, f := range r.Frames {
+		mask := uint64(packCommands(f))
+		if n := len(runs); n > 0 && runs[n-1].mask == mask {
+			runs[n-1].length++
+			continue
+		}
+		runs = append(runs, run{length: 1, mask: mask})
+	}
+	cw.uvarint(uint64(len(runs)))
+	for _, rn := range runs {
+		cw.uvarint(rn.length)
+		cw.uvarint(rn.mask)
+	}
+
+	cw.uvarint(uint64(len(r.Hashes)))
+	var buf [8]byte
+	for _, h := range r.Hashes {
+		binary.LittleEndian.PutUint64(buf[:], h)
+		cw.write(buf[:])
+	}
+	if cw.err == nil {
+		cw.err = bw.Flush()
+	}
+	return cw.n, cw.err
+}
+
+// ReadRecording decodes a recording written by WriteTo.
+func ReadRecording(r io.Reader) (*Recording, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(fileMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if string(header[:len(fileMagic)]) != fileMagic {
+		return nil, errors.New("not a replay file")
+	}
+	if v := header[len(fileMagic)]; v != formatVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", v)
+	}
+
+	phase, err := binary.ReadVarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("read phase: %w", err)
+	}
+	seed, err := binary.ReadVarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("read seed: %w", err)
+	}
+	rec := &Recording{PhaseID: int(phase), Seed: seed}
+
+	runs, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("read run count: %w", err)
+	}
+	for i := uint64(0); i < runs; i++ {
+		length, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("read run %d: %w", i, err)
+		}
+		mask, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("read run %d: %w", i, err)
+		}
+		if length == 0 || length > uint64(maxFrames-len(rec.Frames)) {
+			return nil, fmt.Errorf("run %d: invalid length %d", i, length)
+		}
+		cmds := unpackCommands(uint16(mask))
+		for j := uint64(0); j < length; j++ {
+			rec.Frames = append(rec.Frames, cmds)
+		}
+	}
+
+	hashes, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("read hash count: %w", err)
+	}
+	if hashes > uint64(len(rec.Frames)) {
+		return nil, fmt.Errorf("hash count %d exceeds %d frames", hashes, len(rec.Frames))
+	}
+	if hashes > 0 {
+		rec.Hashes = make([]uint64, hashes)
+		var buf [8]byte
+		for i := range rec.Hashes {
+			if _, err := io.ReadFull(br, buf[:]); err != nil {
+				return nil, fmt.Errorf("read hash %d: %w", i, err)
+			}
+			rec.Hashes[i] = binary.LittleEndian.Uint64(buf[:])
+		}
+	}
+	return rec, nil
+}
+
+// packCommands encodes PlayerCommands as a bitmask in input.PlayerActions
+// order.
+func packCommands(c input.PlayerCommands) uint16 {
+	bits := [...]bool{
+		c.Up, c.Down, c.Left, c.Right,
+		c.Shoot, c.Melee, c.Jump, c.Dash,
+		c.Confirm, c.Cancel, c.WeaponNext, c.WeaponPrev,
+		c.Guard,
+	}
+	var mask uint16
+	for i, b := range bits {
+		if b {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+func unpackCommands(mask uint16) input.PlayerCommands {
+	bit := func(i int) bool { return mask&(1<<i) != 0 }
+	return input.PlayerCommands{
+		Up: bit(0), Down: bit(1), Left: bit(2), Right: bit(3),
+		Shoot: bit(4), Melee: bit(5), Jump: bit(6), Dash: bit(7),
+		Confirm: bit(8), Cancel: bit(9), WeaponNext: bit(10), WeaponPrev: bit(11),
+		Guard: bit(12),
+	}
+}
+
+// countingWriter tracks bytes written and the first error, so WriteTo can
+// encode without checking every call.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) write(p []byte) {
+	if c.err != nil {
+		return
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+}
+
+func (c *countingWriter) varint(v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	c.write(buf[:binary.PutVarint(buf[:], v)])
+}
+
+func (c *countingWriter) uvarint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	c.write(buf[:binary.PutUvarint(buf[:], v)])
+}
+
+// WriteFile writes rec to path, replacing any existing file.
+func WriteFile(path string, rec *Recording) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := rec.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadFile reads a recording from path.
+func ReadFile(path string) (*Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadRecording(f)
+}
@@ -0,0 +1,132 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/input"
+)
+
+func TestRecording_RoundTrip(t *testing.T) {
+	rec := &Recording{
+		PhaseID: 3,
+		Seed:    -42,
+		Frames: []input.PlayerCommands{
+			{},
+			{Right: true},
+			{Right: true, Jump: true},
+			{Left: true, Shoot: true, WeaponPrev: true},
+			{Confirm: true, Cancel: true, Dash: true, Melee: true},
+		},
+		Hashes: []uint64{1, 2, 3, 4, 0xFFFFFFFFFFFFFFFF},
+	}
+
+	var buf bytes.Buffer
+	n, err := rec.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+
+	got, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecording: %v", err)
+	}
+	if !reflect.DeepEqual(got, rec) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, rec)
+	}
+}
+
+func TestRecording_WithoutHashes(t *testing.T) {
+	rec := &Recording{PhaseID: 1, Frames: []input.PlayerCommands{{Up: true}}}
+	var buf bytes.Buffer
+	if _, err := rec.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	got, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecording: %v", err)
+	}
+	if got.Hashes != nil {
+		t.Errorf("expected nil hashes, got %v", got.Hashes)
+	}
+}
+
+func TestRecording_HeldInputIsCompact(t *testing.T) {
+	rec := &Recording{PhaseID: 1}
+	for i := 0; i < 10000; i++ {
+		rec.Frames = append(rec.Frames, input.PlayerCommands{Right: true})
+	}
+	var buf bytes.Buffer
+	if _, err := rec.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if buf.Len() > 32 {
+		t.Errorf("expected held input to collapse into one run, got %d bytes", buf.Len())
+	}
+}
+
+func TestReadRecording_RejectsBadInput(t *testing.T) {
+	if _, err := ReadRecording(bytes.NewReader([]byte("NOPE\x01"))); err == nil {
+		t.Error("expected error for bad magic")
+	}
+	if _, err := ReadRecording(bytes.NewReader([]byte("FFRP\x09"))); err == nil {
+		t.Error("expected error for unknown version")
+	}
+	if _, err := ReadRecording(bytes.NewReader([]byte("FFRP\x01"))); err == nil {
+		t.Error("expected error for truncated file")
+	}
+}
+
+func TestReadRecording_RejectsCorruptCounts(t *testing.T) {
+	// file builds a recording with phase and seed 0 followed by uvarints.
+	file := func(vals ...uint64) []byte {
+		data := []byte("FFRP\x01\x00\x00")
+		for _, v := range vals {
+			data = binary.AppendUvarint(data, v)
+		}
+		return data
+	}
+	cases := map[string][]byte{
+		"huge run count":     file(1 << 62),
+		"huge run length":    file(1, 1<<62, 0),
+		"empty run":          file(1, 0, 0),
+		"hashes past frames": file(1, 1, 0, 1<<62),
+	}
+	for name, data := range cases {
+		if _, err := ReadRecording(bytes.NewReader(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func FuzzReadRecording(f *testing.F) {
+	rec := &Recording{Frames: []input.PlayerCommands{{Right: true}, {Jump: true}}, Hashes: []uint64{1, 2}}
+	var buf bytes.Buffer
+	if _, err := rec.WriteTo(&buf); err != nil {
+		f.Fatalf("WriteTo: %v", err)
+	}
+	f.Add(buf.Bytes())
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = ReadRecording(bytes.NewReader(data))
+	})
+}
+
+func TestPackCommands_CoversEveryAction(t *testing.T) {
+	all := input.PlayerCommands{
+		Up: true, Down: true, Left: true, Right: true,
+		Shoot: true, Melee: true, Jump: true, Dash: true,
+		Confirm: true, Cancel: true, WeaponNext: true, WeaponPrev: true,
+		Guard: true,
+	}
+	if got := unpackCommands(packCommands(all)); got != all {
+		t.Errorf("unpack(pack(all)) = %+v", got)
+	}
+	if mask := packCommands(all); mask != 1<<len(input.PlayerActions)-1 {
+		t.Errorf("mask = %b, want one bit per player action", mask)
+	}
+}
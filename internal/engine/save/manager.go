@@ -0,0 +1,116 @@
+package save
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Migration upgrades a decoded save document from one schema version to the
+// next. It mutates doc in place; the "version" field is bumped by the Manager.
+type Migration func(doc map[string]any) error
+
+// Manager reads and writes Snapshots to numbered slots on a Storage backend,
+// upgrading older files through the registered migrations on load.
+type Manager struct {
+	storage    Storage
+	migrations map[int]Migration
+	version    int
+	now        func() time.Time
+}
+
+// NewManager creates a save manager on top of storage.
+func NewManager(storage Storage) *Manager {
+	return &Manager{
+		storage:    storage,
+		migrations: make(map[int]Migration),
+		version:    CurrentVersion,
+		now:        time.Now,
+	}
+}
+
+// RegisterMigration registers the migration that upgrades documents at
+// version from to version from+1.
+func (m *Manager) RegisterMigration(from int, fn Migration) {
+	m.migrations[from] = fn
+}
+
+// Save stamps snap with CurrentVersion and the current time and writes it to
+// slot.
+func (m *Manager) Save(slot int, snap *Snapshot) error {
+	snap.Version = m.version
+	snap.SavedAt = m.now()
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode slot %d: %w", slot, err)
+	}
+	if err := m.storage.Write(slot, data); err != nil {
+		return fmt.Errorf("write slot %d: %w", slot, err)
+	}
+	return nil
+}
+
+// Load reads slot, migrates it to CurrentVersion if needed and decodes it.
+// It returns ErrSlotNotFound (wrapped) when the slot is empty.
+func (m *Manager) Load(slot int) (*Snapshot, error) {
+	data, err := m.storage.Read(slot)
+	if err != nil {
+		return nil, fmt.Errorf("read slot %d: %w", slot, err)
+	}
+	data, err = m.migrate(data)
+	if err != nil {
+		return nil, fmt.Errorf("migrate slot %d: %w", slot, err)
+	}
+	snap := NewSnapshot()
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, fmt.Errorf("decode slot %d: %w", slot, err)
+	}
+	return snap, nil
+}
+
+// Delete removes slot. Deleting an empty slot is not an error.
+func (m *Manager) Delete(slot int) error {
+	return m.storage.Delete(slot)
+}
+
+// Exists reports whether slot holds a save.
+func (m *Manager) Exists(slot int) bool {
+	_, err := m.storage.Read(slot)
+	return err == nil
+}
+
+// Slots returns the occupied slot numbers in ascending order.
+func (m *Manager) Slots() ([]int, error) {
+	return m.storage.Slots()
+}
+
+// migrate runs the migration chain from the document's version up to
+// CurrentVersion. Documents from a newer build are rejected rather than
+// silently losing fields.
+func (m *Manager) migrate(data []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	version := 0
+	if v, ok := doc["version"].(float64); ok {
+		version = int(v)
+	}
+	if version > m.version {
+		return nil, fmt.Errorf("save version %d is newer than supported version %d", version, m.version)
+	}
+	if version == m.version {
+		return data, nil
+	}
+	for ; version < m.version; version++ {
+		fn, ok := m.migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from version %d", version)
+		}
+		if err := fn(doc); err != nil {
+			return nil, fmt.Errorf("version %d: %w", version, err)
+		}
+		doc["version"] = version + 1
+	}
+	return json.Marshal(doc)
+}
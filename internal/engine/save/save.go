@@ -0,0 +1,197 @@
+package save
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
+)
+
+// CurrentVersion is the schema version written by this build. Bump it together
+// with a Migration registered for the previous version whenever the Snapshot
+// JSON layout changes in a way older files cannot be decoded into.
+const CurrentVersion = 1
+
+// Snapshot is the persisted state of one save slot. It only holds plain data;
+// the Capture*/Apply* helpers copy state in and out of live engine systems.
+type Snapshot struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"saved_at"`
+
+	Phase             PhaseState     `json:"phase"`
+	ConsumedSequences []string       `json:"consumed_sequences,omitempty"`
+	SequenceFlags     []string       `json:"sequence_flags,omitempty"`
+	VisitedRooms      []string       `json:"visited_rooms,omitempty"`
+	Player            PlayerState    `json:"player"`
+	Inventory         InventoryState `json:"inventory"`
+
+	// Data holds game-defined key/value entries. Values are raw JSON so the
+	// engine never needs to know the game's types; use SetData/GetData.
+	Data map[string]json.RawMessage `json:"data,omitempty"`
+}
+
+// PhaseState records phase progress.
+type PhaseState struct {
+	CurrentPhase    int   `json:"current_phase"`
+	CompletedPhases []int `json:"completed_phases,omitempty"`
+}
+
+// PlayerState records the player's persistent stats.
+type PlayerState struct {
+	Health    int `json:"health"`
+	MaxHealth int `json:"max_health"`
+}
+
+// InventoryState records owned weapons, their ammo (-1 = unlimited) and the
+// active weapon index.
+type InventoryState struct {
+	Weapons     []string       `json:"weapons,omitempty"`
+	Ammo        map[string]int `json:"ammo,omitempty"`
+	ActiveIndex int            `json:"active_index"`
+}
+
+// HealthHolder is the subset of body.Alive needed to persist player health.
+type HealthHolder interface {
+	Health() int
+	MaxHealth() int
+	SetHealth(health int)
+	SetMaxHealth(health int)
+}
+
+// ConsumedSequencesTracker is implemented by sequence players that remember
+// which one-time sequences have already run.
+type ConsumedSequencesTracker interface {
+	ConsumedOneTimeSequences() []string
+	RestoreConsumedOneTimeSequences(paths []string)
+}
+
+// SequenceFlagsTracker is implemented by sequence players that keep
+// persistent flags set by scripted sequences.
+type SequenceFlagsTracker interface {
+	Flags() []string
+	RestoreFlags(names []string)
+}
+
+// VisitedRoomsTracker is implemented by minimaps that remember which rooms
+// the player has been in.
+type VisitedRoomsTracker interface {
+	VisitedRooms() []string
+	RestoreVisitedRooms(keys []string)
+}
+
+// NewSnapshot returns an empty snapshot stamped with CurrentVersion.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		Version: CurrentVersion,
+		Data:    make(map[string]json.RawMessage),
+	}
+}
+
+// SetData stores v under key as JSON.
+func (s *Snapshot) SetData(key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if s.Data == nil {
+		s.Data = make(map[string]json.RawMessage)
+	}
+	s.Data[key] = raw
+	return nil
+}
+
+// GetData decodes the value stored under key into v. It reports false when
+// the key is absent.
+func (s *Snapshot) GetData(key string, v any) (bool, error) {
+	raw, ok := s.Data[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// CapturePhase records the manager's current and completed phases.
+func (s *Snapshot) CapturePhase(m *phases.Manager) {
+	if m == nil {
+		return
+	}
+	s.Phase.CurrentPhase = m.CurrentPhase
+	s.Phase.CompletedPhases = m.CompletedPhases()
+}
+
+// ApplyPhase restores the current and completed phases on m.
+func (s *Snapshot) ApplyPhase(m *phases.Manager) error {
+	if m == nil {
+		return nil
+	}
+	m.RestoreCompletedPhases(s.Phase.CompletedPhases)
+	return m.SetCurrentPhase(s.Phase.CurrentPhase)
+}
+
+// CaptureSequences records the one-time sequences already consumed.
+func (s *Snapshot) CaptureSequences(t ConsumedSequencesTracker) {
+	if t == nil {
+		return
+	}
+	s.ConsumedSequences = t.ConsumedOneTimeSequences()
+}
+
+// ApplySequences marks the saved one-time sequences as consumed on t.
+func (s *Snapshot) ApplySequences(t ConsumedSequencesTracker) {
+	if t == nil {
+		return
+	}
+	t.RestoreConsumedOneTimeSequences(s.ConsumedSequences)
+}
+
+// CaptureSequenceFlags records the sequence flags currently set.
+func (s *Snapshot) CaptureSequenceFlags(t SequenceFlagsTracker) {
+	if t == nil {
+		return
+	}
+	s.SequenceFlags = t.Flags()
+}
+
+// ApplySequenceFlags sets the saved sequence flags on t.
+func (s *Snapshot) ApplySequenceFlags(t SequenceFlagsTracker) {
+	if t == nil {
+		return
+	}
+	t.RestoreFlags(s.SequenceFlags)
+}
+
+// CaptureVisitedRooms records the rooms the player has visited.
+func (s *Snapshot) CaptureVisitedRooms(t VisitedRoomsTracker) {
+	if t == nil {
+		return
+	}
+	s.VisitedRooms = t.VisitedRooms()
+}
+
+// ApplyVisitedRooms marks the saved rooms as visited on t.
+func (s *Snapshot) ApplyVisitedRooms(t VisitedRoomsTracker) {
+	if t == nil {
+		return
+	}
+	t.RestoreVisitedRooms(s.VisitedRooms)
+}
+
+// CapturePlayer records the player's health.
+func (s *Snapshot) CapturePlayer(h HealthHolder) {
+	if h == nil {
+		return
+	}
+	s.Player = PlayerState{Health: h.Health(), MaxHealth: h.MaxHealth()}
+}
+
+// ApplyPlayer restores the player's health. SetMaxHealth also refills current
+// health, so it runs first and SetHealth then applies the saved value.
+func (s *Snapshot) ApplyPlayer(h HealthHolder) {
+	if h == nil {
+		return
+	}
+	if s.Player.MaxHealth > 0 {
+		h.SetMaxHealth(s.Player.MaxHealth)
+	}
+	h.SetHealth(s.Player.Health)
+}
@@ -0,0 +1,227 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
+)
+
+type stubHealth struct {
+	health, maxHealth int
+}
+
+func (s *stubHealth) Health() int    { return s.health }
+func (s *stubHealth) MaxHealth() int { return s.maxHealth }
+func (s *stubHealth) SetHealth(h int) {
+	s.health = h
+}
+func (s *stubHealth) SetMaxHealth(h int) {
+	s.health = h
+	s.maxHealth = h
+}
+
+type stubTracker struct {
+	consumed []string
+	flags    []string
+	rooms    []string
+}
+
+func (s *stubTracker) ConsumedOneTimeSequences() []string { return s.consumed }
+func (s *stubTracker) RestoreConsumedOneTimeSequences(paths []string) {
+	s.consumed = append(s.consumed, paths...)
+}
+func (s *stubTracker) Flags() []string { return s.flags }
+func (s *stubTracker) RestoreFlags(names []string) {
+	s.flags = append(s.flags, names...)
+}
+func (s *stubTracker) VisitedRooms() []string { return s.rooms }
+func (s *stubTracker) RestoreVisitedRooms(keys []string) {
+	s.rooms = append(s.rooms, keys...)
+}
+
+func TestManagerSaveLoadRoundTrip(t *testing.T) {
+	m := NewManager(NewMemoryStorage())
+	fixed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m.now = func() time.Time { return fixed }
+
+	pm := phases.NewManager()
+	pm.AddPhase(phases.Phase{ID: 1})
+	pm.AddPhase(phases.Phase{ID: 2})
+	pm.RestoreCompletedPhases([]int{1})
+	_ = pm.SetCurrentPhase(2)
+
+	snap := NewSnapshot()
+	snap.CapturePhase(pm)
+	snap.CaptureSequences(&stubTracker{consumed: []string{"intro.json"}})
+	snap.CaptureSequenceFlags(&stubTracker{flags: []string{"met_guard"}})
+	snap.CaptureVisitedRooms(&stubTracker{rooms: []string{"castle.tmj#hall"}})
+	snap.CapturePlayer(&stubHealth{health: 3, maxHealth: 5})
+	snap.Inventory = InventoryState{Weapons: []string{"gun"}, Ammo: map[string]int{"gun": 7}}
+	if err := snap.SetData("coins", 42); err != nil {
+		t.Fatalf("SetData: %v", err)
+	}
+
+	if err := m.Save(1, snap); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := m.Load(1)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.Version != CurrentVersion || !got.SavedAt.Equal(fixed) {
+		t.Errorf("header = (%d, %v), want (%d, %v)", got.Version, got.SavedAt, CurrentVersion, fixed)
+	}
+
+	restoredPhases := phases.NewManager()
+	restoredPhases.AddPhase(phases.Phase{ID: 1})
+	restoredPhases.AddPhase(phases.Phase{ID: 2})
+	if err := got.ApplyPhase(restoredPhases); err != nil {
+		t.Fatalf("ApplyPhase: %v", err)
+	}
+	if restoredPhases.CurrentPhase != 2 {
+		t.Errorf("CurrentPhase = %d, want 2", restoredPhases.CurrentPhase)
+	}
+	if !restoredPhases.Completed(1) || restoredPhases.Completed(2) {
+		t.Errorf("CompletedPhases() = %v, want [1]", restoredPhases.CompletedPhases())
+	}
+
+	tracker := &stubTracker{}
+	got.ApplySequences(tracker)
+	if len(tracker.consumed) != 1 || tracker.consumed[0] != "intro.json" {
+		t.Errorf("consumed = %v, want [intro.json]", tracker.consumed)
+	}
+	got.ApplySequenceFlags(tracker)
+	if len(tracker.flags) != 1 || tracker.flags[0] != "met_guard" {
+		t.Errorf("flags = %v, want [met_guard]", tracker.flags)
+	}
+	got.ApplyVisitedRooms(tracker)
+	if len(tracker.rooms) != 1 || tracker.rooms[0] != "castle.tmj#hall" {
+		t.Errorf("visited rooms = %v, want [castle.tmj#hall]", tracker.rooms)
+	}
+
+	hp := &stubHealth{}
+	got.ApplyPlayer(hp)
+	if hp.health != 3 || hp.maxHealth != 5 {
+		t.Errorf("health = %d/%d, want 3/5", hp.health, hp.maxHealth)
+	}
+
+	if got.Inventory.Ammo["gun"] != 7 {
+		t.Errorf("gun ammo = %d, want 7", got.Inventory.Ammo["gun"])
+	}
+
+	var coins int
+	ok, err := got.GetData("coins", &coins)
+	if err != nil || !ok || coins != 42 {
+		t.Errorf("GetData(coins) = (%v, %v, %d), want (true, nil, 42)", ok, err, coins)
+	}
+	if ok, _ := got.GetData("missing", &coins); ok {
+		t.Error("expected GetData on missing key to report false")
+	}
+}
+
+func TestManagerLoadEmptySlot(t *testing.T) {
+	m := NewManager(NewMemoryStorage())
+	if _, err := m.Load(3); !errors.Is(err, ErrSlotNotFound) {
+		t.Fatalf("Load(empty) err = %v, want ErrSlotNotFound", err)
+	}
+	if m.Exists(3) {
+		t.Error("Exists(empty) = true, want false")
+	}
+}
+
+func TestManagerSlotsAndDelete(t *testing.T) {
+	m := NewManager(NewMemoryStorage())
+	for _, slot := range []int{3, 1, 2} {
+		if err := m.Save(slot, NewSnapshot()); err != nil {
+			t.Fatalf("Save(%d): %v", slot, err)
+		}
+	}
+	if err := m.Delete(2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	slots, err := m.Slots()
+	if err != nil {
+		t.Fatalf("Slots: %v", err)
+	}
+	if len(slots) != 2 || slots[0] != 1 || slots[1] != 3 {
+		t.Errorf("Slots() = %v, want [1 3]", slots)
+	}
+}
+
+func TestManagerMigratesOlderVersions(t *testing.T) {
+	storage := NewMemoryStorage()
+	// A v1 document stored the phase as a bare top-level field.
+	_ = storage.Write(1, []byte(`{"version":1,"phase_id":4}`))
+
+	m := NewManager(storage)
+	m.version = 3
+	var ran []int
+	m.RegisterMigration(1, func(doc map[string]any) error {
+		ran = append(ran, 1)
+		doc["phase"] = map[string]any{"current_phase": doc["phase_id"]}
+		delete(doc, "phase_id")
+		return nil
+	})
+	m.RegisterMigration(2, func(doc map[string]any) error {
+		ran = append(ran, 2)
+		if doc["version"].(int) != 2 {
+			t.Errorf("migration 2 saw version %v, want 2", doc["version"])
+		}
+		return nil
+	})
+
+	snap, err := m.Load(1)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(ran) != 2 || ran[0] != 1 || ran[1] != 2 {
+		t.Errorf("migrations ran = %v, want [1 2]", ran)
+	}
+	if snap.Version != 3 || snap.Phase.CurrentPhase != 4 {
+		t.Errorf("snapshot = (v%d, phase %d), want (v3, phase 4)", snap.Version, snap.Phase.CurrentPhase)
+	}
+}
+
+func TestManagerMigrationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"missing migration", `{"version":1}`},
+		{"newer than supported", `{"version":9}`},
+		{"malformed json", `{"version":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewMemoryStorage()
+			_ = storage.Write(1, []byte(tt.doc))
+			m := NewManager(storage)
+			m.version = 2
+			if _, err := m.Load(1); err == nil {
+				t.Fatal("expected Load to fail")
+			}
+		})
+	}
+}
+
+func TestSnapshotJSONIsStable(t *testing.T) {
+	snap := NewSnapshot()
+	snap.Phase.CurrentPhase = 1
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	for _, key := range []string{"version", "saved_at", "phase", "player", "inventory"} {
+		if _, ok := doc[key]; !ok {
+			t.Errorf("expected key %q in encoded snapshot", key)
+		}
+	}
+}
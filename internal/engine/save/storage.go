@@ -0,0 +1,69 @@
+package save
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrSlotNotFound is returned by Storage.Read when a slot has never been
+// written or was deleted.
+var ErrSlotNotFound = errors.New("save slot not found")
+
+// Storage is the pluggable backend behind Manager. Implementations must make
+// Write atomic: a crash mid-write leaves either the old or the new payload,
+// never a truncated one.
+type Storage interface {
+	Read(slot int) ([]byte, error)
+	Write(slot int, data []byte) error
+	Delete(slot int) error
+	// Slots returns the occupied slot numbers in ascending order.
+	Slots() ([]int, error)
+}
+
+// MemoryStorage keeps slots in memory. Useful for tests and for builds that
+// should not touch the disk.
+type MemoryStorage struct {
+	mu    sync.Mutex
+	slots map[int][]byte
+}
+
+// NewMemoryStorage creates an empty in-memory storage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{slots: make(map[int][]byte)}
+}
+
+func (s *MemoryStorage) Read(slot int) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.slots[slot]
+	if !ok {
+		return nil, ErrSlotNotFound
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (s *MemoryStorage) Write(slot int, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slots[slot] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *MemoryStorage) Delete(slot int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.slots, slot)
+	return nil
+}
+
+func (s *MemoryStorage) Slots() ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	slots := make([]int, 0, len(s.slots))
+	for slot := range s.slots {
+		slots = append(slots, slot)
+	}
+	sort.Ints(slots)
+	return slots, nil
+}
@@ -0,0 +1,115 @@
+//go:build !js
+
+package save
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const slotFilePrefix = "slot_"
+const slotFileExt = ".json"
+
+// FileStorage stores each slot as <dir>/slot_<n>.json.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage creates a file-backed storage rooted at dir. The directory
+// is created lazily on the first write.
+func NewFileStorage(dir string) *FileStorage {
+	return &FileStorage{dir: dir}
+}
+
+// NewDefaultStorage returns the platform storage: files under the user config
+// directory on desktop, localStorage in the browser. name namespaces the saves
+// (directory name / key prefix).
+func NewDefaultStorage(name string) Storage {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		base = "."
+	}
+	return NewFileStorage(filepath.Join(base, name, "saves"))
+}
+
+func (s *FileStorage) path(slot int) string {
+	return filepath.Join(s.dir, slotFilePrefix+strconv.Itoa(slot)+slotFileExt)
+}
+
+func (s *FileStorage) Read(slot int) ([]byte, error) {
+	data, err := os.ReadFile(s.path(slot))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrSlotNotFound
+	}
+	return data, err
+}
+
+// Write writes to a temp file in the same directory, syncs it, then renames it
+// over the slot file so readers never observe a partial write.
+func (s *FileStorage) Write(slot int, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, slotFilePrefix+"*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, s.path(slot)); err != nil {
+		os.Remove(tmpName)
+		return fmt.Errorf("commit slot %d: %w", slot, err)
+	}
+	return nil
+}
+
+func (s *FileStorage) Delete(slot int) error {
+	err := os.Remove(s.path(slot))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStorage) Slots() ([]int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var slots []int
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, slotFilePrefix) || !strings.HasSuffix(name, slotFileExt) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, slotFilePrefix), slotFileExt))
+		if err != nil {
+			continue
+		}
+		slots = append(slots, n)
+	}
+	sort.Ints(slots)
+	return slots, nil
+}
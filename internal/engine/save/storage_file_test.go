@@ -0,0 +1,62 @@
+//go:build !js
+
+package save
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStorageReadWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "saves")
+	s := NewFileStorage(dir)
+
+	if _, err := s.Read(1); !errors.Is(err, ErrSlotNotFound) {
+		t.Fatalf("Read(empty) err = %v, want ErrSlotNotFound", err)
+	}
+	if slots, err := s.Slots(); err != nil || len(slots) != 0 {
+		t.Fatalf("Slots() before write = (%v, %v), want empty", slots, err)
+	}
+
+	if err := s.Write(1, []byte("first")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := s.Write(1, []byte("second")); err != nil {
+		t.Fatalf("Write overwrite: %v", err)
+	}
+	data, err := s.Read(1)
+	if err != nil || string(data) != "second" {
+		t.Fatalf("Read = (%q, %v), want (second, nil)", data, err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only the slot file after atomic writes, got %d entries", len(entries))
+	}
+}
+
+func TestFileStorageSlotsIgnoresForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFileStorage(dir)
+	_ = s.Write(2, []byte("{}"))
+	_ = s.Write(10, []byte("{}"))
+	_ = os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "slot_x.json"), nil, 0o644)
+
+	slots, err := s.Slots()
+	if err != nil {
+		t.Fatalf("Slots: %v", err)
+	}
+	if len(slots) != 2 || slots[0] != 2 || slots[1] != 10 {
+		t.Errorf("Slots() = %v, want [2 10]", slots)
+	}
+
+	if err := s.Delete(2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(2); err != nil {
+		t.Fatalf("Delete(missing): %v", err)
+	}
+}
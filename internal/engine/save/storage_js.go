@@ -0,0 +1,74 @@
+//go:build js
+
+package save
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"syscall/js"
+)
+
+// LocalStorage stores each slot under the browser localStorage key
+// "<prefix>/slot_<n>". A single setItem call is atomic, which satisfies the
+// Storage contract.
+type LocalStorage struct {
+	prefix string
+}
+
+// NewLocalStorage creates a localStorage-backed storage namespaced by prefix.
+func NewLocalStorage(prefix string) *LocalStorage {
+	return &LocalStorage{prefix: prefix + "/slot_"}
+}
+
+// NewDefaultStorage returns the platform storage: files under the user config
+// directory on desktop, localStorage in the browser. name namespaces the saves
+// (directory name / key prefix).
+func NewDefaultStorage(name string) Storage {
+	return NewLocalStorage(name)
+}
+
+func localStorage() js.Value {
+	return js.Global().Get("localStorage")
+}
+
+func (s *LocalStorage) key(slot int) string {
+	return s.prefix + strconv.Itoa(slot)
+}
+
+func (s *LocalStorage) Read(slot int) ([]byte, error) {
+	v := localStorage().Call("getItem", s.key(slot))
+	if v.IsNull() || v.IsUndefined() {
+		return nil, ErrSlotNotFound
+	}
+	return []byte(v.String()), nil
+}
+
+func (s *LocalStorage) Write(slot int, data []byte) error {
+	localStorage().Call("setItem", s.key(slot), string(data))
+	return nil
+}
+
+func (s *LocalStorage) Delete(slot int) error {
+	localStorage().Call("removeItem", s.key(slot))
+	return nil
+}
+
+func (s *LocalStorage) Slots() ([]int, error) {
+	ls := localStorage()
+	n := ls.Get("length").Int()
+	var slots []int
+	for i := 0; i < n; i++ {
+		k := ls.Call("key", i).String()
+		if !strings.HasPrefix(k, s.prefix) {
+			continue
+		}
+		slot, err := strconv.Atoi(strings.TrimPrefix(k, s.prefix))
+		if err != nil {
+			continue
+		}
+		slots = append(slots, slot)
+	}
+	sort.Ints(slots)
+	return slots, nil
+}
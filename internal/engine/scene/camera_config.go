@@ -1,12 +1,21 @@
 package scene
 
+import "github.com/boilerplate/ebiten-template/internal/engine/render/camera"
+
 type CameraMode string
 
 const (
 	CameraModeFixed  CameraMode = "fixed"
 	CameraModeFollow CameraMode = "follow"
+	// CameraModeMultiTarget follows the bodies passed to the camera's
+	// SetTargets, framing them all; with none it follows the follow target.
+	CameraModeMultiTarget CameraMode = "multi_target"
 )
 
 type CameraConfig struct {
 	Mode CameraMode
+	// LookAhead leads the view ahead of the follow target; zero is off.
+	LookAhead camera.LookAhead
+	// MultiTarget configures framing in CameraModeMultiTarget.
+	MultiTarget camera.MultiTarget
 }
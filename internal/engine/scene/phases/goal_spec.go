@@ -0,0 +1,141 @@
+package phases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/sequences"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
+)
+
+// GoalSpec describes a phase goal in phase data. "all" and "any" combine
+// the Goals listed under them; "timed" requires them all within Seconds.
+type GoalSpec struct {
+	Type GoalType `json:"type"`
+	// Tag limits kill_all to the enemies carrying it.
+	Tag string `json:"tag,omitempty"`
+	// ItemType and Count configure collect.
+	ItemType string `json:"item_type,omitempty"`
+	Count    int    `json:"count,omitempty"`
+	// Seconds is how long survive lasts and how long timed allows.
+	Seconds float64    `json:"seconds,omitempty"`
+	Goals   []GoalSpec `json:"goals,omitempty"`
+}
+
+// GoalSpec returns the phase's goal: Goal when set, or else one of type
+// GoalType.
+func (p Phase) GoalSpec() GoalSpec {
+	if p.Goal != nil {
+		return *p.Goal
+	}
+	return GoalSpec{Type: p.GoalType}
+}
+
+// Validate checks the spec and the specs under it.
+func (s GoalSpec) Validate() error {
+	var errs []error
+	switch s.Type {
+	case ReactEndpointType, SequenceGoalType, NoGoalType, KillAllGoalType, CollectGoalType, "":
+	case SurviveGoalType:
+		if s.Seconds <= 0 {
+			errs = append(errs, errors.New("survive goal needs positive seconds"))
+		}
+	case TimedGoalType:
+		if s.Seconds <= 0 {
+			errs = append(errs, errors.New("timed goal needs positive seconds"))
+		}
+		fallthrough
+	case AllGoalType, AnyGoalType:
+		if len(s.Goals) == 0 {
+			errs = append(errs, fmt.Errorf("%s goal needs goals", s.Type))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown goal type %q", s.Type))
+	}
+	for _, child := range s.Goals {
+		errs = append(errs, child.Validate())
+	}
+	return errors.Join(errs...)
+}
+
+// GoalContext is what BuildGoal needs from the phase scene.
+type GoalContext struct {
+	// Sequences is the player sequence goals wait on.
+	Sequences sequences.Player
+	// Enemies returns the IDs of the phase's enemies carrying tag, or of all
+	// of them when tag is empty.
+	Enemies func(tag string) []string
+	// OnComplete is called by the OnCompletion of the root goal.
+	OnComplete func()
+}
+
+// BuildGoal creates the goal described by spec. Unknown types never
+// complete.
+func BuildGoal(spec GoalSpec, ctx GoalContext) Goal {
+	g := buildGoal(spec, ctx)
+	if ctx.OnComplete != nil {
+		setOnComplete(g, ctx.OnComplete)
+	}
+	return g
+}
+
+func buildGoal(spec GoalSpec, ctx GoalContext) Goal {
+	switch spec.Type {
+	case ReactEndpointType:
+		return &ReachEndpointGoal{}
+	case SequenceGoalType:
+		return &SequenceGoal{Player: ctx.Sequences}
+	case KillAllGoalType:
+		var ids []string
+		if ctx.Enemies != nil {
+			ids = ctx.Enemies(spec.Tag)
+		}
+		return NewKillAllGoal(spec.Tag, ids)
+	case CollectGoalType:
+		return &CollectGoal{ItemType: spec.ItemType, Count: spec.Count}
+	case SurviveGoalType:
+		return &SurviveGoal{Frames: secondsToFrames(spec.Seconds)}
+	case TimedGoalType:
+		return &TimedGoal{Goal: &AllGoal{Goals: buildGoals(spec.Goals, ctx)}, Frames: secondsToFrames(spec.Seconds)}
+	case AllGoalType:
+		return &AllGoal{Goals: buildGoals(spec.Goals, ctx)}
+	case AnyGoalType:
+		return &AnyGoal{Goals: buildGoals(spec.Goals, ctx)}
+	default:
+		return &NoGoal{}
+	}
+}
+
+func buildGoals(specs []GoalSpec, ctx GoalContext) []Goal {
+	goals := make([]Goal, len(specs))
+	for i, spec := range specs {
+		goals[i] = buildGoal(spec, ctx)
+	}
+	return goals
+}
+
+func setOnComplete(g Goal, fn func()) {
+	switch g := g.(type) {
+	case *ReachEndpointGoal:
+		g.OnCompletion_ = fn
+	case *SequenceGoal:
+		g.OnCompleteFunc = fn
+	case *KillAllGoal:
+		g.OnCompleteFunc = fn
+	case *CollectGoal:
+		g.OnCompleteFunc = fn
+	case *SurviveGoal:
+		g.OnCompleteFunc = fn
+	case *TimedGoal:
+		g.OnCompleteFunc = fn
+	case *AllGoal:
+		g.OnCompleteFunc = fn
+	case *AnyGoal:
+		g.OnCompleteFunc = fn
+	}
+}
+
+func secondsToFrames(seconds float64) int {
+	return timing.FromDuration(time.Duration(seconds * float64(time.Second)))
+}
@@ -0,0 +1,147 @@
+package phases
+
+import (
+	"testing"
+
+	actorevents "github.com/boilerplate/ebiten-template/internal/engine/entity/actors/events"
+	itemevents "github.com/boilerplate/ebiten-template/internal/engine/entity/items/events"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
+)
+
+func enemiesByTag(tag string) []string {
+	if tag == "boss" {
+		return []string{"wolf_boss"}
+	}
+	return []string{"bat_0", "bat_1", "wolf_boss"}
+}
+
+func TestBuildGoal_AllOfKillTaggedAndCollect(t *testing.T) {
+	completed := 0
+	g := BuildGoal(GoalSpec{Type: AllGoalType, Goals: []GoalSpec{
+		{Type: KillAllGoalType, Tag: "boss"},
+		{Type: CollectGoalType, ItemType: "star", Count: 2},
+	}}, GoalContext{Enemies: enemiesByTag, OnComplete: func() { completed++ }})
+	em := event.NewManager()
+	SubscribeGoal(g, em)
+
+	em.Publish(&actorevents.ActorDiedEvent{ID: "bat_0"})
+	em.Publish(&itemevents.ItemCollectedEvent{ItemType: "star"})
+	em.Publish(&itemevents.ItemCollectedEvent{ItemType: "coin"})
+	if g.IsCompleted() {
+		t.Fatal("completed before the boss was beaten and both stars collected")
+	}
+	want := []Progress{
+		{Goal: KillAllGoalType, Label: "boss", Current: 0, Target: 1},
+		{Goal: CollectGoalType, Label: "star", Current: 1, Target: 2},
+	}
+	if got := GoalProgress(g); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("GoalProgress() = %+v, want %+v", got, want)
+	}
+
+	em.Publish(&actorevents.ActorDiedEvent{ID: "wolf_boss"})
+	em.Publish(&itemevents.ItemCollectedEvent{ItemType: "star"})
+	if !g.IsCompleted() {
+		t.Fatalf("not completed: %+v", GoalProgress(g))
+	}
+	g.OnCompletion()
+	if completed != 1 {
+		t.Errorf("OnComplete called %d times, want 1", completed)
+	}
+}
+
+func TestBuildGoal_TimedReachEndpoint(t *testing.T) {
+	spec := GoalSpec{Type: TimedGoalType, Seconds: 1, Goals: []GoalSpec{{Type: ReactEndpointType}}}
+	frames := secondsToFrames(1)
+
+	g := BuildGoal(spec, GoalContext{})
+	for range frames - 1 {
+		UpdateGoal(g)
+	}
+	if IsFailed(g) {
+		t.Fatal("failed before time ran out")
+	}
+	ReachEndpoint(g)
+	UpdateGoal(g)
+	if !g.IsCompleted() || IsFailed(g) {
+		t.Errorf("reached in time: completed %v, failed %v", g.IsCompleted(), IsFailed(g))
+	}
+
+	late := BuildGoal(spec, GoalContext{})
+	for range frames {
+		UpdateGoal(late)
+	}
+	ReachEndpoint(late)
+	if late.IsCompleted() || !IsFailed(late) {
+		t.Errorf("reached too late: completed %v, failed %v", late.IsCompleted(), IsFailed(late))
+	}
+}
+
+func TestBuildGoal_AnyOfSurviveOrKillAll(t *testing.T) {
+	g := BuildGoal(GoalSpec{Type: AnyGoalType, Goals: []GoalSpec{
+		{Type: SurviveGoalType, Seconds: 1},
+		{Type: KillAllGoalType},
+	}}, GoalContext{Enemies: enemiesByTag})
+	em := event.NewManager()
+	SubscribeGoal(g, em)
+
+	for _, id := range enemiesByTag("") {
+		em.Publish(&actorevents.ActorDiedEvent{ID: id})
+	}
+	if !g.IsCompleted() {
+		t.Error("beating every enemy did not complete the goal")
+	}
+
+	survive := BuildGoal(GoalSpec{Type: SurviveGoalType, Seconds: 1}, GoalContext{})
+	for range secondsToFrames(1) {
+		if survive.IsCompleted() {
+			t.Fatal("survived too soon")
+		}
+		UpdateGoal(survive)
+	}
+	if !survive.IsCompleted() {
+		t.Error("not completed after surviving")
+	}
+}
+
+func TestBuildGoal_KillAllTracksSpawnedEnemies(t *testing.T) {
+	g := BuildGoal(GoalSpec{Type: KillAllGoalType, Tag: "wave"}, GoalContext{})
+	em := event.NewManager()
+	SubscribeGoal(g, em)
+
+	if g.IsCompleted() {
+		t.Fatal("completed before any enemy spawned")
+	}
+	em.Publish(&actorevents.EnemySpawnedEvent{ID: "bat_0", Tags: []string{"wave"}})
+	em.Publish(&actorevents.EnemySpawnedEvent{ID: "bat_1", Tags: []string{"wave"}})
+	em.Publish(&actorevents.EnemySpawnedEvent{ID: "wolf", Tags: []string{"boss"}})
+	em.Publish(&actorevents.ActorDiedEvent{ID: "bat_0"})
+	if g.IsCompleted() {
+		t.Fatal("completed with a spawned enemy still alive")
+	}
+	want := Progress{Goal: KillAllGoalType, Label: "wave", Current: 1, Target: 2}
+	if got := GoalProgress(g); len(got) != 1 || got[0] != want {
+		t.Errorf("GoalProgress() = %+v, want [%+v]", got, want)
+	}
+
+	em.Publish(&actorevents.ActorDiedEvent{ID: "bat_1"})
+	if !g.IsCompleted() {
+		t.Errorf("not completed: %+v", GoalProgress(g))
+	}
+}
+
+func TestGoalSpecValidate(t *testing.T) {
+	valid := GoalSpec{Type: TimedGoalType, Seconds: 30, Goals: []GoalSpec{{Type: ReactEndpointType}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate(valid) = %v", err)
+	}
+	for _, spec := range []GoalSpec{
+		{Type: "capture_the_flag"},
+		{Type: SurviveGoalType},
+		{Type: AllGoalType},
+		{Type: AnyGoalType, Goals: []GoalSpec{{Type: "bogus"}}},
+	} {
+		if err := spec.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", spec)
+		}
+	}
+}
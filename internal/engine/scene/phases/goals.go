@@ -1,6 +1,9 @@
 package phases
 
-import "github.com/boilerplate/ebiten-template/internal/engine/contracts/sequences"
+import (
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/sequences"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
+)
 
 // Goal defines the interface for phase completion criteria
 type Goal interface {
@@ -8,6 +11,89 @@ type Goal interface {
 	OnCompletion()
 }
 
+// UpdatableGoal is a goal that advances every frame, such as a timer. Scenes
+// call UpdateGoal once per frame.
+type UpdatableGoal interface {
+	Goal
+	Update()
+}
+
+// FailableGoal is a goal that can be lost, e.g. when its time runs out.
+type FailableGoal interface {
+	Goal
+	IsFailed() bool
+}
+
+// EventGoal is a goal that counts events, such as enemies defeated. Scenes
+// call SubscribeGoal when the goal is created.
+type EventGoal interface {
+	Goal
+	Subscribe(em *event.Manager, opts ...event.SubscribeOption)
+}
+
+// ProgressGoal is a goal that reports its progress for a HUD.
+type ProgressGoal interface {
+	Goal
+	Progress() []Progress
+}
+
+// Progress is how far one goal has come. Current and Target count enemies,
+// items or frames, depending on Goal.
+type Progress struct {
+	Goal GoalType
+	// Label is the tag or item type the goal counts, if any.
+	Label   string
+	Current int
+	Target  int
+}
+
+// UpdateGoal advances g by one frame if it is an UpdatableGoal.
+func UpdateGoal(g Goal) {
+	if u, ok := g.(UpdatableGoal); ok {
+		u.Update()
+	}
+}
+
+// IsFailed reports whether g is a FailableGoal that has been lost.
+func IsFailed(g Goal) bool {
+	f, ok := g.(FailableGoal)
+	return ok && f.IsFailed()
+}
+
+// SubscribeGoal subscribes g to em if it is an EventGoal.
+func SubscribeGoal(g Goal, em *event.Manager, opts ...event.SubscribeOption) {
+	if e, ok := g.(EventGoal); ok && em != nil {
+		e.Subscribe(em, opts...)
+	}
+}
+
+// GoalProgress returns the progress of g, or nil if it reports none.
+func GoalProgress(g Goal) []Progress {
+	if p, ok := g.(ProgressGoal); ok {
+		return p.Progress()
+	}
+	return nil
+}
+
+// ReachEndpoint marks every ReachEndpointGoal in g, including those inside
+// composite and timed goals, as reached.
+func ReachEndpoint(g Goal) {
+	switch g := g.(type) {
+	case *ReachEndpointGoal:
+		g.Reach()
+	case *AllGoal:
+		for _, child := range g.Goals {
+			ReachEndpoint(child)
+		}
+	case *AnyGoal:
+		for _, child := range g.Goals {
+			ReachEndpoint(child)
+		}
+	case *TimedGoal:
+		ReachEndpoint(g.Goal)
+	}
+}
+
 // GoalType constants for identifying the completion criteria of a phase.
 // These live in the engine package so both kit and game layers can reference them.
 //
@@ -16,6 +102,12 @@ var (
 	ReactEndpointType GoalType = "reach_endpoint"
 	SequenceGoalType  GoalType = "sequence"
 	NoGoalType        GoalType = "no_goal"
+	KillAllGoalType   GoalType = "kill_all"
+	CollectGoalType   GoalType = "collect"
+	SurviveGoalType   GoalType = "survive"
+	TimedGoalType     GoalType = "timed"
+	AllGoalType       GoalType = "all"
+	AnyGoalType       GoalType = "any"
 )
 
 // SequenceGoal: Complete when sequence finishes
@@ -0,0 +1,99 @@
+package phases
+
+import "github.com/boilerplate/ebiten-template/internal/engine/event"
+
+// AllGoal completes once every one of its goals is completed, and fails as
+// soon as one of them fails.
+type AllGoal struct {
+	Goals          []Goal
+	OnCompleteFunc func()
+}
+
+func (g *AllGoal) IsCompleted() bool {
+	for _, child := range g.Goals {
+		if !child.IsCompleted() {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *AllGoal) IsFailed() bool {
+	for _, child := range g.Goals {
+		if IsFailed(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *AllGoal) OnCompletion() {
+	if g.OnCompleteFunc != nil {
+		g.OnCompleteFunc()
+	}
+}
+
+func (g *AllGoal) Update()              { updateGoals(g.Goals) }
+func (g *AllGoal) Progress() []Progress { return goalsProgress(g.Goals) }
+
+func (g *AllGoal) Subscribe(em *event.Manager, opts ...event.SubscribeOption) {
+	subscribeGoals(g.Goals, em, opts)
+}
+
+// AnyGoal completes once one of its goals is completed, and fails only when
+// all of them have failed.
+type AnyGoal struct {
+	Goals          []Goal
+	OnCompleteFunc func()
+}
+
+func (g *AnyGoal) IsCompleted() bool {
+	for _, child := range g.Goals {
+		if child.IsCompleted() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *AnyGoal) IsFailed() bool {
+	for _, child := range g.Goals {
+		if !IsFailed(child) {
+			return false
+		}
+	}
+	return len(g.Goals) > 0
+}
+
+func (g *AnyGoal) OnCompletion() {
+	if g.OnCompleteFunc != nil {
+		g.OnCompleteFunc()
+	}
+}
+
+func (g *AnyGoal) Update()              { updateGoals(g.Goals) }
+func (g *AnyGoal) Progress() []Progress { return goalsProgress(g.Goals) }
+
+func (g *AnyGoal) Subscribe(em *event.Manager, opts ...event.SubscribeOption) {
+	subscribeGoals(g.Goals, em, opts)
+}
+
+func updateGoals(goals []Goal) {
+	for _, g := range goals {
+		UpdateGoal(g)
+	}
+}
+
+func goalsProgress(goals []Goal) []Progress {
+	var list []Progress
+	for _, g := range goals {
+		list = append(list, GoalProgress(g)...)
+	}
+	return list
+}
+
+func subscribeGoals(goals []Goal, em *event.Manager, opts []event.SubscribeOption) {
+	for _, g := range goals {
+		SubscribeGoal(g, em, opts...)
+	}
+}
@@ -0,0 +1,176 @@
+package phases
+
+import (
+	"slices"
+
+	actorevents "github.com/boilerplate/ebiten-template/internal/engine/entity/actors/events"
+	itemevents "github.com/boilerplate/ebiten-template/internal/engine/entity/items/events"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
+)
+
+// KillAllGoal completes once every target actor has died. Scenes create it
+// with the IDs of the phase's enemies, or only those carrying Tag, and it
+// also tracks enemies spawned later. It never completes before it has seen
+// an enemy, so a wave that has yet to spawn doesn't count as beaten.
+type KillAllGoal struct {
+	Tag            string
+	OnCompleteFunc func()
+
+	seen  map[string]bool
+	alive map[string]bool
+}
+
+// NewKillAllGoal returns a goal to defeat the actors with the given IDs.
+func NewKillAllGoal(tag string, ids []string) *KillAllGoal {
+	g := &KillAllGoal{Tag: tag, seen: make(map[string]bool, len(ids)), alive: make(map[string]bool, len(ids))}
+	for _, id := range ids {
+		g.Track(id)
+	}
+	return g
+}
+
+// Track adds the actor with the given ID to the targets, unless it was
+// already tracked.
+func (g *KillAllGoal) Track(id string) {
+	if g.seen[id] {
+		return
+	}
+	g.seen[id] = true
+	g.alive[id] = true
+}
+
+// Defeat counts the actor with the given ID as defeated.
+func (g *KillAllGoal) Defeat(id string) {
+	delete(g.alive, id)
+}
+
+func (g *KillAllGoal) IsCompleted() bool { return len(g.seen) > 0 && len(g.alive) == 0 }
+
+func (g *KillAllGoal) OnCompletion() {
+	if g.OnCompleteFunc != nil {
+		g.OnCompleteFunc()
+	}
+}
+
+// Subscribe tracks EnemySpawnedEvents carrying Tag and counts
+// ActorDiedEvents.
+func (g *KillAllGoal) Subscribe(em *event.Manager, opts ...event.SubscribeOption) {
+	event.Subscribe(em, func(e *actorevents.EnemySpawnedEvent) {
+		if g.Tag == "" || slices.Contains(e.Tags, g.Tag) {
+			g.Track(e.ID)
+		}
+	}, opts...)
+	event.Subscribe(em, func(e *actorevents.ActorDiedEvent) { g.Defeat(e.ID) }, opts...)
+}
+
+func (g *KillAllGoal) Progress() []Progress {
+	total := len(g.seen)
+	return []Progress{{Goal: KillAllGoalType, Label: g.Tag, Current: total - len(g.alive), Target: total}}
+}
+
+// CollectGoal completes once Count items of ItemType have been collected.
+// An empty ItemType counts every item; a non-positive Count means one.
+type CollectGoal struct {
+	ItemType       string
+	Count          int
+	OnCompleteFunc func()
+
+	collected int
+}
+
+// Collect counts one collected item of the given type.
+func (g *CollectGoal) Collect(itemType string) {
+	if g.ItemType == "" || itemType == g.ItemType {
+		g.collected++
+	}
+}
+
+func (g *CollectGoal) IsCompleted() bool { return g.collected >= g.target() }
+
+func (g *CollectGoal) OnCompletion() {
+	if g.OnCompleteFunc != nil {
+		g.OnCompleteFunc()
+	}
+}
+
+// Subscribe counts ItemCollectedEvents.
+func (g *CollectGoal) Subscribe(em *event.Manager, opts ...event.SubscribeOption) {
+	event.Subscribe(em, func(e *itemevents.ItemCollectedEvent) { g.Collect(e.ItemType) }, opts...)
+}
+
+func (g *CollectGoal) Progress() []Progress {
+	target := g.target()
+	return []Progress{{Goal: CollectGoalType, Label: g.ItemType, Current: min(g.collected, target), Target: target}}
+}
+
+func (g *CollectGoal) target() int {
+	return max(g.Count, 1)
+}
+
+// SurviveGoal completes once Frames frames have passed.
+type SurviveGoal struct {
+	Frames         int
+	OnCompleteFunc func()
+
+	elapsed int
+}
+
+func (g *SurviveGoal) Update() {
+	if g.elapsed < g.Frames {
+		g.elapsed++
+	}
+}
+
+func (g *SurviveGoal) IsCompleted() bool { return g.elapsed >= g.Frames }
+
+func (g *SurviveGoal) OnCompletion() {
+	if g.OnCompleteFunc != nil {
+		g.OnCompleteFunc()
+	}
+}
+
+func (g *SurviveGoal) Progress() []Progress {
+	return []Progress{{Goal: SurviveGoalType, Current: g.elapsed, Target: g.Frames}}
+}
+
+// TimedGoal wraps a goal that must be completed within Frames frames, e.g.
+// reaching the endpoint before a timer expires. It fails when time runs out,
+// and stays failed even if the wrapped goal is completed later.
+type TimedGoal struct {
+	Goal           Goal
+	Frames         int
+	OnCompleteFunc func()
+
+	elapsed int
+	failed  bool
+}
+
+func (g *TimedGoal) Update() {
+	UpdateGoal(g.Goal)
+	if g.failed || g.Goal.IsCompleted() {
+		return
+	}
+	g.elapsed++
+	if g.elapsed >= g.Frames {
+		g.failed = true
+	}
+}
+
+func (g *TimedGoal) IsCompleted() bool { return !g.IsFailed() && g.Goal.IsCompleted() }
+
+func (g *TimedGoal) IsFailed() bool { return g.failed || IsFailed(g.Goal) }
+
+func (g *TimedGoal) OnCompletion() {
+	if g.OnCompleteFunc != nil {
+		g.OnCompleteFunc()
+	}
+}
+
+func (g *TimedGoal) Subscribe(em *event.Manager, opts ...event.SubscribeOption) {
+	SubscribeGoal(g.Goal, em, opts...)
+}
+
+// Progress reports the wrapped goal's progress followed by the time used.
+func (g *TimedGoal) Progress() []Progress {
+	return append(GoalProgress(g.Goal), Progress{Goal: TimedGoalType, Current: g.elapsed, Target: g.Frames})
+}
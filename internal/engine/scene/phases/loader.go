@@ -0,0 +1,155 @@
+package phases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Graph is a phase list loaded from JSON: every phase of the game and the
+// one it starts at.
+type Graph struct {
+	StartPhaseID int
+	Phases       []Phase
+}
+
+type graphData struct {
+	StartPhaseID int         `json:"start_phase_id"`
+	Phases       []phaseData `json:"phases"`
+}
+
+type phaseData struct {
+	ID                  int            `json:"id"`
+	Name                string         `json:"name"`
+	Title               string         `json:"title"`
+	Genre               string         `json:"genre"`
+	TilemapPath         string         `json:"tilemap_path"`
+	SequencePath        string         `json:"sequence_path"`
+	GoalType            GoalType       `json:"goal_type"`
+	NextPhaseID         int            `json:"next_phase_id"`
+	BlockPlayerMovement bool           `json:"block_player_movement"`
+	AutoScrollX         float64        `json:"auto_scroll_x"`
+	AutoScrollY         float64        `json:"auto_scroll_y"`
+	Exits               []exitData     `json:"exits"`
+	Unlock              *conditionData `json:"unlock"`
+	Goal                *GoalSpec      `json:"goal"`
+}
+
+type exitData struct {
+	EventID     string `json:"event_id"`
+	NextPhaseID int    `json:"next_phase_id"`
+}
+
+type conditionData struct {
+	Completed    []int `json:"completed"`
+	AnyCompleted []int `json:"any_completed"`
+}
+
+// LoadGraph reads a phase graph from a JSON file in fsys and validates it.
+// Genres are written by name and looked up in genres. The scene type of each
+// phase is left for the caller to fill in from its genre. A missing
+// start_phase_id starts at the first phase.
+func LoadGraph(fsys fs.FS, path string, genres map[string]Genre) (*Graph, error) {
+	raw, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	var data graphData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	g := &Graph{StartPhaseID: data.StartPhaseID}
+	var errs []error
+	for _, d := range data.Phases {
+		genre, ok := genres[d.Genre]
+		if !ok {
+			errs = append(errs, fmt.Errorf("phase %d: unknown genre %q", d.ID, d.Genre))
+		}
+		p := Phase{
+			ID:                  d.ID,
+			Name:                d.Name,
+			Title:               d.Title,
+			Genre:               genre,
+			TilemapPath:         d.TilemapPath,
+			SequencePath:        d.SequencePath,
+			GoalType:            d.GoalType,
+			NextPhaseID:         d.NextPhaseID,
+			BlockPlayerMovement: d.BlockPlayerMovement,
+			AutoScrollX:         d.AutoScrollX,
+			AutoScrollY:         d.AutoScrollY,
+		}
+		for _, e := range d.Exits {
+			p.Exits = append(p.Exits, Exit(e))
+		}
+		if d.Unlock != nil {
+			p.Unlock = &Condition{Completed: d.Unlock.Completed, AnyCompleted: d.Unlock.AnyCompleted}
+		}
+		if d.Goal != nil {
+			p.Goal = d.Goal
+			if p.GoalType == "" {
+				p.GoalType = d.Goal.Type
+			}
+		}
+		for _, asset := range []string{p.TilemapPath, p.SequencePath} {
+			if asset == "" {
+				continue
+			}
+			if _, err := fs.Stat(fsys, asset); err != nil {
+				errs = append(errs, fmt.Errorf("phase %d: %w", p.ID, err))
+			}
+		}
+		g.Phases = append(g.Phases, p)
+	}
+	if g.StartPhaseID == 0 && len(g.Phases) > 0 {
+		g.StartPhaseID = g.Phases[0].ID
+	}
+	errs = append(errs, g.Validate())
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("loading phases from %s: %w", path, err)
+	}
+	return g, nil
+}
+
+// Validate checks that phase IDs are positive and unique, that goals are
+// well formed, and that the start phase, exits and unlock conditions only
+// name phases of the graph.
+func (g *Graph) Validate() error {
+	var errs []error
+	ids := make(map[int]bool, len(g.Phases))
+	for _, p := range g.Phases {
+		if p.ID <= 0 {
+			errs = append(errs, fmt.Errorf("phase %q: id must be positive, got %d", p.Name, p.ID))
+		}
+		if ids[p.ID] {
+			errs = append(errs, fmt.Errorf("phase %d: duplicate id", p.ID))
+		}
+		ids[p.ID] = true
+	}
+	if len(g.Phases) == 0 {
+		errs = append(errs, errors.New("no phases"))
+	} else if !ids[g.StartPhaseID] {
+		errs = append(errs, fmt.Errorf("start phase %d not found", g.StartPhaseID))
+	}
+
+	for _, p := range g.Phases {
+		if err := p.GoalSpec().Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("phase %d: %w", p.ID, err))
+		}
+		for _, e := range p.exits() {
+			if !ids[e.NextPhaseID] {
+				errs = append(errs, fmt.Errorf("phase %d: exit %q leads to unknown phase %d", p.ID, e.EventID, e.NextPhaseID))
+			}
+		}
+		if p.Unlock == nil {
+			continue
+		}
+		for _, id := range append(append([]int(nil), p.Unlock.Completed...), p.Unlock.AnyCompleted...) {
+			if !ids[id] {
+				errs = append(errs, fmt.Errorf("phase %d: unlock condition names unknown phase %d", p.ID, id))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+package phases
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var testGenres = map[string]Genre{"platformer": 1, "beatemup": 2}
+
+func TestLoadGraph(t *testing.T) {
+	fsys := fstest.MapFS{
+		"maps/a.tmj": {},
+		"maps/b.tmj": {},
+		"phases.json": {Data: []byte(`{
+			"start_phase_id": 2,
+			"phases": [
+				{"id": 1, "name": "Town", "genre": "beatemup", "tilemap_path": "maps/b.tmj"},
+				{"id": 2, "name": "Forest", "genre": "platformer", "tilemap_path": "maps/a.tmj",
+				 "goal_type": "reach_endpoint", "auto_scroll_x": 0.5,
+				 "exits": [{"event_id": "cave", "next_phase_id": 3}, {"next_phase_id": 1}]},
+				{"id": 3, "name": "Cave", "genre": "platformer", "unlock": {"completed": [1]},
+				 "goal": {"type": "all", "goals": [{"type": "kill_all", "tag": "boss"}, {"type": "collect", "item_type": "star", "count": 3}]}}
+			]
+		}`)},
+	}
+
+	g, err := LoadGraph(fsys, "phases.json", testGenres)
+	if err != nil {
+		t.Fatalf("LoadGraph: %v", err)
+	}
+	if g.StartPhaseID != 2 || len(g.Phases) != 3 {
+		t.Fatalf("graph = start %d, %d phases", g.StartPhaseID, len(g.Phases))
+	}
+	p := g.Phases[1]
+	if p.Genre != 1 || p.GoalType != ReactEndpointType || p.AutoScrollX != 0.5 || p.TilemapPath != "maps/a.tmj" {
+		t.Errorf("phase 2 = %+v", p)
+	}
+	if len(p.Exits) != 2 || p.Exits[0] != (Exit{EventID: "cave", NextPhaseID: 3}) {
+		t.Errorf("exits = %+v", p.Exits)
+	}
+	if c := g.Phases[2].Unlock; c == nil || len(c.Completed) != 1 || c.Completed[0] != 1 {
+		t.Errorf("unlock = %+v", c)
+	}
+	if spec := g.Phases[2].GoalSpec(); spec.Type != AllGoalType || len(spec.Goals) != 2 || spec.Goals[1].Count != 3 {
+		t.Errorf("goal = %+v", spec)
+	}
+	if g.Phases[2].GoalType != AllGoalType {
+		t.Errorf("GoalType = %q, want it taken from the goal", g.Phases[2].GoalType)
+	}
+}
+
+func TestLoadGraphReportsEveryProblem(t *testing.T) {
+	fsys := fstest.MapFS{
+		"phases.json": {Data: []byte(`{"phases": [
+			{"id": 1, "genre": "racing", "tilemap_path": "maps/missing.tmj", "next_phase_id": 7},
+			{"id": 1, "genre": "platformer", "unlock": {"any_completed": [8]}, "goal": {"type": "survive"}}
+		]}`)},
+	}
+
+	_, err := LoadGraph(fsys, "phases.json", testGenres)
+	if err == nil {
+		t.Fatal("want an error")
+	}
+	for _, want := range []string{`unknown genre "racing"`, "maps/missing.tmj", "duplicate id", "unknown phase 7", "unknown phase 8", "survive goal needs positive seconds"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
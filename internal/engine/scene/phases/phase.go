@@ -20,4 +20,42 @@ type Phase struct {
 	SceneType           navigation.SceneType
 	Genre               Genre
 	BlockPlayerMovement bool
+	// AutoScrollX and AutoScrollY make platformer phases scroll the camera
+	// at a constant speed, in pixels per frame, instead of following the
+	// player. A player pinned against a wall by the screen edge dies.
+	AutoScrollX, AutoScrollY float64
+	// Exits are the phases this one leads to. The endpoint the player
+	// reaches picks one by its event_id; see Manager.ChooseExit. A phase
+	// without exits goes to NextPhaseID.
+	Exits []Exit
+	// Unlock, when set, keeps the phase locked until its condition is met.
+	Unlock *Condition
+	// Goal, when set, replaces GoalType with a configured, possibly
+	// composite goal.
+	Goal *GoalSpec
+}
+
+// Exit is an edge of the phase graph.
+type Exit struct {
+	// EventID is the event_id of the endpoint that takes this exit. Empty
+	// matches any endpoint without an exit of its own.
+	EventID     string
+	NextPhaseID int
+}
+
+// Condition unlocks a phase once other phases have been completed.
+type Condition struct {
+	// Completed phases must all have been completed.
+	Completed []int
+	// AnyCompleted, when not empty, needs at least one of its phases
+	// completed.
+	AnyCompleted []int
+}
+
+// exits returns the phase's exits, falling back to NextPhaseID.
+func (p Phase) exits() []Exit {
+	if len(p.Exits) > 0 || p.NextPhaseID == 0 {
+		return p.Exits
+	}
+	return []Exit{{NextPhaseID: p.NextPhaseID}}
 }
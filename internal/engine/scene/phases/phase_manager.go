@@ -1,15 +1,23 @@
 package phases
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 type Manager struct {
 	phases       map[int]Phase
 	CurrentPhase int
+
+	completed map[int]bool
+	// exitEvent is the event_id chosen for leaving the current phase.
+	exitEvent string
 }
 
 func NewManager() *Manager {
 	return &Manager{
-		phases: make(map[int]Phase),
+		phases:    make(map[int]Phase),
+		completed: make(map[int]bool),
 	}
 }
 
@@ -25,6 +33,16 @@ func (m *Manager) GetPhase(id int) (Phase, error) {
 	return p, nil
 }
 
+// Phases returns every phase, ordered by ID.
+func (m *Manager) Phases() []Phase {
+	list := make([]Phase, 0, len(m.phases))
+	for _, p := range m.phases {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
 func (m *Manager) GetCurrentPhase() (Phase, error) {
 	return m.GetPhase(m.CurrentPhase)
 }
@@ -35,18 +53,98 @@ func (m *Manager) SetCurrentPhase(id int) error {
 		return err
 	}
 	m.CurrentPhase = id
+	m.exitEvent = ""
 	return nil
 }
 
+// ChooseExit records the event_id of the endpoint the player reached, so the
+// next AdvanceToNextPhase takes the exit matching it.
+func (m *Manager) ChooseExit(eventID string) {
+	m.exitEvent = eventID
+}
+
+// AdvanceToNextPhase completes the current phase and moves to the first
+// unlocked exit matching the chosen event_id, or else to the first unlocked
+// exit without one.
 func (m *Manager) AdvanceToNextPhase() error {
 	p, err := m.GetCurrentPhase()
 	if err != nil {
 		return err
 	}
 
-	if p.NextPhaseID == 0 {
+	next, ok := m.nextPhase(p, m.exitEvent)
+	if !ok {
 		return fmt.Errorf("no next phase defined for phase %d", p.ID)
 	}
 
-	return m.SetCurrentPhase(p.NextPhaseID)
+	m.completed[p.ID] = true
+	return m.SetCurrentPhase(next)
+}
+
+func (m *Manager) nextPhase(p Phase, eventID string) (int, bool) {
+	exits := p.exits()
+	if eventID != "" {
+		for _, e := range exits {
+			if e.EventID == eventID && m.Unlocked(e.NextPhaseID) {
+				return e.NextPhaseID, true
+			}
+		}
+	}
+	for _, e := range exits {
+		if e.EventID == "" && m.Unlocked(e.NextPhaseID) {
+			return e.NextPhaseID, true
+		}
+	}
+	return 0, false
+}
+
+// Unlocked reports whether the phase exists and its unlock condition, if
+// any, is met.
+func (m *Manager) Unlocked(id int) bool {
+	p, ok := m.phases[id]
+	if !ok {
+		return false
+	}
+	c := p.Unlock
+	if c == nil {
+		return true
+	}
+	for _, id := range c.Completed {
+		if !m.completed[id] {
+			return false
+		}
+	}
+	if len(c.AnyCompleted) == 0 {
+		return true
+	}
+	for _, id := range c.AnyCompleted {
+		if m.completed[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// Completed reports whether the phase has been completed.
+func (m *Manager) Completed(id int) bool {
+	return m.completed[id]
+}
+
+// CompletedPhases returns the IDs of the completed phases, sorted. Used by
+// the save system.
+func (m *Manager) CompletedPhases() []int {
+	ids := make([]int, 0, len(m.completed))
+	for id := range m.completed {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// RestoreCompletedPhases marks the given phases completed, e.g. after
+// loading a save.
+func (m *Manager) RestoreCompletedPhases(ids []int) {
+	for _, id := range ids {
+		m.completed[id] = true
+	}
 }
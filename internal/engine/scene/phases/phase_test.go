@@ -29,6 +29,65 @@ func TestManagerAdvanceToNextPhaseIntegration(t *testing.T) {
 	}
 }
 
+func TestManagerExitsBranchOnEventAndUnlock(t *testing.T) {
+	m := NewManager()
+	m.AddPhase(Phase{ID: 1, Exits: []Exit{
+		{EventID: "secret", NextPhaseID: 3},
+		{EventID: "boss", NextPhaseID: 4},
+		{NextPhaseID: 2},
+	}})
+	m.AddPhase(Phase{ID: 2, NextPhaseID: 1})
+	m.AddPhase(Phase{ID: 3})
+	m.AddPhase(Phase{ID: 4, Unlock: &Condition{Completed: []int{2}}})
+
+	_ = m.SetCurrentPhase(1)
+	m.ChooseExit("boss")
+	if err := m.AdvanceToNextPhase(); err != nil {
+		t.Fatalf("AdvanceToNextPhase: %v", err)
+	}
+	if m.CurrentPhase != 2 {
+		t.Fatalf("locked boss exit: CurrentPhase = %d, want the default exit 2", m.CurrentPhase)
+	}
+
+	if err := m.AdvanceToNextPhase(); err != nil {
+		t.Fatalf("AdvanceToNextPhase: %v", err)
+	}
+	if !m.Unlocked(4) {
+		t.Fatal("phase 4 still locked after completing phase 2")
+	}
+	m.ChooseExit("boss")
+	if err := m.AdvanceToNextPhase(); err != nil {
+		t.Fatalf("AdvanceToNextPhase: %v", err)
+	}
+	if m.CurrentPhase != 4 {
+		t.Errorf("CurrentPhase = %d, want 4", m.CurrentPhase)
+	}
+	if got := m.CompletedPhases(); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("CompletedPhases() = %v, want [1 2]", got)
+	}
+
+	if err := m.AdvanceToNextPhase(); err == nil {
+		t.Error("phase without exits: want an error")
+	}
+}
+
+func TestConditionAnyCompleted(t *testing.T) {
+	m := NewManager()
+	m.AddPhase(Phase{ID: 1})
+	m.AddPhase(Phase{ID: 2})
+	m.AddPhase(Phase{ID: 3, Unlock: &Condition{AnyCompleted: []int{1, 2}}})
+	if m.Unlocked(3) {
+		t.Error("unlocked before either phase was completed")
+	}
+	m.RestoreCompletedPhases([]int{2})
+	if !m.Unlocked(3) {
+		t.Error("still locked after phase 2 was completed")
+	}
+	if m.Unlocked(9) {
+		t.Error("unknown phase reported unlocked")
+	}
+}
+
 type testPlayer struct {
 	playing bool
 	played  []contractseq.Sequence
@@ -0,0 +1,229 @@
+package scene
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"strings"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/render/tilemap"
+)
+
+// RoomKey identifies a named room in one of the maps of a phase.
+type RoomKey struct {
+	Map  string
+	Room string
+}
+
+// String returns the key as "map#room", the form saves store it in.
+func (k RoomKey) String() string {
+	return k.Map + "#" + k.Room
+}
+
+// ParseRoomKey parses a key written by RoomKey.String.
+func ParseRoomKey(s string) RoomKey {
+	m, room, found := strings.Cut(s, "#")
+	if !found {
+		return RoomKey{Room: s}
+	}
+	return RoomKey{Map: m, Room: room}
+}
+
+// Minimap records the rooms the player has visited across the maps of a
+// phase, in the order they were first entered. A map screen draws from it,
+// and it implements save.VisitedRoomsTracker so progress is saved.
+type Minimap struct {
+	visited map[RoomKey]bool
+	order   []RoomKey
+}
+
+// NewMinimap returns a minimap with no rooms visited.
+func NewMinimap() *Minimap {
+	return &Minimap{visited: make(map[RoomKey]bool)}
+}
+
+// Visit marks a room as visited and reports whether it is the first visit.
+func (m *Minimap) Visit(k RoomKey) bool {
+	if m.visited[k] {
+		return false
+	}
+	m.visited[k] = true
+	m.order = append(m.order, k)
+	return true
+}
+
+// Visited reports whether the player has been in the room.
+func (m *Minimap) Visited(k RoomKey) bool {
+	return m.visited[k]
+}
+
+// Rooms returns the visited rooms in the order they were first entered.
+func (m *Minimap) Rooms() []RoomKey {
+	return m.order
+}
+
+// VisitedRooms implements save.VisitedRoomsTracker.
+func (m *Minimap) VisitedRooms() []string {
+	keys := make([]string, len(m.order))
+	for i, k := range m.order {
+		keys[i] = k.String()
+	}
+	return keys
+}
+
+// RestoreVisitedRooms implements save.VisitedRoomsTracker.
+func (m *Minimap) RestoreVisitedRooms(keys []string) {
+	for _, k := range keys {
+		m.Visit(ParseRoomKey(k))
+	}
+}
+
+// Travel is a trip through a door.
+type Travel struct {
+	From RoomKey
+	Door tilemap.Door
+	// Offset is the player's position relative to the top-left corner of
+	// the door they walked into. They come out at the same offset from the
+	// exit door.
+	Offset image.Point
+}
+
+// RoomGraph links the rooms of a ScreenFlipper to rooms in the same or other
+// maps through the doors of the map's "Doors" layer, so a phase becomes a
+// graph of maps. Walking into a door moves the player to the matching door
+// of the target room and snaps the camera there.
+type RoomGraph struct {
+	mapPath string
+	flipper *ScreenFlipper
+	doors   []*tilemap.Door
+	minimap *Minimap
+	// armed is false until the player is outside every door, so arriving
+	// in a door doesn't send them straight back.
+	armed bool
+
+	// OnTravel is called when the player walks through a door to another
+	// map. The scene loads that map, builds a RoomGraph for it and calls
+	// Arrive on it with t. Doors within the map are handled by the graph.
+	OnTravel func(t Travel)
+}
+
+// NewRoomGraph returns the room graph of the map at mapPath, whose rooms and
+// player are those of flipper. Visited rooms are recorded on minimap, which
+// may be nil.
+func NewRoomGraph(mapPath string, flipper *ScreenFlipper, minimap *Minimap) *RoomGraph {
+	g := &RoomGraph{
+		mapPath: mapPath,
+		flipper: flipper,
+		minimap: minimap,
+	}
+	if flipper != nil {
+		g.doors = flipper.tilemap.GetDoors()
+	}
+	return g
+}
+
+// Current returns the room the player is in.
+func (g *RoomGraph) Current() RoomKey {
+	return RoomKey{Map: g.mapPath, Room: g.flipper.RoomName()}
+}
+
+// Doors returns the doors of the map.
+func (g *RoomGraph) Doors() []*tilemap.Door {
+	return g.doors
+}
+
+// Update records the current room as visited and takes the player through
+// the door they walked into, if any. Call it every frame after the
+// ScreenFlipper.
+func (g *RoomGraph) Update() {
+	if g.flipper == nil || g.flipper.player == nil || g.flipper.IsFlipping() {
+		return
+	}
+	g.visit()
+
+	pos := g.flipper.player.Position()
+	center := pos.Min.Add(pos.Size().Div(2))
+	var door *tilemap.Door
+	for _, d := range g.doors {
+		if center.In(d.Area) {
+			door = d
+			break
+		}
+	}
+	if door == nil {
+		g.armed = true
+		return
+	}
+	if !g.armed {
+		return
+	}
+	g.armed = false
+
+	t := Travel{From: g.Current(), Door: *door, Offset: pos.Min.Sub(door.Area.Min)}
+	if door.TargetMap == "" || door.TargetMap == g.mapPath {
+		if err := g.Arrive(t); err != nil {
+			log.Printf("room graph: %v", err)
+		}
+		return
+	}
+	if g.OnTravel != nil {
+		g.OnTravel(t)
+	}
+}
+
+// Arrive places the player at the exit door of t in this map, keeping their
+// offset from the door they came in by, and snaps the camera to the room.
+func (g *RoomGraph) Arrive(t Travel) error {
+	exit := g.exitDoor(t)
+	if exit == nil {
+		return fmt.Errorf("no exit door in room %q of %s for door %q", t.Door.TargetRoom, g.mapPath, t.Door.Name)
+	}
+	player := g.flipper.player
+	w, h := player.GetShape().Width(), player.GetShape().Height()
+	x := min(max(exit.Area.Min.X+t.Offset.X, exit.Area.Min.X), max(exit.Area.Max.X-w, exit.Area.Min.X))
+	y := min(max(exit.Area.Min.Y+t.Offset.Y, exit.Area.Min.Y), max(exit.Area.Max.Y-h, exit.Area.Min.Y))
+	player.SetPosition(x, y)
+
+	g.armed = false
+	g.flipper.SnapToCurrentRoom()
+	g.visit()
+	return nil
+}
+
+// exitDoor returns the door the player comes out of at the end of t: the
+// door named by TargetDoor, or else the door in TargetRoom leading back to
+// the room they left.
+func (g *RoomGraph) exitDoor(t Travel) *tilemap.Door {
+	if t.Door.TargetDoor != "" {
+		for _, d := range g.doors {
+			if d.Name == t.Door.TargetDoor {
+				return d
+			}
+		}
+		return nil
+	}
+	room, ok := g.flipper.Room(t.Door.TargetRoom)
+	if !ok {
+		return nil
+	}
+	for _, d := range g.doors {
+		back := d.TargetMap
+		if back == "" {
+			back = g.mapPath
+		}
+		center := d.Area.Min.Add(d.Area.Size().Div(2))
+		if center.In(room) && d.TargetRoom == t.From.Room && back == t.From.Map {
+			return d
+		}
+	}
+	return nil
+}
+
+func (g *RoomGraph) visit() {
+	if g.minimap == nil {
+		return
+	}
+	if k := g.Current(); k.Room != "" {
+		g.minimap.Visit(k)
+	}
+}
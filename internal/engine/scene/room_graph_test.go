@@ -0,0 +1,152 @@
+package scene
+
+import (
+	"image"
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
+	"github.com/boilerplate/ebiten-template/internal/engine/mocks"
+	"github.com/boilerplate/ebiten-template/internal/engine/render/camera"
+	"github.com/boilerplate/ebiten-template/internal/engine/render/tilemap"
+)
+
+// roomsMap returns a map with the given rooms on its "Camera" layer and
+// doors on its "Doors" layer.
+func roomsMap(rooms []*tilemap.Obstacle, doors []*tilemap.Obstacle) *tilemap.Tilemap {
+	return &tilemap.Tilemap{
+		Width: 40, Height: 15, Tilewidth: 16, Tileheight: 16,
+		Tilesets: []*tilemap.Tileset{{Firstgid: 1}},
+		Layers: []*tilemap.Layer{
+			{Name: "Camera", Type: "objectgroup", Visible: true, Objects: rooms},
+			{Name: "Doors", Type: "objectgroup", Visible: true, Objects: doors},
+		},
+	}
+}
+
+func door(name string, x, y float64, props ...tilemap.Property) *tilemap.Obstacle {
+	return &tilemap.Obstacle{Name: name, X: x, Y: y, Width: 32, Height: 48, Properties: props}
+}
+
+func TestRoomGraph_DoorWithinMap(t *testing.T) {
+	config.Set(&config.AppConfig{ScreenWidth: 320, ScreenHeight: 240})
+	cam := camera.NewController(0, 0)
+	cam.DisableSmoothing()
+	player := &mocks.MockActor{Id: "player"}
+	player.SetPosition(100, 150)
+
+	tm := roomsMap(
+		[]*tilemap.Obstacle{
+			{Name: "hall", Width: 320, Height: 240},
+			{Name: "vault", X: 320, Width: 320, Height: 240},
+		},
+		[]*tilemap.Obstacle{
+			door("hall_door", 200, 150, tilemap.Property{Name: "target_room", Value: "vault"}),
+			door("vault_door", 500, 150, tilemap.Property{Name: "target_room", Value: "hall"}),
+		},
+	)
+	sf := NewScreenFlipper(cam, player, tm, &app.AppContext{})
+	sf.SnapToCurrentRoom()
+	minimap := NewMinimap()
+	g := NewRoomGraph("castle.tmj", sf, minimap)
+
+	g.Update()
+	if g.Current() != (RoomKey{Map: "castle.tmj", Room: "hall"}) {
+		t.Fatalf("Current() = %v, want the hall", g.Current())
+	}
+
+	// Walk into the hall door, 4px below its top.
+	player.SetPosition(210, 154)
+	g.Update()
+	if got := player.Position().Min; got != image.Pt(510, 154) {
+		t.Errorf("player at %v, want the same offset in the vault door (510,154)", got)
+	}
+	if g.Current().Room != "vault" {
+		t.Errorf("Current() = %v, want the vault", g.Current())
+	}
+	if x, _ := cam.GetCenter(); x != 480 {
+		t.Errorf("camera center x %v, want snapped to the vault at 480", x)
+	}
+
+	// Standing in the exit door doesn't go back until the player steps out.
+	g.Update()
+	if g.Current().Room != "vault" {
+		t.Error("arriving in a door sent the player straight back")
+	}
+
+	want := []string{"castle.tmj#hall", "castle.tmj#vault"}
+	if got := minimap.VisitedRooms(); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("VisitedRooms() = %v, want %v", got, want)
+	}
+}
+
+func TestRoomGraph_DoorToAnotherMap(t *testing.T) {
+	config.Set(&config.AppConfig{ScreenWidth: 320, ScreenHeight: 240})
+	cam := camera.NewController(0, 0)
+	cam.DisableSmoothing()
+	player := &mocks.MockActor{Id: "player"}
+	player.SetPosition(20, 20)
+
+	hall := roomsMap(
+		[]*tilemap.Obstacle{{Name: "hall", Width: 320, Height: 240}},
+		[]*tilemap.Obstacle{door("down", 200, 150,
+			tilemap.Property{Name: "target_map", Value: "crypt.tmj"},
+			tilemap.Property{Name: "target_room", Value: "stairs"},
+		)},
+	)
+	sf := NewScreenFlipper(cam, player, hall, &app.AppContext{})
+	sf.SnapToCurrentRoom()
+	g := NewRoomGraph("castle.tmj", sf, nil)
+	var travel *Travel
+	g.OnTravel = func(t Travel) { travel = &t }
+	g.Update()
+
+	player.SetPosition(200, 160)
+	g.Update()
+	if travel == nil {
+		t.Fatal("OnTravel not called")
+	}
+	if travel.From != (RoomKey{Map: "castle.tmj", Room: "hall"}) || travel.Offset != image.Pt(0, 10) {
+		t.Errorf("travel = %+v", travel)
+	}
+
+	// The scene loads the crypt and arrives there: the door leading back to
+	// the hall is the exit.
+	crypt := roomsMap(
+		[]*tilemap.Obstacle{
+			{Name: "tomb", Width: 320, Height: 240},
+			{Name: "stairs", X: 320, Width: 320, Height: 240},
+		},
+		[]*tilemap.Obstacle{door("up", 400, 100,
+			tilemap.Property{Name: "target_map", Value: "castle.tmj"},
+			tilemap.Property{Name: "target_room", Value: "hall"},
+		)},
+	)
+	minimap := NewMinimap()
+	next := NewRoomGraph("crypt.tmj", NewScreenFlipper(cam, player, crypt, &app.AppContext{}), minimap)
+	if err := next.Arrive(*travel); err != nil {
+		t.Fatalf("Arrive: %v", err)
+	}
+	if got := player.Position().Min; got != image.Pt(400, 110) {
+		t.Errorf("player at %v, want (400,110)", got)
+	}
+	if !minimap.Visited(RoomKey{Map: "crypt.tmj", Room: "stairs"}) {
+		t.Error("arrival room not visited")
+	}
+
+	travel.Door.TargetRoom = "nowhere"
+	if err := next.Arrive(*travel); err == nil {
+		t.Error("Arrive into a missing room: want an error")
+	}
+}
+
+func TestMinimap_RestoreVisitedRooms(t *testing.T) {
+	m := NewMinimap()
+	m.RestoreVisitedRooms([]string{"castle.tmj#hall", "crypt.tmj#stairs"})
+	if !m.Visited(RoomKey{Map: "crypt.tmj", Room: "stairs"}) || len(m.Rooms()) != 2 {
+		t.Errorf("Rooms() = %v", m.Rooms())
+	}
+	if m.Visit(RoomKey{Map: "castle.tmj", Room: "hall"}) {
+		t.Error("second visit reported as new")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -18,6 +19,7 @@ type BaseScene struct {
 	IsKeysDisabled bool
 
 	scheduledActions []scheduledAction
+	events           *event.Group
 }
 
 type scheduledAction struct {
@@ -62,6 +64,16 @@ func (s *BaseScene) OnStart() {
 
 func (s *BaseScene) OnFinish() {}
 
+// EventGroup returns the scene's subscription group. Subscribe with
+// event.InGroup(s.EventGroup()) and the SceneManager removes the listener
+// when the scene finishes.
+func (s *BaseScene) EventGroup() *event.Group {
+	if s.events == nil {
+		s.events = event.NewGroup()
+	}
+	return s.events
+}
+
 func (s *BaseScene) Exit() {}
 
 func (s *BaseScene) AddBoundaries(boundaries ...body.MovableCollidable) {
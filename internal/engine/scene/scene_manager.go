@@ -7,6 +7,7 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/audio"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/navigation"
 	"github.com/boilerplate/ebiten-template/internal/engine/debug"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -20,6 +21,13 @@ type SceneManager struct {
 	transitioner  navigation.Transition
 }
 
+// EventScoped is implemented by scenes that own an event.Group. SwitchTo
+// clears the group right after OnFinish, so listeners a scene registered do
+// not outlive it.
+type EventScoped interface {
+	EventGroup() *event.Group
+}
+
 func NewSceneManager() *SceneManager {
 	m := &SceneManager{}
 	return m
@@ -56,6 +64,9 @@ func (m *SceneManager) DrawOver(screen *ebiten.Image) {
 func (m *SceneManager) SwitchTo(scene navigation.Scene) {
 	if m.current != nil {
 		m.current.OnFinish()
+		if scoped, ok := m.current.(EventScoped); ok {
+			scoped.EventGroup().Clear()
+		}
 	}
 
 	m.current = scene
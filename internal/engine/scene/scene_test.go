@@ -6,6 +6,7 @@ import (
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/navigation"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
 	"github.com/boilerplate/ebiten-template/internal/engine/mocks"
 	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
@@ -150,6 +151,34 @@ func TestSceneManager_Properties(t *testing.T) {
 	}
 }
 
+type eventScopedScene struct {
+	mocks.MockScene
+	group *event.Group
+}
+
+func (s *eventScopedScene) EventGroup() *event.Group { return s.group }
+
+func TestSceneManagerSwitchToClearsEventGroup(t *testing.T) {
+	em := event.NewManager()
+	manager := NewSceneManager()
+
+	first := &eventScopedScene{group: event.NewGroup()}
+	manager.SwitchTo(first)
+
+	count := 0
+	em.Subscribe("X", func(e event.Event) { count++ }, event.InGroup(first.group))
+
+	manager.SwitchTo(&mocks.MockScene{})
+	em.Publish(event.GenericEvent{EventType: "X"})
+
+	if first.FinishCount != 1 {
+		t.Fatalf("expected OnFinish to be called, got %d", first.FinishCount)
+	}
+	if count != 0 {
+		t.Fatalf("expected scene listener removed after switch, got %d calls", count)
+	}
+}
+
 func TestSceneManager_NavigateBack_NoHistory(t *testing.T) {
 	manager := NewSceneManager()
 	// Should not panic
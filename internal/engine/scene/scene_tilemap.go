@@ -10,15 +10,18 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors/enemies"
+	actorevents "github.com/boilerplate/ebiten-template/internal/engine/entity/actors/events"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors/npcs"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/items"
 	"github.com/boilerplate/ebiten-template/internal/engine/render/camera"
 	"github.com/boilerplate/ebiten-template/internal/engine/render/tilemap"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
 )
 
 type TilemapScene struct {
 	BaseScene
 	tilemap      *tilemap.Tilemap
+	mapPath      string
 	cam          *camera.Controller
 	cameraConfig CameraConfig
 }
@@ -33,7 +36,12 @@ func NewTilemapScene(ctx *app.AppContext) *TilemapScene {
 
 func (s *TilemapScene) SetCameraConfig(config CameraConfig) {
 	s.cameraConfig = config
-	s.cam.SetFollowing(config.Mode == CameraModeFollow)
+	s.cam.SetFollowing(config.Mode == CameraModeFollow || config.Mode == CameraModeMultiTarget)
+	s.cam.SetLookAhead(config.LookAhead)
+	s.cam.SetMultiTarget(config.MultiTarget)
+	if config.Mode != CameraModeMultiTarget {
+		s.cam.SetTargets()
+	}
 }
 
 func (s *TilemapScene) Update() error {
@@ -59,22 +67,68 @@ func (s *TilemapScene) OnStart() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	s.tilemap = tm
+	s.setTilemap(phase.TilemapPath, tm)
 
 	// Init space
 	s.PhysicsSpace().SetTilemapDimensionsProvider(s)
 }
 
+// LoadMap replaces the scene's tilemap with the map at path, for phases made
+// of several maps linked by doors. Like OnStart, it empties the space and the
+// actor manager: the caller adds the player back and populates the new map.
+func (s *TilemapScene) LoadMap(path string) error {
+	tm, err := tilemap.LoadTilemap(s.AppContext().Assets, path)
+	if err != nil {
+		return fmt.Errorf("loading map %s: %w", path, err)
+	}
+	s.BaseScene.OnStart()
+	s.setTilemap(path, tm)
+	return nil
+}
+
+func (s *TilemapScene) setTilemap(path string, tm *tilemap.Tilemap) {
+	s.tilemap = tm
+	s.mapPath = path
+	s.cam.SetZones(cameraZones(tm))
+}
+
+// MapPath returns the path of the map the scene shows.
+func (s *TilemapScene) MapPath() string {
+	return s.mapPath
+}
+
+// cameraZones converts the map's camera zones for the camera controller.
+func cameraZones(tm *tilemap.Tilemap) []camera.Zone {
+	var zones []camera.Zone
+	for _, z := range tm.GetCameraZones() {
+		zone := camera.Zone{
+			Area:        z.Area,
+			Zoom:        z.Zoom,
+			OffsetX:     z.OffsetX,
+			OffsetY:     z.OffsetY,
+			BlendFrames: timing.FromDuration(z.Blend),
+		}
+		if z.Clamp {
+			area := z.Area
+			zone.Bounds = &area
+		}
+		zones = append(zones, zone)
+	}
+	return zones
+}
+
 func (s *TilemapScene) GetTilemapWidth() int {
 	if s.tilemap != nil && len(s.tilemap.Layers) > 0 {
-		return s.tilemap.Layers[0].Width * s.tilemap.Tileheight
+		w, _ := s.tilemap.PixelSize()
+		return w
 	}
 	return config.Get().ScreenWidth
 }
 
 func (s *TilemapScene) GetTilemapHeight() int {
 	if s.tilemap != nil && len(s.tilemap.Layers) > 0 {
-		return s.tilemap.Layers[0].Height * s.tilemap.Tileheight
+		_, h := s.tilemap.PixelSize()
+		return h
 	}
 	return config.Get().ScreenHeight
 }
@@ -104,6 +158,18 @@ func (s *TilemapScene) SetPlayerStartPosition(p actors.ActorEntity) {
 	}
 }
 
+// EnemyIDs returns the IDs of the map's enemies carrying tag, or of all of
+// them when tag is empty. Kill-all goals track them.
+func (s *TilemapScene) EnemyIDs(tag string) []string {
+	var ids []string
+	for _, e := range s.tilemap.GetEnemiesPositionID() {
+		if tag == "" || e.HasTag(tag) {
+			ids = append(ids, e.ID)
+		}
+	}
+	return ids
+}
+
 func InitEnemies[T actors.ActorEntity](s *TilemapScene, factory *enemies.EnemyFactory[T]) error {
 	enemiesPos := s.Tilemap().GetEnemiesPositionID()
 
@@ -120,6 +186,9 @@ func InitEnemies[T actors.ActorEntity](s *TilemapScene, factory *enemies.EnemyFa
 		if s.AppContext().ActorManager != nil {
 			s.AppContext().ActorManager.Register(enemy)
 		}
+		if s.AppContext().EventManager != nil {
+			s.AppContext().EventManager.Publish(&actorevents.EnemySpawnedEvent{ID: e.ID, Tags: e.Tags})
+		}
 	}
 
 	return nil
@@ -156,6 +225,9 @@ func InitItems[T items.Item](s *TilemapScene, factory *items.ItemFactory[T]) err
 		}
 
 		item.SetID(fmt.Sprintf("ITEM_%v", i.ID))
+		if typed, ok := item.(items.Typed); ok {
+			typed.SetItemType(items.ItemType(i.ItemType))
+		}
 		s.PhysicsSpace().AddBody(item)
 	}
 
@@ -40,6 +40,11 @@ func TestTilemapScene_Basics(t *testing.T) {
 		t.Error("SetCameraConfig(Fixed) failed to unset camera following state")
 	}
 
+	s.SetCameraConfig(CameraConfig{Mode: CameraModeMultiTarget})
+	if !s.Camera().IsFollowing() {
+		t.Error("SetCameraConfig(MultiTarget) failed to set camera following state")
+	}
+
 	// Test Tilemap Width/Height defaults (no tilemap loaded)
 	if s.GetTilemapWidth() != 320 {
 		t.Errorf("expected default width 320; got %d", s.GetTilemapWidth())
@@ -1,6 +1,7 @@
 package scene
 
 import (
+	"fmt"
 	"image"
 	"log"
 	"math"
@@ -49,6 +50,7 @@ type ScreenFlipper struct {
 
 	// Room Management
 	rooms       []image.Rectangle
+	roomNames   []string
 	currentRoom *image.Rectangle
 
 	context *app.AppContext
@@ -90,9 +92,8 @@ func (sf *ScreenFlipper) SnapToCurrentRoom() {
 		return
 	}
 
-	if sf.currentRoom == nil {
-		sf.updateCurrentRoom()
-	}
+	// The player may have been moved to another room, e.g. through a door.
+	sf.updateCurrentRoom()
 	if sf.currentRoom == nil {
 		return
 	}
@@ -190,12 +191,9 @@ func (sf *ScreenFlipper) ensureRooms() {
 	}
 
 	// 1. Try to load from "Camera" layer
-	layer, found := sf.tilemap.FindLayerByName("Camera")
-	if found {
-		for _, obj := range layer.Objects {
-			r := image.Rect(int(obj.X), int(obj.Y), int(obj.X+obj.Width), int(obj.Y+obj.Height))
-			sf.rooms = append(sf.rooms, r)
-		}
+	for _, room := range sf.tilemap.GetRooms() {
+		sf.rooms = append(sf.rooms, room.Area)
+		sf.roomNames = append(sf.roomNames, room.Name)
 	}
 
 	// 2. Fallback: Generate Grid
@@ -214,11 +212,38 @@ func (sf *ScreenFlipper) ensureRooms() {
 					(y+1)*int(sf.screenHeight),
 				)
 				sf.rooms = append(sf.rooms, r)
+				sf.roomNames = append(sf.roomNames, fmt.Sprintf("%d,%d", x, y))
 			}
 		}
 	}
 }
 
+// RoomName returns the name of the room the camera is in: the object name on
+// the "Camera" layer, or "column,row" for grid rooms. It is empty before the
+// first room is found.
+func (sf *ScreenFlipper) RoomName() string {
+	for i := range sf.rooms {
+		if &sf.rooms[i] == sf.currentRoom {
+			return sf.roomNames[i]
+		}
+	}
+	return ""
+}
+
+// Room returns the area of the room with the given name.
+func (sf *ScreenFlipper) Room(name string) (image.Rectangle, bool) {
+	if sf.tilemap == nil {
+		return image.Rectangle{}, false
+	}
+	sf.ensureRooms()
+	for i, n := range sf.roomNames {
+		if n == name {
+			return sf.rooms[i], true
+		}
+	}
+	return image.Rectangle{}, false
+}
+
 func (sf *ScreenFlipper) triggerFlip(dx, dy int) {
 	// Calculate Player Target (pushed into next room)
 	px, py := sf.player.GetPositionMin()
@@ -1,6 +1,8 @@
 package sequences
 
 import (
+	"fmt"
+
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/dialogue"
 	"github.com/boilerplate/ebiten-template/internal/engine/event"
@@ -80,6 +82,138 @@ func (c *DialogueCommand) Update() bool {
 	return !c.dialogueManager.IsSpeaking()
 }
 
+// ChoiceOption is one answer of a ChoiceCommand.
+type ChoiceOption struct {
+	// TextKey is an i18n key; when set it wins over Text.
+	TextKey string `json:"text_key,omitempty"`
+	Text    string `json:"text,omitempty"`
+	// Value is stored in the choice variable when the option is picked. It
+	// defaults to the option's index in the JSON list.
+	Value any `json:"value,omitempty"`
+	// Condition hides the option unless it holds when the choice opens.
+	Condition *ConditionData `json:"condition,omitempty"`
+}
+
+// ChoiceCommand shows a prompt with player-selectable options in the speech
+// box and waits for an answer. The picked option's value is stored in the
+// sequence variable Var and, when EventType is set, published as a
+// GenericEvent with "index" and "value" in its payload.
+type ChoiceCommand struct {
+	PromptKey string
+	Prompt    string
+	Options   []ChoiceOption
+	Var       string
+	EventType string
+	Position  string
+	Speed     int
+	SpeechID  string
+
+	conditions      []condition
+	shown           []int
+	vars            *Variables
+	appContext      *app.AppContext
+	dialogueManager dialogue.Manager
+}
+
+func (c *ChoiceCommand) setVariables(vars *Variables) { c.vars = vars }
+
+// compile validates option conditions. The loader calls it so bad
+// conditions fail at load time; Init compiles lazily for commands built in Go.
+func (c *ChoiceCommand) compile() error {
+	c.conditions = make([]condition, len(c.Options))
+	for i, opt := range c.Options {
+		if !isScalar(opt.Value) {
+			c.conditions = nil
+			return fmt.Errorf("options[%d]: value must be a number, string or bool", i)
+		}
+		if opt.Condition == nil {
+			continue
+		}
+		cond, err := opt.Condition.compile()
+		if err != nil {
+			c.conditions = nil
+			return fmt.Errorf("options[%d]: %w", i, err)
+		}
+		c.conditions[i] = cond
+	}
+	return nil
+}
+
+func (c *ChoiceCommand) Init(appContext any) {
+	ctx := appContext.(*app.AppContext)
+	c.appContext = ctx
+	c.dialogueManager = ctx.DialogueManager
+	if c.conditions == nil && c.compile() != nil {
+		c.conditions = make([]condition, len(c.Options))
+	}
+	if c.vars == nil {
+		c.vars = NewVariables()
+	}
+
+	c.shown = c.shown[:0]
+	var labels []string
+	for i, opt := range c.Options {
+		if cond := c.conditions[i]; cond != nil && !cond(ctx, c.vars) {
+			continue
+		}
+		c.shown = append(c.shown, i)
+		labels = append(labels, translate(ctx, opt.TextKey, opt.Text))
+	}
+	if len(c.shown) == 0 || c.dialogueManager == nil {
+		return
+	}
+
+	speechID := c.SpeechID
+	if speechID == "" {
+		speechID = dialogue.BubbleSpeechID
+	}
+	c.dialogueManager.SetActiveSpeech(speechID)
+	c.dialogueManager.ClearSpeechAudioQueue()
+	c.dialogueManager.ShowChoice(translate(ctx, c.PromptKey, c.Prompt), labels, c.Position, c.Speed)
+}
+
+func (c *ChoiceCommand) Update() bool {
+	if len(c.shown) == 0 || c.dialogueManager == nil {
+		return true
+	}
+	if c.dialogueManager.IsSpeaking() {
+		return false
+	}
+	picked, ok := c.dialogueManager.ChoiceResult()
+	if !ok || picked < 0 || picked >= len(c.shown) {
+		// The dialogue was stopped without an answer.
+		return true
+	}
+
+	index := c.shown[picked]
+	value := c.Options[index].Value
+	if value == nil {
+		value = index
+	}
+	if c.Var != "" {
+		c.vars.Set(c.Var, value)
+	}
+	if c.EventType != "" && c.appContext.EventManager != nil {
+		c.appContext.EventManager.Publish(event.GenericEvent{
+			EventType: c.EventType,
+			Payload:   map[string]interface{}{"index": index, "value": value},
+		})
+	}
+	return true
+}
+
+// translate returns the i18n text for key, or fallback when there is no key
+// or no i18n manager.
+func translate(ctx *app.AppContext, key, fallback string) string {
+	if key == "" || ctx.I18n == nil {
+		if fallback == "" {
+			return key
+		}
+		return fallback
+	}
+	return ctx.I18n.T(key)
+}
+
 type DialogueResetCommand struct {
 	dialogueManager dialogue.Manager
 }
@@ -1,6 +1,8 @@
 package sequences
 
 import (
+	"image"
+
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
@@ -402,3 +404,67 @@ func (c *CameraShakeCommand) Init(appContext any) {
 func (c *CameraShakeCommand) Update() bool {
 	return true
 }
+
+// sceneCamera returns the camera of the current scene, or nil.
+func sceneCamera(appContext any) *camera.Controller {
+	ctx := appContext.(*app.AppContext)
+	switch s := ctx.SceneManager.CurrentScene().(type) {
+	case *scene.TilemapScene:
+		return s.Camera()
+	case interface{ Camera() *camera.Controller }:
+		return s.Camera()
+	}
+	return nil
+}
+
+// CameraLockCommand locks the camera to an area, beat 'em up style, until a
+// camera_unlock. Without a size it locks the camera where it is.
+type CameraLockCommand struct {
+	X, Y          float64
+	Width, Height float64
+}
+
+func (c *CameraLockCommand) Init(appContext any) {
+	cam := sceneCamera(appContext)
+	if cam == nil {
+		return
+	}
+	if c.Width <= 0 || c.Height <= 0 {
+		cam.Lock(cam.VisibleRect())
+		return
+	}
+	cam.Lock(image.Rect(int(c.X), int(c.Y), int(c.X+c.Width), int(c.Y+c.Height)))
+}
+
+func (c *CameraLockCommand) Update() bool {
+	return true
+}
+
+// CameraUnlockCommand releases a camera lock.
+type CameraUnlockCommand struct{}
+
+func (c *CameraUnlockCommand) Init(appContext any) {
+	if cam := sceneCamera(appContext); cam != nil {
+		cam.Unlock()
+	}
+}
+
+func (c *CameraUnlockCommand) Update() bool {
+	return true
+}
+
+// CameraAutoScrollCommand scrolls the camera at a constant speed, in pixels
+// per frame. Zero speeds stop the scroll.
+type CameraAutoScrollCommand struct {
+	ScrollX, ScrollY float64
+}
+
+func (c *CameraAutoScrollCommand) Init(appContext any) {
+	if cam := sceneCamera(appContext); cam != nil {
+		cam.SetAutoScroll(c.ScrollX, c.ScrollY)
+	}
+}
+
+func (c *CameraAutoScrollCommand) Update() bool {
+	return true
+}
@@ -1,6 +1,7 @@
 package sequences
 
 import (
+	"image"
 	"testing"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
@@ -461,3 +462,44 @@ func TestCameraResetCommand_Update_DurationPath(t *testing.T) {
 		t.Errorf("expected zoom 1.0, got %f", cam.Kamera().ZoomFactor)
 	}
 }
+
+func TestCameraLockUnlockAndAutoScrollCommands(t *testing.T) {
+	appContext := &app.AppContext{}
+	sceneManager := scene.NewSceneManager()
+	sceneManager.SetAppContext(appContext)
+	appContext.SceneManager = sceneManager
+
+	cam := camera.NewController(0, 0)
+	mockScene := &mockSceneWithCamera{cam: cam}
+	mockScene.SetAppContext(appContext)
+	appContext.SceneManager.SwitchTo(mockScene)
+
+	lock := &CameraLockCommand{X: 320, Y: 0, Width: 320, Height: 240}
+	lock.Init(appContext)
+	if !lock.Update() {
+		t.Error("CameraLockCommand.Update() should return true (instant command)")
+	}
+	if area, ok := cam.Locked(); !ok || area != image.Rect(320, 0, 640, 240) {
+		t.Errorf("Locked() = %v, %v, want (320,0)-(640,240)", area, ok)
+	}
+
+	(&CameraUnlockCommand{}).Init(appContext)
+	if _, ok := cam.Locked(); ok {
+		t.Error("camera still locked after camera_unlock")
+	}
+
+	// Without a size the lock holds the current view.
+	(&CameraLockCommand{}).Init(appContext)
+	if area, ok := cam.Locked(); !ok || area != cam.VisibleRect() {
+		t.Errorf("Locked() = %v, %v, want the visible rect %v", area, ok, cam.VisibleRect())
+	}
+
+	(&CameraAutoScrollCommand{ScrollX: 1}).Init(appContext)
+	if !cam.AutoScrolling() {
+		t.Error("camera_auto_scroll did not start scrolling")
+	}
+	(&CameraAutoScrollCommand{}).Init(appContext)
+	if cam.AutoScrolling() {
+		t.Error("zero camera_auto_scroll did not stop scrolling")
+	}
+}
@@ -0,0 +1,176 @@
+package sequences
+
+import (
+	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/sequences"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
+)
+
+// variablesUser is implemented by commands that read or write sequence
+// variables. The player hands them its Variables before Init.
+type variablesUser interface {
+	setVariables(vars *Variables)
+}
+
+// flowCommand is a compiled control-flow step ("if"/"else" and "goto"). The
+// player resolves it inline while advancing, so branching costs no frames and
+// never reaches Init/Update.
+type flowCommand interface {
+	sequences.Command
+	// next returns the index of the command to run after the one at pc.
+	next(pc int, ctx *app.AppContext, vars *Variables) int
+}
+
+// branchCommand is the head of an "if" block: it falls through into the then
+// branch when the condition holds and jumps to the else branch otherwise.
+type branchCommand struct {
+	cond      condition
+	elseIndex int
+}
+
+func (c *branchCommand) Init(appContext any) {}
+
+func (c *branchCommand) Update() bool { return true }
+
+func (c *branchCommand) next(pc int, ctx *app.AppContext, vars *Variables) int {
+	if c.cond(ctx, vars) {
+		return pc + 1
+	}
+	return c.elseIndex
+}
+
+// jumpCommand continues at target. It backs "goto" and the jump over an else
+// branch at the end of a then branch.
+type jumpCommand struct {
+	label  string
+	target int
+}
+
+func (c *jumpCommand) Init(appContext any) {}
+
+func (c *jumpCommand) Update() bool { return true }
+
+func (c *jumpCommand) next(int, *app.AppContext, *Variables) int {
+	return c.target
+}
+
+// SetVarCommand stores Value in the sequence variable Name.
+type SetVarCommand struct {
+	Name  string
+	Value any
+
+	vars *Variables
+}
+
+func (c *SetVarCommand) setVariables(vars *Variables) { c.vars = vars }
+
+func (c *SetVarCommand) Init(appContext any) {
+	if c.vars != nil {
+		c.vars.Set(c.Name, c.Value)
+	}
+}
+
+func (c *SetVarCommand) Update() bool { return true }
+
+// IncVarCommand adds By to the numeric sequence variable Name.
+type IncVarCommand struct {
+	Name string
+	By   float64
+
+	vars *Variables
+}
+
+func (c *IncVarCommand) setVariables(vars *Variables) { c.vars = vars }
+
+func (c *IncVarCommand) Init(appContext any) {
+	if c.vars != nil {
+		c.vars.Add(c.Name, c.By)
+	}
+}
+
+func (c *IncVarCommand) Update() bool { return true }
+
+// SetFlagCommand sets or clears the persistent flag Name.
+type SetFlagCommand struct {
+	Name  string
+	Value bool
+
+	vars *Variables
+}
+
+func (c *SetFlagCommand) setVariables(vars *Variables) { c.vars = vars }
+
+func (c *SetFlagCommand) Init(appContext any) {
+	if c.vars != nil {
+		c.vars.SetFlag(c.Name, c.Value)
+	}
+}
+
+func (c *SetFlagCommand) Update() bool { return true }
+
+// WaitForEventCommand blocks until an event of EventType is published. It
+// finishes immediately when there is no event manager to wait on.
+type WaitForEventCommand struct {
+	EventType string
+
+	received    bool
+	unsubscribe func()
+}
+
+func (c *WaitForEventCommand) Init(appContext any) {
+	c.received = false
+	if c.unsubscribe != nil {
+		c.unsubscribe()
+		c.unsubscribe = nil
+	}
+	em := appContext.(*app.AppContext).EventManager
+	if em == nil {
+		c.received = true
+		return
+	}
+	c.unsubscribe = em.Subscribe(c.EventType, func(event.Event) {
+		c.received = true
+	}, event.Once())
+}
+
+func (c *WaitForEventCommand) Update() bool {
+	return c.received
+}
+
+// ParallelCommand runs every child command at once and finishes when all of
+// them have. Child block_sequence flags are ignored.
+type ParallelCommand struct {
+	Commands []sequences.Command
+
+	done []bool
+}
+
+func (c *ParallelCommand) setVariables(vars *Variables) {
+	for _, cmd := range c.Commands {
+		if user, ok := cmd.(variablesUser); ok {
+			user.setVariables(vars)
+		}
+	}
+}
+
+func (c *ParallelCommand) Init(appContext any) {
+	c.done = make([]bool, len(c.Commands))
+	for _, cmd := range c.Commands {
+		cmd.Init(appContext)
+	}
+}
+
+func (c *ParallelCommand) Update() bool {
+	finished := true
+	for i, cmd := range c.Commands {
+		if c.done[i] {
+			continue
+		}
+		if cmd.Update() {
+			c.done[i] = true
+			continue
+		}
+		finished = false
+	}
+	return finished
+}
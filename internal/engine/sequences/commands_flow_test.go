@@ -0,0 +1,227 @@
+package sequences
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
+)
+
+func loadTestSequence(t *testing.T, body string) *Sequence {
+	t.Helper()
+	fsys := fstest.MapFS{"seq.json": {Data: []byte(body)}}
+	seq, err := NewSequenceFromFS(fsys, "seq.json")
+	if err != nil {
+		t.Fatalf("NewSequenceFromFS: %v", err)
+	}
+	return seq
+}
+
+// runToEnd plays seq and updates the player until it finishes.
+func runToEnd(t *testing.T, player *SequencePlayer, seq *Sequence) {
+	t.Helper()
+	player.Play(seq)
+	for i := 0; player.hasActiveCommands; i++ {
+		if i > 100 {
+			t.Fatalf("sequence did not finish")
+		}
+		player.Update()
+	}
+}
+
+func TestIfElseBranchesOnVariable(t *testing.T) {
+	tests := []struct {
+		name  string
+		coins int
+		want  string
+	}{
+		{"then", 3, "rich"},
+		{"else", 1, "poor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq := loadTestSequence(t, `{"commands": [
+				{"command": "set_var", "name": "coins", "value": `+strconv.Itoa(tt.coins)+`},
+				{"command": "if", "condition": {"var": "coins", "op": ">=", "value": 3},
+					"then": [{"command": "set_var", "name": "result", "value": "rich"}],
+					"else": [{"command": "set_var", "name": "result", "value": "poor"}]},
+				{"command": "inc_var", "name": "after"}
+			]}`)
+			player := NewSequencePlayer(&app.AppContext{})
+			runToEnd(t, player, seq)
+
+			if got, _ := player.Variables().Get("result"); got != tt.want {
+				t.Errorf("result = %v, want %q", got, tt.want)
+			}
+			if got, _ := player.Variables().Get("after"); got != 1.0 {
+				t.Errorf("expected command after the if block to run once, after = %v", got)
+			}
+		})
+	}
+}
+
+func TestGotoLoop(t *testing.T) {
+	seq := loadTestSequence(t, `{"commands": [
+		{"command": "label", "label": "top"},
+		{"command": "inc_var", "name": "n"},
+		{"command": "delay", "frames": 1},
+		{"command": "if", "condition": {"var": "n", "op": "<", "value": 3},
+			"then": [{"command": "goto", "label": "top"}]}
+	]}`)
+	player := NewSequencePlayer(&app.AppContext{})
+	runToEnd(t, player, seq)
+
+	if got, _ := player.Variables().Get("n"); got != 3.0 {
+		t.Errorf("n = %v, want 3", got)
+	}
+}
+
+func TestGotoLoopWithoutBlockingCommandEnds(t *testing.T) {
+	seq := loadTestSequence(t, `{"commands": [
+		{"command": "label", "label": "top"},
+		{"command": "goto", "label": "top"}
+	]}`)
+	player := NewSequencePlayer(&app.AppContext{})
+	player.Play(seq)
+	player.Update()
+
+	if player.IsPlaying() || player.hasActiveCommands {
+		t.Errorf("expected runaway goto loop to end the sequence")
+	}
+}
+
+func TestGotoLoopOverNonBlockingCommandEnds(t *testing.T) {
+	seq := loadTestSequence(t, `{"commands": [
+		{"command": "label", "label": "top"},
+		{"command": "delay", "frames": 60, "block_sequence": false},
+		{"command": "goto", "label": "top"}
+	]}`)
+	player := NewSequencePlayer(&app.AppContext{})
+	player.Play(seq)
+
+	if player.currentCommandIndex < len(seq.Commands()) {
+		t.Errorf("expected runaway loop to leave the command list, at index %d", player.currentCommandIndex)
+	}
+	if n := len(player.backgroundCommands); n != 1 {
+		t.Errorf("background commands = %d, want the looped delay queued once", n)
+	}
+}
+
+func TestFlagsPersistAcrossSequences(t *testing.T) {
+	player := NewSequencePlayer(&app.AppContext{})
+	runToEnd(t, player, loadTestSequence(t, `{"commands": [
+		{"command": "set_var", "name": "local", "value": true},
+		{"command": "set_flag", "name": "met_guard"}
+	]}`))
+
+	second := loadTestSequence(t, `{"commands": [
+		{"command": "if", "condition": {"all": [{"flag": "met_guard"}, {"not": {"var": "local"}}]},
+			"then": [{"command": "set_var", "name": "greeted", "value": true}]}
+	]}`)
+	second.Path = "second.json"
+	runToEnd(t, player, second)
+
+	if got, _ := player.Variables().Get("greeted"); got != true {
+		t.Errorf("expected flag to survive and vars to reset between sequences, greeted = %v", got)
+	}
+	if flags := player.Flags(); len(flags) != 1 || flags[0] != "met_guard" {
+		t.Errorf("Flags() = %v, want [met_guard]", flags)
+	}
+}
+
+func TestWaitForEventBlocksUntilPublished(t *testing.T) {
+	em := event.NewManager()
+	seq := loadTestSequence(t, `{"commands": [
+		{"command": "wait_for_event", "event_type": "door_opened"},
+		{"command": "set_var", "name": "done", "value": true}
+	]}`)
+	player := NewSequencePlayer(&app.AppContext{EventManager: em})
+	player.Play(seq)
+	player.Update()
+	player.Update()
+	if !player.IsPlaying() {
+		t.Fatalf("expected sequence to wait for the event")
+	}
+
+	em.Publish(event.GenericEvent{EventType: "door_opened"})
+	player.Update()
+	if got, _ := player.Variables().Get("done"); got != true {
+		t.Errorf("expected sequence to continue after the event")
+	}
+}
+
+func TestParallelFinishesWithSlowestChild(t *testing.T) {
+	seq := loadTestSequence(t, `{"commands": [
+		{"command": "parallel", "commands": [
+			{"command": "delay", "frames": 2},
+			{"command": "delay", "frames": 4},
+			{"command": "inc_var", "name": "n"}
+		]}
+	]}`)
+	player := NewSequencePlayer(&app.AppContext{})
+	player.Play(seq)
+	for i := 0; i < 3; i++ {
+		player.Update()
+		if !player.IsPlaying() {
+			t.Fatalf("parallel finished after %d frames, want 4", i+1)
+		}
+	}
+	player.Update()
+	if player.IsPlaying() {
+		t.Errorf("expected parallel to finish after 4 frames")
+	}
+	if got, _ := player.Variables().Get("n"); got != 1.0 {
+		t.Errorf("expected child inc_var to see the player's variables, n = %v", got)
+	}
+}
+
+func TestLoadErrorsNameFileAndIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			"unknown command",
+			`{"commands": [{"command": "delay"}, {"command": "dance"}]}`,
+			`seq.json: commands[1]: unknown command "dance"`,
+		},
+		{
+			"unknown nested command",
+			`{"commands": [{"command": "if", "condition": {"flag": "f"}, "then": [{"command": "nope"}]}]}`,
+			`seq.json: commands[0].then[0]: unknown command "nope"`,
+		},
+		{
+			"unknown label",
+			`{"commands": [{"command": "goto", "label": "missing"}]}`,
+			`seq.json: commands[0]: goto unknown label "missing"`,
+		},
+		{
+			"bad condition",
+			`{"commands": [{"command": "if", "condition": {"var": "x", "op": "~"}}]}`,
+			`seq.json: commands[0]: unknown op "~"`,
+		},
+		{
+			"flow inside parallel",
+			`{"commands": [{"command": "parallel", "commands": [{"command": "goto", "label": "x"}]}]}`,
+			`seq.json: commands[0].commands[0]: goto is not allowed inside parallel`,
+		},
+		{
+			"unknown audio bus",
+			`{"commands": [{"command": "set_bus_volume", "bus": "drums", "volume": 1}]}`,
+			`seq.json: commands[0]: unknown audio bus "drums"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"seq.json": {Data: []byte(tt.body)}}
+			_, err := NewSequenceFromFS(fsys, "seq.json")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package sequences
 
 import (
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	"github.com/boilerplate/ebiten-template/internal/engine/audio"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
 )
 
@@ -33,6 +34,54 @@ func (c *PlayMusicCommand) Update() bool {
 	return true // instant command
 }
 
+// CrossfadeMusicCommand fades the current track out while Path fades in over
+// Duration frames. The fade runs on wall-clock time, so slow-mo and
+// fast-forward do not stretch it.
+type CrossfadeMusicCommand struct {
+	Path     string
+	Duration int
+	Loop     bool
+}
+
+func (c *CrossfadeMusicCommand) Init(appContext any) {
+	am := appContext.(*app.AppContext).AudioManager
+	if am == nil {
+		return
+	}
+	am.CrossfadeMusic(c.Path, c.Loop, timing.ToDuration(c.Duration))
+}
+
+func (c *CrossfadeMusicCommand) Update() bool {
+	return true // instant command; the fade continues in the audio manager
+}
+
+// SetBusVolumeCommand changes an audio bus volume and/or mute, e.g. to silence
+// sfx during a cutscene. Unset fields leave the bus as it is. The change is a
+// scripted layer over the player's audio settings: it is never saved, and the
+// SequencePlayer clears it when the sequence ends.
+type SetBusVolumeCommand struct {
+	Bus    audio.Bus
+	Volume *float64
+	Muted  *bool
+}
+
+func (c *SetBusVolumeCommand) Init(appContext any) {
+	am := appContext.(*app.AppContext).AudioManager
+	if am == nil {
+		return
+	}
+	if c.Volume != nil {
+		am.Mixer().SetScriptedVolume(c.Bus, *c.Volume)
+	}
+	if c.Muted != nil {
+		am.Mixer().SetScriptedMuted(c.Bus, *c.Muted)
+	}
+}
+
+func (c *SetBusVolumeCommand) Update() bool {
+	return true // instant command
+}
+
 // PauseAllMusicCommand pauses all currently playing music.
 // Useful for dramatic moments or transitions.
 type PauseAllMusicCommand struct{}
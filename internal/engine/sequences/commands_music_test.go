@@ -1,9 +1,12 @@
 package sequences
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/boilerplate/ebiten-template/internal/engine/audio"
 	"github.com/boilerplate/ebiten-template/internal/engine/mocks"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
 )
 
 func TestPlayMusicCommand_Init_WithMockAudioManager(t *testing.T) {
@@ -94,3 +97,63 @@ func TestFadeOutAllMusicCommand_Update(t *testing.T) {
 		t.Error("FadeOutAllMusicCommand.Update() should return true (instant command)")
 	}
 }
+
+func TestSetBusVolumeCommand(t *testing.T) {
+	ctx := setupTestAppContext()
+	am := mocks.NewMockAudioManager()
+	ctx.AudioManager = am
+	mixer := am.Mixer()
+	mixer.SetVolume(audio.BusSFX, 0.8)
+	saved := mixer.Settings()
+	saves := 0
+	mixer.SetOnChange(func() { saves++ })
+
+	seq := loadTestSequence(t, `{"commands": [
+		{"command": "set_bus_volume", "bus": "sfx", "volume": 0.5},
+		{"command": "set_bus_volume", "bus": "music", "muted": true},
+		{"command": "delay", "frames": 5}
+	]}`)
+	player := NewSequencePlayer(ctx)
+	player.Play(seq)
+	player.Update()
+	player.Update()
+
+	if g := mixer.Gain(audio.BusSFX); g != 0.4 {
+		t.Errorf("sfx gain = %v, want 0.4", g)
+	}
+	if g := mixer.Gain(audio.BusMusic); g != 0 {
+		t.Errorf("music gain = %v, want muted", g)
+	}
+	if !reflect.DeepEqual(mixer.Settings(), saved) || saves != 0 {
+		t.Errorf("settings changed to %v with %d saves, want %v unsaved", mixer.Settings(), saves, saved)
+	}
+
+	runToEnd(t, player, seq)
+	if g := mixer.Gain(audio.BusSFX); g != 0.8 {
+		t.Errorf("sfx gain after the sequence = %v, want 0.8", g)
+	}
+	if g := mixer.Gain(audio.BusMusic); g != 1 {
+		t.Errorf("music gain after the sequence = %v, want 1", g)
+	}
+	if !reflect.DeepEqual(mixer.Settings(), saved) || saves != 0 {
+		t.Errorf("settings changed to %v with %d saves, want %v unsaved", mixer.Settings(), saves, saved)
+	}
+}
+
+func TestCrossfadeMusicCommand(t *testing.T) {
+	ctx := setupTestAppContext()
+	am := mocks.NewMockAudioManager()
+	ctx.AudioManager = am
+
+	seq := loadTestSequence(t, `{"commands": [
+		{"command": "crossfade_music", "path": "boss.ogg", "duration": 60, "loop": true}
+	]}`)
+	runToEnd(t, NewSequencePlayer(ctx), seq)
+
+	if d, ok := am.Crossfades["boss.ogg"]; !ok || d != timing.ToDuration(60) {
+		t.Errorf("crossfade = %v (called %v), want %v", d, ok, timing.ToDuration(60))
+	}
+	if !am.LoopSettings["boss.ogg"] {
+		t.Error("expected loop=true for boss.ogg")
+	}
+}
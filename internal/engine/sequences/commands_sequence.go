@@ -14,8 +14,11 @@ type CallSequenceCommand struct {
 	nestedSequence *Sequence
 	sequencePlayer *SequencePlayer
 	isComplete     bool
+	vars           *Variables
 }
 
+func (c *CallSequenceCommand) setVariables(vars *Variables) { c.vars = vars }
+
 func (c *CallSequenceCommand) Init(appContext any) {
 	ctx := appContext.(*app.AppContext)
 
@@ -45,6 +48,9 @@ func (c *CallSequenceCommand) Init(appContext any) {
 
 	// Mark that this player is blocked by parent sequence
 	c.sequencePlayer.blockedByParent = true
+	if c.vars != nil {
+		c.sequencePlayer.vars = c.vars
+	}
 
 	c.sequencePlayer.Play(c.nestedSequence)
 	c.isComplete = false
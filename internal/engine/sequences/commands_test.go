@@ -238,3 +238,58 @@ func TestDelayCommand_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestChoiceCommand_StoresValueAndPublishes(t *testing.T) {
+	em := event.NewManager()
+	dm := &mocks.MockDialogueManager{IsSpeakingValue: true}
+	ctx := &app.AppContext{DialogueManager: dm, EventManager: em}
+
+	var payload map[string]interface{}
+	em.Subscribe("answered", func(e event.Event) {
+		payload = e.(event.GenericEvent).Payload
+	})
+
+	vars := NewVariables()
+	vars.SetFlag("knows_secret", true)
+	cmd := &ChoiceCommand{
+		Prompt: "Well?",
+		Options: []ChoiceOption{
+			{Text: "Hidden", Condition: &ConditionData{Not: &ConditionData{Flag: "knows_secret"}}},
+			{Text: "Yes", Value: "yes"},
+			{Text: "Tell me the secret"},
+		},
+		Var:       "answer",
+		EventType: "answered",
+	}
+	cmd.setVariables(vars)
+	cmd.Init(ctx)
+
+	if len(dm.LastOptions) != 2 || dm.LastOptions[0] != "Yes" || dm.LastPrompt != "Well?" {
+		t.Fatalf("ShowChoice got (%q, %v), want hidden option filtered", dm.LastPrompt, dm.LastOptions)
+	}
+	if cmd.Update() {
+		t.Fatal("expected choice to wait while the dialogue is open")
+	}
+
+	dm.IsSpeakingValue = false
+	dm.ChoiceValue, dm.ChoiceMade = 1, true
+	if !cmd.Update() {
+		t.Fatal("expected choice to finish once answered")
+	}
+	if got, _ := vars.Get("answer"); got != 2.0 {
+		t.Errorf("answer = %v, want the original option index 2", got)
+	}
+	if payload["index"] != 2 {
+		t.Errorf("event payload = %v, want index 2", payload)
+	}
+}
+
+func TestChoiceCommand_NoVisibleOptionsFinishes(t *testing.T) {
+	dm := &mocks.MockDialogueManager{}
+	cmd := &ChoiceCommand{Options: []ChoiceOption{{Text: "x", Condition: &ConditionData{Flag: "never"}}}}
+	cmd.Init(&app.AppContext{DialogueManager: dm})
+
+	if dm.ShowChoiceCalls != 0 || !cmd.Update() {
+		t.Error("expected a choice with no visible options to finish without showing")
+	}
+}
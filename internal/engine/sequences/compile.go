@@ -0,0 +1,189 @@
+package sequences
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/audio"
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/sequences"
+)
+
+// compileCommands flattens the JSON command tree into the linear command list
+// the player walks. "if"/"else" blocks become a branchCommand followed by the
+// then branch, a jump over the else branch, and the else branch; "label"
+// emits nothing and "goto" becomes a jump resolved once every label is known.
+// Errors name the offending command by its index path, e.g.
+// "commands[3].then[1]".
+func compileCommands(list []CommandData) ([]sequences.Command, []bool, error) {
+	c := &compiler{labels: make(map[string]int)}
+	if err := c.compile(list, "commands"); err != nil {
+		return nil, nil, err
+	}
+	for _, g := range c.gotos {
+		target, ok := c.labels[g.jump.label]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: goto unknown label %q", g.where, g.jump.label)
+		}
+		g.jump.target = target
+	}
+	return c.commands, c.blocking, nil
+}
+
+type compiler struct {
+	commands []sequences.Command
+	blocking []bool
+	labels   map[string]int
+	gotos    []pendingGoto
+}
+
+type pendingGoto struct {
+	jump  *jumpCommand
+	where string
+}
+
+func (c *compiler) emit(cmd sequences.Command, block bool) {
+	c.commands = append(c.commands, cmd)
+	c.blocking = append(c.blocking, block)
+}
+
+func (c *compiler) compile(list []CommandData, path string) error {
+	for i := range list {
+		cd := &list[i]
+		where := fmt.Sprintf("%s[%d]", path, i)
+		switch cd.Type {
+		case "if":
+			if cd.Condition == nil {
+				return fmt.Errorf("%s: if needs a condition", where)
+			}
+			cond, err := cd.Condition.compile()
+			if err != nil {
+				return fmt.Errorf("%s: %w", where, err)
+			}
+			// Flow commands are marked blocking so the blocking phase cannot
+			// end before every branch has been decided.
+			branch := &branchCommand{cond: cond}
+			c.emit(branch, true)
+			if err := c.compile(cd.Then, where+".then"); err != nil {
+				return err
+			}
+			if len(cd.Else) > 0 {
+				skip := &jumpCommand{}
+				c.emit(skip, true)
+				branch.elseIndex = len(c.commands)
+				if err := c.compile(cd.Else, where+".else"); err != nil {
+					return err
+				}
+				skip.target = len(c.commands)
+			} else {
+				branch.elseIndex = len(c.commands)
+			}
+		case "label":
+			if cd.Label == "" {
+				return fmt.Errorf("%s: label needs a label name", where)
+			}
+			if _, dup := c.labels[cd.Label]; dup {
+				return fmt.Errorf("%s: duplicate label %q", where, cd.Label)
+			}
+			c.labels[cd.Label] = len(c.commands)
+		case "goto":
+			if cd.Label == "" {
+				return fmt.Errorf("%s: goto needs a label", where)
+			}
+			jump := &jumpCommand{label: cd.Label}
+			c.gotos = append(c.gotos, pendingGoto{jump: jump, where: where})
+			c.emit(jump, true)
+		case "parallel":
+			cmd, err := compileParallel(cd.Commands, where+".commands")
+			if err != nil {
+				return err
+			}
+			c.emit(cmd, blockFlag(cd))
+		default:
+			cmd, err := compileCommand(cd, where)
+			if err != nil {
+				return err
+			}
+			c.emit(cmd, blockFlag(cd))
+		}
+	}
+	return nil
+}
+
+// compileParallel builds a ParallelCommand. Its children run side by side, so
+// control flow inside them has no meaning and is rejected.
+func compileParallel(list []CommandData, path string) (*ParallelCommand, error) {
+	par := &ParallelCommand{}
+	for i := range list {
+		cd := &list[i]
+		where := fmt.Sprintf("%s[%d]", path, i)
+		switch cd.Type {
+		case "if", "label", "goto":
+			return nil, fmt.Errorf("%s: %s is not allowed inside parallel", where, cd.Type)
+		case "parallel":
+			child, err := compileParallel(cd.Commands, where+".commands")
+			if err != nil {
+				return nil, err
+			}
+			par.Commands = append(par.Commands, child)
+		default:
+			cmd, err := compileCommand(cd, where)
+			if err != nil {
+				return nil, err
+			}
+			par.Commands = append(par.Commands, cmd)
+		}
+	}
+	return par, nil
+}
+
+func compileCommand(cd *CommandData, where string) (sequences.Command, error) {
+	if cd.Type == "" {
+		return nil, fmt.Errorf("%s: missing command", where)
+	}
+	switch cd.Type {
+	case "set_var", "inc_var", "set_flag":
+		if cd.Name == "" {
+			return nil, fmt.Errorf("%s: %s needs a name", where, cd.Type)
+		}
+		if cd.Type == "set_var" && !isScalar(cd.Value) {
+			return nil, fmt.Errorf("%s: value must be a number, string or bool", where)
+		}
+	case "wait_for_event":
+		if cd.EventType == "" {
+			return nil, fmt.Errorf("%s: wait_for_event needs an event_type", where)
+		}
+	case "crossfade_music":
+		if cd.Path == "" {
+			return nil, fmt.Errorf("%s: crossfade_music needs a path", where)
+		}
+	case "set_bus_volume":
+		if !slices.Contains(audio.Buses, audio.Bus(cd.Bus)) {
+			return nil, fmt.Errorf("%s: unknown audio bus %q", where, cd.Bus)
+		}
+		if cd.Volume == nil && cd.Muted == nil {
+			return nil, fmt.Errorf("%s: set_bus_volume needs a volume or muted", where)
+		}
+	case "choice":
+		if len(cd.Options) == 0 {
+			return nil, fmt.Errorf("%s: choice needs options", where)
+		}
+	}
+	cmd := cd.ToCommand()
+	if cmd == nil {
+		return nil, fmt.Errorf("%s: unknown command %q", where, cd.Type)
+	}
+	if choice, ok := cmd.(*ChoiceCommand); ok {
+		if err := choice.compile(); err != nil {
+			return nil, fmt.Errorf("%s: %w", where, err)
+		}
+	}
+	return cmd, nil
+}
+
+// blockFlag returns the command's block_sequence flag, defaulting to true.
+func blockFlag(cd *CommandData) bool {
+	if cd.BlockSequence != nil {
+		return *cd.BlockSequence
+	}
+	return true
+}
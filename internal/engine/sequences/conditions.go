@@ -0,0 +1,165 @@
+package sequences
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/app"
+)
+
+// ConditionData is the JSON form of a test used by "if" commands and choice
+// options. Exactly one of Var, Flag, Actor, All, Any or Not is set:
+//
+//	{"var": "coins", "op": ">=", "value": 3}
+//	{"var": "met_guard"}                       // truthy test
+//	{"flag": "boss_defeated"}
+//	{"actor": "guard", "state": "idle"}        // omit state to test existence
+//	{"all": [...]}, {"any": [...]}, {"not": {...}}
+type ConditionData struct {
+	Var   string `json:"var,omitempty"`
+	Op    string `json:"op,omitempty"`
+	Value any    `json:"value,omitempty"`
+
+	Flag string `json:"flag,omitempty"`
+
+	Actor string `json:"actor,omitempty"`
+	State string `json:"state,omitempty"`
+
+	All []ConditionData `json:"all,omitempty"`
+	Any []ConditionData `json:"any,omitempty"`
+	Not *ConditionData  `json:"not,omitempty"`
+}
+
+// condition is a compiled ConditionData.
+type condition func(ctx *app.AppContext, vars *Variables) bool
+
+// compile validates the condition and returns its evaluator.
+func (c *ConditionData) compile() (condition, error) {
+	set := 0
+	for _, present := range []bool{c.Var != "", c.Flag != "", c.Actor != "", c.All != nil, c.Any != nil, c.Not != nil} {
+		if present {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, errors.New("condition needs exactly one of var, flag, actor, all, any or not")
+	}
+
+	switch {
+	case c.Var != "":
+		return c.compileVar()
+	case c.Flag != "":
+		name := c.Flag
+		return func(_ *app.AppContext, vars *Variables) bool { return vars.Flag(name) }, nil
+	case c.Actor != "":
+		id, state := c.Actor, c.State
+		return func(ctx *app.AppContext, _ *Variables) bool {
+			if ctx == nil || ctx.ActorManager == nil {
+				return false
+			}
+			actor, ok := ctx.ActorManager.Find(id)
+			if !ok {
+				return false
+			}
+			return state == "" || actor.State().String() == state
+		}, nil
+	case c.Not != nil:
+		inner, err := c.Not.compile()
+		if err != nil {
+			return nil, fmt.Errorf("not: %w", err)
+		}
+		return func(ctx *app.AppContext, vars *Variables) bool { return !inner(ctx, vars) }, nil
+	}
+
+	all := c.All != nil
+	list := c.Any
+	if all {
+		list = c.All
+	}
+	conds := make([]condition, len(list))
+	for i := range list {
+		cond, err := list[i].compile()
+		if err != nil {
+			if all {
+				return nil, fmt.Errorf("all[%d]: %w", i, err)
+			}
+			return nil, fmt.Errorf("any[%d]: %w", i, err)
+		}
+		conds[i] = cond
+	}
+	return func(ctx *app.AppContext, vars *Variables) bool {
+		for _, cond := range conds {
+			if cond(ctx, vars) != all {
+				return !all
+			}
+		}
+		return all
+	}, nil
+}
+
+func (c *ConditionData) compileVar() (condition, error) {
+	name, want := c.Var, c.Value
+	if !isScalar(want) {
+		return nil, errors.New("value must be a number, string or bool")
+	}
+	op := c.Op
+	if op == "" {
+		if want == nil {
+			return func(_ *app.AppContext, vars *Variables) bool {
+				val, _ := vars.Get(name)
+				return truthy(val)
+			}, nil
+		}
+		op = "=="
+	}
+
+	switch op {
+	case "==", "!=":
+		equal := op == "=="
+		return func(_ *app.AppContext, vars *Variables) bool {
+			val, _ := vars.Get(name)
+			return valuesEqual(val, want) == equal
+		}, nil
+	case "<", "<=", ">", ">=":
+		limit, ok := toNumber(want)
+		if !ok {
+			return nil, fmt.Errorf("op %q needs a numeric value", op)
+		}
+		return func(_ *app.AppContext, vars *Variables) bool {
+			val, _ := vars.Get(name)
+			n, _ := toNumber(val)
+			switch op {
+			case "<":
+				return n < limit
+			case "<=":
+				return n <= limit
+			case ">":
+				return n > limit
+			default:
+				return n >= limit
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown op %q", op)
+}
+
+// isScalar reports whether value is nil, a number, a string or a bool: the
+// only values variables hold and conditions compare.
+func isScalar(value any) bool {
+	switch value.(type) {
+	case nil, bool, string:
+		return true
+	}
+	_, ok := toNumber(value)
+	return ok
+}
+
+// valuesEqual compares JSON-ish values, treating a missing numeric variable
+// as 0 so {"var": "count", "value": 0} holds before the first increment.
+func valuesEqual(got, want any) bool {
+	if w, ok := toNumber(want); ok {
+		g, _ := toNumber(got)
+		return g == w
+	}
+	return got == want
+}
@@ -3,6 +3,9 @@ package sequences
 import (
 	"fmt"
 	"image/color"
+	"log"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
@@ -50,8 +53,17 @@ type SequencePlayer struct {
 
 	backgroundCommands       []sequences.Command
 	consumedOneTimeSequences map[string]struct{}
+
+	// vars is shared with nested players started by call_sequence so called
+	// sequences can read and set their caller's variables.
+	vars *Variables
 }
 
+// maxFlowSteps bounds how many control-flow and non-blocking commands one
+// advance may resolve, so a goto loop with no blocking command in it ends the
+// sequence instead of hanging the frame.
+const maxFlowSteps = 10000
+
 // NewSequencePlayer creates a new player.
 func NewSequencePlayer(appContext *app.AppContext) *SequencePlayer {
 	ctx := app.AppContextHolder{}
@@ -59,14 +71,35 @@ func NewSequencePlayer(appContext *app.AppContext) *SequencePlayer {
 	return &SequencePlayer{
 		AppContextHolder:         ctx,
 		consumedOneTimeSequences: make(map[string]struct{}),
+		vars:                     NewVariables(),
+	}
+}
+
+// Variables returns the variables and flags sequences branch on.
+func (p *SequencePlayer) Variables() *Variables {
+	return p.vars
+}
+
+// Flags returns the names of every set sequence flag, sorted. Used by the
+// save system.
+func (p *SequencePlayer) Flags() []string {
+	return p.vars.Flags()
+}
+
+// RestoreFlags sets the given sequence flags, e.g. after loading a save.
+func (p *SequencePlayer) RestoreFlags(names []string) {
+	for _, name := range names {
+		p.vars.SetFlag(name, true)
 	}
 }
 
-// PlaySequence loads and plays a sequence from a JSON file.
+// PlaySequence loads and plays a sequence from a JSON file. A file that
+// fails to load or compile is logged and nothing plays.
 func (p *SequencePlayer) PlaySequence(filePath string) {
 	debug.Watch("sequence_play", "", filePath)
 	sequence, err := NewSequenceFromFS(p.AppContext().Assets, filePath)
 	if err != nil {
+		log.Printf("PlaySequence %s: %v", filePath, err)
 		return
 	}
 	p.Play(sequence)
@@ -137,6 +170,9 @@ func (p *SequencePlayer) play(sequence sequences.Sequence) {
 	p.blockingEnded = false
 	p.lastBlockingIndex = -1
 	p.backgroundCommands = nil
+	if !p.blockedByParent {
+		p.vars.resetVars()
+	}
 
 	if seq, ok := sequence.(*Sequence); ok && len(seq.blockSequenceFlags) == len(seq.commands) {
 		for i, flag := range seq.blockSequenceFlags {
@@ -172,6 +208,25 @@ func (p *SequencePlayer) play(sequence sequences.Sequence) {
 	p.updating = false
 }
 
+// ConsumedOneTimeSequences returns the paths of one-time sequences that have
+// already played, in sorted order. Used by the save system.
+func (p *SequencePlayer) ConsumedOneTimeSequences() []string {
+	paths := make([]string, 0, len(p.consumedOneTimeSequences))
+	for path := range p.consumedOneTimeSequences {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// RestoreConsumedOneTimeSequences marks paths as already played so loaded
+// games do not replay one-time sequences.
+func (p *SequencePlayer) RestoreConsumedOneTimeSequences(paths []string) {
+	for _, path := range paths {
+		p.consumedOneTimeSequences[path] = struct{}{}
+	}
+}
+
 // IsPlaying returns true if a sequence is currently being played.
 func (p *SequencePlayer) IsPlaying() bool {
 	debug.Watch("sequence_isPlaying", "", p.isPlaying)
@@ -270,6 +325,7 @@ func (p *SequencePlayer) update() {
 // advanceToNextCommand moves to the next command in the queue and initializes it.
 func (p *SequencePlayer) advanceToNextCommand() {
 	// Keep advancing until we hit a blocking command or the end
+	flowSteps := 0
 	for {
 		p.currentCommandIndex++
 		if p.currentCommandIndex >= len(p.currentSequence.Commands()) {
@@ -287,6 +343,19 @@ func (p *SequencePlayer) advanceToNextCommand() {
 
 		nextCommand := p.currentSequence.Commands()[p.currentCommandIndex]
 
+		flowSteps++
+		if flowSteps > maxFlowSteps {
+			debug.Log("sequence_player", "seq=%s: control flow looped %d times without blocking, ending sequence",
+				p.currentSequencePath, maxFlowSteps)
+			p.currentCommandIndex = len(p.currentSequence.Commands()) - 1
+			continue
+		}
+
+		if flow, ok := nextCommand.(flowCommand); ok {
+			p.currentCommandIndex = flow.next(p.currentCommandIndex, p.AppContext(), p.vars) - 1
+			continue
+		}
+
 		// Check if this command is blocking or not
 		isBlocking := true
 		if seq, ok := p.currentSequence.(*Sequence); ok && p.currentCommandIndex < len(seq.blockSequenceFlags) {
@@ -296,6 +365,9 @@ func (p *SequencePlayer) advanceToNextCommand() {
 		debug.Log("command_init", "[%d/%d] %s blocking=%v seq=%s",
 			p.currentCommandIndex+1, len(p.currentSequence.Commands()),
 			commandName(nextCommand), isBlocking, p.currentSequencePath)
+		if user, ok := nextCommand.(variablesUser); ok {
+			user.setVariables(p.vars)
+		}
 		nextCommand.Init(p.AppContext())
 		if isBlocking {
 			if p.debugActive {
@@ -304,8 +376,12 @@ func (p *SequencePlayer) advanceToNextCommand() {
 			return
 		}
 
-		// Non-blocking: run in background and immediately advance to try next
-		p.backgroundCommands = append(p.backgroundCommands, nextCommand)
+		// Non-blocking: run in background and immediately advance to try next.
+		// A loop that reaches the same command again has just restarted it,
+		// so it is only queued once.
+		if !slices.Contains(p.backgroundCommands, nextCommand) {
+			p.backgroundCommands = append(p.backgroundCommands, nextCommand)
+		}
 		// Loop to look for the next blocking command (or finish)
 	}
 }
@@ -319,11 +395,24 @@ func (p *SequencePlayer) endSequence() {
 	if !p.blockingEnded {
 		p.endBlockingPhase()
 	}
+	p.clearScriptedAudio()
 	p.hasActiveCommands = false
 	p.currentSequence = nil
 	p.currentSequencePath = ""
 }
 
+// clearScriptedAudio drops the bus levels set by set_bus_volume, returning
+// every bus to the player's settings. Sequences started by call_sequence leave
+// them to their caller.
+func (p *SequencePlayer) clearScriptedAudio() {
+	if p.blockedByParent {
+		return
+	}
+	if ctx := p.AppContext(); ctx != nil && ctx.AudioManager != nil {
+		ctx.AudioManager.Mixer().ClearScripted()
+	}
+}
+
 // Stop cleanly stops the current sequence.
 func (p *SequencePlayer) Stop() {
 	if !p.hasActiveCommands {
@@ -341,6 +430,7 @@ func (p *SequencePlayer) Stop() {
 		}
 	}
 
+	p.clearScriptedAudio()
 	p.hasActiveCommands = false
 	p.currentSequence = nil
 	p.currentSequencePath = ""
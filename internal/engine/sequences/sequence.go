@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/boilerplate/ebiten-template/internal/engine/audio"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/sequences"
 )
 
@@ -86,16 +87,28 @@ type CommandData struct {
 	Y      float64 `json:"y,omitempty"`
 	Smooth bool    `json:"smooth,omitempty"`
 
+	// Fields for "camera_lock"; X and Y are shared with "camera_move"
+	Width  float64 `json:"width,omitempty"`
+	Height float64 `json:"height,omitempty"`
+
+	// Fields for "camera_auto_scroll", in pixels per frame
+	ScrollX float64 `json:"scroll_x,omitempty"`
+	ScrollY float64 `json:"scroll_y,omitempty"`
+
 	// Fields for "camera_reset"
 	DefaultZoom float64 `json:"default_zoom,omitempty"`
 
-	// Fields for "call_sequence" and "play_music"
+	// Fields for "call_sequence", "play_music" and "crossfade_music"
 	Path string `json:"path,omitempty"`
 
-	// Fields for "play_music"
-	MusicRewind bool    `json:"rewind,omitempty"`
-	Volume      float64 `json:"volume,omitempty"`
-	Loop        bool    `json:"loop,omitempty"`
+	// Fields for "play_music"; Volume is shared with "set_bus_volume"
+	MusicRewind bool     `json:"rewind,omitempty"`
+	Volume      *float64 `json:"volume,omitempty"`
+	Loop        bool     `json:"loop,omitempty"`
+
+	// Fields for "set_bus_volume"
+	Bus   string `json:"bus,omitempty"`
+	Muted *bool  `json:"muted,omitempty"`
 
 	// Fields for "spawn_text"
 	Text     string `json:"text,omitempty"`
@@ -110,6 +123,30 @@ type CommandData struct {
 
 	// Fields for vfx
 	Color color.RGBA `json:"color,omitempty"`
+
+	// Fields for "set_var", "inc_var" and "set_flag". set_flag defaults value
+	// to true; inc_var defaults by to 1.
+	Name  string   `json:"name,omitempty"`
+	Value any      `json:"value,omitempty"`
+	By    *float64 `json:"by,omitempty"`
+
+	// Fields for "if"
+	Condition *ConditionData `json:"condition,omitempty"`
+	Then      []CommandData  `json:"then,omitempty"`
+	Else      []CommandData  `json:"else,omitempty"`
+
+	// Fields for "label" and "goto"
+	Label string `json:"label,omitempty"`
+
+	// Fields for "parallel"
+	Commands []CommandData `json:"commands,omitempty"`
+
+	// Fields for "choice" (also uses position, speech_speed, speech_id and
+	// event_type)
+	PromptKey string         `json:"prompt_key,omitempty"`
+	Prompt    string         `json:"prompt,omitempty"`
+	Options   []ChoiceOption `json:"options,omitempty"`
+	Var       string         `json:"var,omitempty"`
 }
 
 // SequenceData is a wrapper used for parsing a full sequence from JSON.
@@ -129,6 +166,17 @@ func (cd *CommandData) ToCommand() sequences.Command {
 			speed = int(cd.Speed)
 		}
 		return &DialogueCommand{Lines: cd.Lines, Position: cd.Position, Speed: speed, SpeechID: cd.SpeechID, SpeechAudio: cd.SpeechAudio, EnableSpeechSkip: cd.EnableSpeechSkip, EnablePlayerAdvance: cd.EnablePlayerAdvance, Accumulative: cd.Accumulative, HideIndicator: cd.HideIndicator}
+	case "choice":
+		return &ChoiceCommand{
+			PromptKey: cd.PromptKey,
+			Prompt:    cd.Prompt,
+			Options:   cd.Options,
+			Var:       cd.Var,
+			EventType: cd.EventType,
+			Position:  cd.Position,
+			Speed:     cd.SpeechSpeed,
+			SpeechID:  cd.SpeechID,
+		}
 	case "dialogue_reset":
 		return &DialogueResetCommand{}
 	case "delay":
@@ -200,6 +248,20 @@ func (cd *CommandData) ToCommand() sequences.Command {
 		return &CameraShakeCommand{
 			Trauma: cd.Trauma,
 		}
+	case "camera_lock":
+		return &CameraLockCommand{
+			X:      cd.X,
+			Y:      cd.Y,
+			Width:  cd.Width,
+			Height: cd.Height,
+		}
+	case "camera_unlock":
+		return &CameraUnlockCommand{}
+	case "camera_auto_scroll":
+		return &CameraAutoScrollCommand{
+			ScrollX: cd.ScrollX,
+			ScrollY: cd.ScrollY,
+		}
 	case "vignette_radius":
 		return &VignetteRadiusCommand{
 			InitialRadius: cd.InitialRadius,
@@ -216,18 +278,49 @@ func (cd *CommandData) ToCommand() sequences.Command {
 			Path: cd.Path,
 		}
 	case "play_music":
-		return &PlayMusicCommand{
+		cmd := &PlayMusicCommand{
 			Path:   cd.Path,
 			Rewind: cd.MusicRewind,
-			Volume: cd.Volume,
 			Loop:   cd.Loop,
 		}
+		if cd.Volume != nil {
+			cmd.Volume = *cd.Volume
+		}
+		return cmd
+	case "crossfade_music":
+		return &CrossfadeMusicCommand{
+			Path:     cd.Path,
+			Duration: cd.Duration,
+			Loop:     cd.Loop,
+		}
+	case "set_bus_volume":
+		return &SetBusVolumeCommand{
+			Bus:    audio.Bus(cd.Bus),
+			Volume: cd.Volume,
+			Muted:  cd.Muted,
+		}
 	case "pause_all_music":
 		return &PauseAllMusicCommand{}
-	case "fadeout_all_music":
+	case "fadeout_all_music", "fade_out_all_music":
 		return &FadeOutAllMusicCommand{
 			Duration: cd.Duration,
 		}
+	case "set_var":
+		return &SetVarCommand{Name: cd.Name, Value: cd.Value}
+	case "inc_var":
+		by := 1.0
+		if cd.By != nil {
+			by = *cd.By
+		}
+		return &IncVarCommand{Name: cd.Name, By: by}
+	case "set_flag":
+		on := true
+		if b, ok := cd.Value.(bool); ok {
+			on = b
+		}
+		return &SetFlagCommand{Name: cd.Name, Value: on}
+	case "wait_for_event":
+		return &WaitForEventCommand{EventType: cd.EventType}
 	case "spawn_text":
 		return &SpawnTextCommand{
 			TargetID: cd.TargetID,
@@ -262,18 +355,9 @@ func NewSequenceFromFS(fsys fs.FS, filePath string, fullPath ...string) (*Sequen
 		return &Sequence{}, err
 	}
 
-	var commands []sequences.Command
-	var flags []bool
-	for _, cd := range sequenceData.Commands {
-		cmd := cd.ToCommand()
-		if cmd != nil {
-			commands = append(commands, cmd)
-			block := true
-			if cd.BlockSequence != nil {
-				block = *cd.BlockSequence
-			}
-			flags = append(flags, block)
-		}
+	commands, flags, err := compileCommands(sequenceData.Commands)
+	if err != nil {
+		return &Sequence{}, fmt.Errorf("sequence %s: %w", filePath, err)
 	}
 
 	// Default values for optional fields
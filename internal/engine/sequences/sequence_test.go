@@ -218,6 +218,28 @@ func TestSequence_ToCommand_Dialogue(t *testing.T) {
 			},
 			wantType: "*sequences.CameraShakeCommand",
 		},
+		{
+			name: "camera_lock",
+			cmdData: CommandData{
+				Type:  "camera_lock",
+				X:     320,
+				Width: 320,
+			},
+			wantType: "*sequences.CameraLockCommand",
+		},
+		{
+			name:     "camera_unlock",
+			cmdData:  CommandData{Type: "camera_unlock"},
+			wantType: "*sequences.CameraUnlockCommand",
+		},
+		{
+			name: "camera_auto_scroll",
+			cmdData: CommandData{
+				Type:    "camera_auto_scroll",
+				ScrollX: 0.5,
+			},
+			wantType: "*sequences.CameraAutoScrollCommand",
+		},
 		{
 			name: "quake",
 			cmdData: CommandData{
@@ -442,6 +464,12 @@ func getTypeName(v interface{}) string {
 		return "*sequences.FadeOutAllMusicCommand"
 	case *CameraShakeCommand:
 		return "*sequences.CameraShakeCommand"
+	case *CameraLockCommand:
+		return "*sequences.CameraLockCommand"
+	case *CameraUnlockCommand:
+		return "*sequences.CameraUnlockCommand"
+	case *CameraAutoScrollCommand:
+		return "*sequences.CameraAutoScrollCommand"
 	case *QuakeCommand:
 		return "*sequences.QuakeCommand"
 	case *SpawnTextCommand:
@@ -1,8 +1,11 @@
 package sequences
 
 import (
+	"bytes"
+	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
@@ -303,13 +306,20 @@ func TestPlaySequence_ValidFile(t *testing.T) {
 }
 
 func TestPlaySequence_InvalidPath(t *testing.T) {
-	ctx := &app.AppContext{}
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	ctx := &app.AppContext{Assets: os.DirFS(t.TempDir())}
 	p := NewSequencePlayer(ctx)
 	p.PlaySequence("nonexistent/path.json")
 
 	if p.IsPlaying() {
 		t.Error("player should remain idle when PlaySequence path is invalid")
 	}
+	if !strings.Contains(buf.String(), "nonexistent/path.json") {
+		t.Errorf("load error not logged; log = %q", buf.String())
+	}
 }
 
 func TestEndBlockingPhase_UnblocksPlayer(t *testing.T) {
@@ -431,3 +441,35 @@ func TestReentrantChainDefersAndRunsToCompletion(t *testing.T) {
 		t.Fatalf("player left blocked after chain: blockCount=%d", pl.blockCount)
 	}
 }
+
+func TestSequencePlayerRestoreConsumedOneTimeSequences(t *testing.T) {
+	ctx := &app.AppContext{}
+	player := NewSequencePlayer(ctx)
+
+	oneTime := &mocks.MockSequence{
+		CommandsList: []contractseq.Command{&mocks.MockCommand{CompleteAfter: 1}},
+		IsOneTime:    true,
+		Path:         "intro.json",
+	}
+	player.Play(oneTime)
+	for i := 0; i < 5 && player.IsPlaying(); i++ {
+		player.Update()
+	}
+
+	consumed := player.ConsumedOneTimeSequences()
+	if len(consumed) != 1 || consumed[0] != "intro.json" {
+		t.Fatalf("ConsumedOneTimeSequences() = %v, want [intro.json]", consumed)
+	}
+
+	restored := NewSequencePlayer(ctx)
+	restored.RestoreConsumedOneTimeSequences(consumed)
+	cmd := &mocks.MockCommand{CompleteAfter: 1}
+	restored.Play(&mocks.MockSequence{
+		CommandsList: []contractseq.Command{cmd},
+		IsOneTime:    true,
+		Path:         "intro.json",
+	})
+	if cmd.InitCalled {
+		t.Fatal("expected restored one-time sequence not to replay")
+	}
+}
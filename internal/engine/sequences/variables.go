@@ -0,0 +1,108 @@
+package sequences
+
+import "sort"
+
+// Variables is the state branching sequences read and write. Vars are scoped
+// to a sequence run: they reset whenever a top-level sequence starts and are
+// shared with sequences it calls. Flags are named booleans that live as long
+// as the SequencePlayer and are persisted by the save system.
+//
+// Values come from JSON, so numbers are stored as float64.
+type Variables struct {
+	vars  map[string]any
+	flags map[string]bool
+}
+
+// NewVariables returns an empty variable store.
+func NewVariables() *Variables {
+	return &Variables{
+		vars:  make(map[string]any),
+		flags: make(map[string]bool),
+	}
+}
+
+// Get returns the value of name.
+func (v *Variables) Get(name string) (any, bool) {
+	val, ok := v.vars[name]
+	return val, ok
+}
+
+// Set stores value under name. Integer values are widened to float64 so they
+// compare equal to numbers decoded from JSON.
+func (v *Variables) Set(name string, value any) {
+	if n, ok := toNumber(value); ok {
+		value = n
+	}
+	v.vars[name] = value
+}
+
+// Add increments the numeric variable name by delta and returns the result.
+// Missing and non-numeric variables count as 0.
+func (v *Variables) Add(name string, delta float64) float64 {
+	n, _ := toNumber(v.vars[name])
+	n += delta
+	v.vars[name] = n
+	return n
+}
+
+// Flag reports whether the flag name is set.
+func (v *Variables) Flag(name string) bool {
+	return v.flags[name]
+}
+
+// SetFlag sets or clears the flag name.
+func (v *Variables) SetFlag(name string, on bool) {
+	if on {
+		v.flags[name] = true
+		return
+	}
+	delete(v.flags, name)
+}
+
+// Flags returns the names of every set flag, sorted.
+func (v *Variables) Flags() []string {
+	names := make([]string, 0, len(v.flags))
+	for name := range v.flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resetVars clears the run-scoped vars, keeping flags.
+func (v *Variables) resetVars() {
+	v.vars = make(map[string]any)
+}
+
+func toNumber(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// truthy mirrors what writers expect from a bare {"var": "x"} test: false,
+// 0, "" and missing values are false.
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	if n, ok := toNumber(value); ok {
+		return n != 0
+	}
+	return true
+}
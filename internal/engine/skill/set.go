@@ -2,8 +2,8 @@ package skill
 
 import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/input"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // Set is a registry of skills belonging to an actor.
@@ -21,11 +21,11 @@ func (s *Set) Add(sk Skill) {
 	s.skills = append(s.skills, sk)
 }
 
-// Get returns the ActiveSkill registered for the given key, if any.
-func (s *Set) Get(key ebiten.Key) (ActiveSkill, bool) {
+// Get returns the ActiveSkill registered for the given action, if any.
+func (s *Set) Get(action input.Action) (ActiveSkill, bool) {
 	for _, sk := range s.skills {
 		if as, ok := sk.(ActiveSkill); ok {
-			if as.ActivationKey() == key {
+			if as.ActivationAction() == action {
 				return as, true
 			}
 		}
@@ -4,9 +4,9 @@ import (
 	"testing"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/input"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // stubSkill is a minimal Skill implementation for testing Set operations.
@@ -22,14 +22,14 @@ func (s *stubSkill) Update(body.MovableCollidable, physicsmovement.MovementModel
 // stubActiveSkill is a minimal ActiveSkill implementation for testing Set.Get.
 type stubActiveSkill struct {
 	stubSkill
-	key ebiten.Key
+	action input.Action
 }
 
 func (s *stubActiveSkill) HandleInput(body.MovableCollidable, physicsmovement.MovementModel, body.BodiesSpace) {
 }
 
-func (s *stubActiveSkill) ActivationKey() ebiten.Key {
-	return s.key
+func (s *stubActiveSkill) ActivationAction() input.Action {
+	return s.action
 }
 
 // TestEngineSkillSetSurface verifies that the skill.Set registry type exports
@@ -91,20 +91,20 @@ func TestEngineSkillSetSurface(t *testing.T) {
 	t.Run("Get_round_trips_ActiveSkill", func(t *testing.T) {
 		s := skill.NewSet()
 
-		stub := &stubActiveSkill{key: ebiten.KeySpace}
+		stub := &stubActiveSkill{action: input.ActionJump}
 		s.Add(stub)
 
-		retrieved, ok := s.Get(ebiten.KeySpace)
+		retrieved, ok := s.Get(input.ActionJump)
 		if !ok {
-			t.Fatal("Get(KeySpace) returned ok=false, want true")
+			t.Fatal("Get(ActionJump) returned ok=false, want true")
 		}
 		if retrieved != stub {
-			t.Error("Get(KeySpace) returned different skill than added")
+			t.Error("Get(ActionJump) returned different skill than added")
 		}
 
-		_, ok = s.Get(ebiten.KeyEnter)
+		_, ok = s.Get(input.ActionConfirm)
 		if ok {
-			t.Error("Get(KeyEnter) returned ok=true for unregistered key, want false")
+			t.Error("Get(ActionConfirm) returned ok=true for unregistered action, want false")
 		}
 	})
 
@@ -2,8 +2,8 @@ package skill
 
 import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/input"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // SkillState represents the possible states of a skill.
@@ -22,10 +22,12 @@ type Skill interface {
 }
 
 // ActiveSkill defines the interface for a skill that requires user input.
+// ActivationAction names the input action that triggers it, so the physical
+// key or gamepad button stays rebindable through input.ActionMap.
 type ActiveSkill interface {
 	Skill
 	HandleInput(body body.MovableCollidable, model physicsmovement.MovementModel, space body.BodiesSpace)
-	ActivationKey() ebiten.Key
+	ActivationAction() input.Action
 }
 
 // SkillBase provides a base implementation for common skill attributes.
@@ -0,0 +1,81 @@
+package hud
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
+	"github.com/boilerplate/ebiten-template/internal/engine/ui/overlayutil"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// GoalProgress draws the progress of the current phase goal, one line per
+// goal, e.g. "enemies 3/5" or "time 0:42".
+type GoalProgress struct {
+	BaseHUD
+	goal func() phases.Goal
+	face *text.GoTextFace
+	X, Y float64
+}
+
+// NewGoalProgress returns a visible element showing the goal returned by
+// goal, which phase scenes expose as Goal.
+func NewGoalProgress(goal func() phases.Goal, face *text.GoTextFace) *GoalProgress {
+	h := &GoalProgress{goal: goal, face: face, X: 10, Y: 10}
+	h.SetVisible(true)
+	return h
+}
+
+// Lines returns the text drawn for the goal.
+func (h *GoalProgress) Lines() []string {
+	if h.goal == nil {
+		return nil
+	}
+	g := h.goal()
+	if g == nil {
+		return nil
+	}
+	progress := phases.GoalProgress(g)
+	lines := make([]string, len(progress))
+	for i, p := range progress {
+		lines[i] = progressLine(p)
+	}
+	return lines
+}
+
+func (h *GoalProgress) Draw(screen *ebiten.Image) {
+	if h.face == nil {
+		return
+	}
+	const lineH = 12
+	for i, line := range h.Lines() {
+		overlayutil.DrawText(screen, h.face, line, h.X, h.Y+float64(i*lineH), color.White)
+	}
+}
+
+func progressLine(p phases.Progress) string {
+	switch p.Goal {
+	case phases.SurviveGoalType:
+		return "survive " + clock(p.Target-p.Current)
+	case phases.TimedGoalType:
+		return "time " + clock(p.Target-p.Current)
+	}
+	label := p.Label
+	if label == "" {
+		label = string(p.Goal)
+		if p.Goal == phases.KillAllGoalType {
+			label = "enemies"
+		}
+	}
+	return fmt.Sprintf("%s %d/%d", label, p.Current, p.Target)
+}
+
+// clock formats a frame count as m:ss, rounding up so it only shows 0:00
+// once time is up.
+func clock(frames int) string {
+	tps := max(timing.TPS(), 1)
+	seconds := (max(frames, 0) + tps - 1) / tps
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,35 @@
+package hud
+
+import (
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
+)
+
+func TestGoalProgressLines(t *testing.T) {
+	tps := timing.TPS()
+	goal := &phases.AllGoal{Goals: []phases.Goal{
+		phases.NewKillAllGoal("", []string{"bat_0", "bat_1"}),
+		&phases.CollectGoal{ItemType: "star", Count: 3},
+		&phases.TimedGoal{Goal: &phases.ReachEndpointGoal{}, Frames: 90 * tps},
+	}}
+	goal.Goals[0].(*phases.KillAllGoal).Defeat("bat_1")
+	goal.Update()
+
+	h := NewGoalProgress(func() phases.Goal { return goal }, nil)
+	want := []string{"enemies 1/2", "star 0/3", "time 1:30"}
+	got := h.Lines()
+	if len(got) != len(want) {
+		t.Fatalf("Lines() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if lines := NewGoalProgress(func() phases.Goal { return &phases.NoGoal{} }, nil).Lines(); len(lines) != 0 {
+		t.Errorf("NoGoal lines = %q, want none", lines)
+	}
+}
@@ -3,6 +3,7 @@ package phaseoverlay
 import (
 	"image/color"
 	"strconv"
+	"strings"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/ui/overlayutil"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -14,6 +15,17 @@ import (
 type Entry struct {
 	ID   int
 	Name string
+	// Exits are the phases this one leads to, drawn after its name so the
+	// overlay shows the phase graph.
+	Exits []Exit
+	// Locked phases are drawn dimmed; they can still be jumped to.
+	Locked bool
+}
+
+// Exit is an edge of the phase graph: the phase an endpoint event leads to.
+type Exit struct {
+	EventID string
+	To      int
 }
 
 // PhaseOverlay is an in-game overlay that lists registered phases and jumps to
@@ -27,6 +39,7 @@ type PhaseOverlay struct {
 	face           *text.GoTextFace
 	keyJustPressed func(ebiten.Key) bool
 	onSelect       func(id int)
+	entriesFunc    func() []Entry
 }
 
 // New creates a PhaseOverlay wired to the real Ebitengine key-input backend.
@@ -38,8 +51,14 @@ func New() *PhaseOverlay {
 	}
 }
 
-// Open makes the overlay visible.
-func (o *PhaseOverlay) Open() { o.Base.Open() }
+// Open makes the overlay visible, refreshing the entries first when an
+// entries func is set.
+func (o *PhaseOverlay) Open() {
+	if o.entriesFunc != nil {
+		o.entries = o.entriesFunc()
+	}
+	o.Base.Open()
+}
 
 // Close hides the overlay.
 func (o *PhaseOverlay) Close() { o.Base.Close() }
@@ -53,6 +72,10 @@ func (o *PhaseOverlay) SetFont(f *text.GoTextFace) { o.face = f }
 // SetEntries sets the list of phases shown in the overlay.
 func (o *PhaseOverlay) SetEntries(entries []Entry) { o.entries = entries }
 
+// SetEntriesFunc sets a func the overlay calls for its entries each time it
+// opens, so lock states reflect the player's progress.
+func (o *PhaseOverlay) SetEntriesFunc(fn func() []Entry) { o.entriesFunc = fn }
+
 // SetOnSelect registers the callback invoked with the selected phase ID when
 // the player confirms a choice with Enter.
 func (o *PhaseOverlay) SetOnSelect(fn func(id int)) { o.onSelect = fn }
@@ -134,10 +157,33 @@ func (o *PhaseOverlay) Draw(screen *ebiten.Image) {
 
 	for i, e := range o.entries {
 		c := color.Color(color.White)
-		if i == o.cursor {
+		switch {
+		case i == o.cursor:
 			c = color.RGBA{255, 255, 0, 255}
+		case e.Locked:
+			c = color.RGBA{120, 120, 120, 255}
 		}
-		overlayutil.DrawText(screen, o.face, strconv.Itoa(i)+" "+e.Name, xPad, y, c)
+		overlayutil.DrawText(screen, o.face, entryLabel(i, e), xPad, y, c)
 		y += lineH
 	}
 }
+
+// entryLabel is the line drawn for the entry at index i, e.g.
+// "0 Forest -> 2, 3 [cave]".
+func entryLabel(i int, e Entry) string {
+	label := strconv.Itoa(i) + " " + e.Name
+	if e.Locked {
+		label += " (locked)"
+	}
+	if len(e.Exits) == 0 {
+		return label
+	}
+	exits := make([]string, len(e.Exits))
+	for j, x := range e.Exits {
+		exits[j] = strconv.Itoa(x.To)
+		if x.EventID != "" {
+			exits[j] += " [" + x.EventID + "]"
+		}
+	}
+	return label + " -> " + strings.Join(exits, ", ")
+}
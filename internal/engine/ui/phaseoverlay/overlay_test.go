@@ -248,4 +248,30 @@ func TestPhaseOverlay(t *testing.T) {
 		}()
 		o.Update()
 	})
+
+	t.Run("T-P15 entries func refreshes on open", func(t *testing.T) {
+		o := New()
+		locked := true
+		o.SetEntriesFunc(func() []Entry {
+			return []Entry{{ID: 4, Name: "Cave", Locked: locked}}
+		})
+		o.Open()
+		if len(o.entries) != 1 || !o.entries[0].Locked {
+			t.Fatalf("entries = %+v, want the locked cave", o.entries)
+		}
+
+		o.Close()
+		locked = false
+		o.Open()
+		if o.entries[0].Locked {
+			t.Fatal("entries not refreshed on open")
+		}
+	})
+
+	t.Run("T-P16 entry label lists exits", func(t *testing.T) {
+		e := Entry{ID: 2, Name: "Forest", Locked: true, Exits: []Exit{{To: 1}, {EventID: "cave", To: 3}}}
+		if got, want := entryLabel(0, e), "0 Forest (locked) -> 1, 3 [cave]"; got != want {
+			t.Fatalf("entryLabel = %q, want %q", got, want)
+		}
+	})
 }
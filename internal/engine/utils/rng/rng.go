@@ -0,0 +1,41 @@
+package rng
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Gameplay randomness (AI timers, drops, …) goes through this seedable source
+// so input replays reproduce the same run. Cosmetic randomness (particles)
+// may keep using math/rand; it does not affect simulation state.
+//
+//nolint:gochecknoglobals
+var (
+	seed int64
+	src  *rand.Rand
+)
+
+func init() {
+	Seed(time.Now().UnixNano())
+}
+
+// Seed resets the gameplay source to a deterministic sequence.
+func Seed(s int64) {
+	seed = s
+	src = rand.New(rand.NewSource(s))
+}
+
+// CurrentSeed returns the seed most recently passed to Seed.
+func CurrentSeed() int64 {
+	return seed
+}
+
+// Intn returns a non-negative pseudo-random number in [0,n). It panics if n <= 0.
+func Intn(n int) int {
+	return src.Intn(n)
+}
+
+// Float64 returns a pseudo-random number in [0.0,1.0).
+func Float64() float64 {
+	return src.Float64()
+}
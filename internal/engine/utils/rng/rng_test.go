@@ -0,0 +1,21 @@
+package rng
+
+import "testing"
+
+func TestSeedIsDeterministic(t *testing.T) {
+	Seed(42)
+	first := []int{Intn(100), Intn(100), Intn(100)}
+	f := Float64()
+
+	Seed(42)
+	second := []int{Intn(100), Intn(100), Intn(100)}
+	if first[0] != second[0] || first[1] != second[1] || first[2] != second[2] {
+		t.Errorf("Intn sequence %v != %v after reseeding", first, second)
+	}
+	if g := Float64(); g != f {
+		t.Errorf("Float64 = %v, want %v after reseeding", g, f)
+	}
+	if CurrentSeed() != 42 {
+		t.Errorf("CurrentSeed() = %d, want 42", CurrentSeed())
+	}
+}
@@ -1,8 +1,15 @@
 package gamesetup
 
 import (
+	"encoding/json"
+	"io/fs"
 	"os"
 	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/data/schemas"
+	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
+	"github.com/boilerplate/ebiten-template/internal/engine/ui/phaseoverlay"
+	"github.com/boilerplate/ebiten-template/internal/kit/combat/projectile"
 )
 
 func TestMain(m *testing.M) {
@@ -30,14 +37,72 @@ func TestNewConfig(t *testing.T) {
 	}
 }
 
-func TestGetPhases(t *testing.T) {
-	phases := GetPhases()
-	if len(phases) == 0 {
-		t.Fatal("GetPhases returned empty slice")
+func TestLoadPhases(t *testing.T) {
+	g, err := LoadPhases(os.DirFS("../../.."))
+	if err != nil {
+		t.Fatalf("LoadPhases: %v", err)
+	}
+	if len(g.Phases) == 0 {
+		t.Fatal("LoadPhases returned no phases")
 	}
 
 	// Basic check for phase 1
-	if phases[0].ID != 1 {
-		t.Errorf("expected phase 1 ID to be 1, got %d", phases[0].ID)
+	if g.Phases[0].ID != 1 || g.StartPhaseID != 1 {
+		t.Errorf("expected to start at phase 1, got phase %d first and start %d", g.Phases[0].ID, g.StartPhaseID)
+	}
+	if g.Phases[0].SceneType == 0 {
+		t.Error("scene type not set from the genre")
+	}
+}
+
+func TestPhaseEntriesShowGraph(t *testing.T) {
+	m := phases.NewManager()
+	m.AddPhase(phases.Phase{ID: 1, Name: "Forest", Exits: []phases.Exit{{EventID: "cave", NextPhaseID: 2}}})
+	m.AddPhase(phases.Phase{ID: 2, Name: "Cave", NextPhaseID: 1, Unlock: &phases.Condition{Completed: []int{1}}})
+
+	entries := phaseEntries(m)
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if entries[0].Locked || len(entries[0].Exits) != 1 || entries[0].Exits[0] != (phaseoverlay.Exit{EventID: "cave", To: 2}) {
+		t.Errorf("forest entry = %+v", entries[0])
+	}
+	if !entries[1].Locked || len(entries[1].Exits) != 1 || entries[1].Exits[0].To != 1 {
+		t.Errorf("cave entry = %+v", entries[1])
+	}
+}
+
+func TestLoadProjectilesCoversEnemyWeapons(t *testing.T) {
+	assets := os.DirFS("../../..")
+	r, err := projectile.LoadRegistry(assets, ProjectilesPath)
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+
+	paths, err := fs.Glob(assets, "assets/entities/enemies/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		raw, err := fs.ReadFile(assets, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var enemy struct {
+			Sprites schemas.SpriteData `json:"sprites"`
+		}
+		if err := json.Unmarshal(raw, &enemy); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if w := enemy.Sprites.Weapon; w != nil {
+			if _, ok := r.Lookup(w.ProjectileType); !ok {
+				t.Errorf("%s: projectile type %q is not registered", path, w.ProjectileType)
+			}
+		}
+	}
+	for _, name := range []string{"bullet_small", "bullet_large"} {
+		if _, ok := r.Lookup(name); !ok {
+			t.Errorf("player projectile type %q is not registered", name)
+		}
 	}
 }
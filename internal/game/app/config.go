@@ -17,6 +17,10 @@ const (
 	DefaultVolume = 0.5
 	MainFontFace  = "assets/fonts/pressstart2p.ttf"
 	SmallFontFace = "assets/fonts/tiny5.ttf"
+	SaveNamespace = "ebiten-boilerplate"
+
+	// ProjectilesPath is the JSON file the projectile types are loaded from.
+	ProjectilesPath = "assets/data/projectiles.json"
 )
 
 func NewConfig() *config.AppConfig {
@@ -67,6 +71,9 @@ func NewConfig() *config.AppConfig {
 	flag.Float64Var(&cfg.SlowMoFactor, "slow-mo-factor", 0.25, "Slow-motion TPS multiplier (clamped to [0.05, 1.0])")
 	flag.BoolVar(&cfg.FastForward, "fast-forward", false, "Enable fast-forward debug mode (raises effective TPS)")
 	flag.Float64Var(&cfg.FastForwardFactor, "fast-forward-factor", 4.0, "Fast-forward TPS multiplier (clamped to [1.0, 16.0])")
+	flag.StringVar(&cfg.RecordPath, "record", "", "Record player input to this replay file (starts in the current phase)")
+	flag.StringVar(&cfg.ReplayPath, "replay", "", "Replay player input from this replay file")
+	flag.BoolVar(&cfg.ReplayVerify, "replay-verify", false, "With -replay, hash actor positions each frame and report the first divergence")
 
 	return cfg
 }
@@ -1,30 +1,44 @@
 package gamesetup
 
 import (
+	"io/fs"
+
 	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
+	"github.com/boilerplate/ebiten-template/internal/engine/ui/phaseoverlay"
 	gamescenephases "github.com/boilerplate/ebiten-template/internal/game/scenes/phases"
 	phaseskit "github.com/boilerplate/ebiten-template/internal/kit/scenes/phases"
 )
 
-func GetPhases() []phases.Phase {
-	return []phases.Phase{
-		{
-			Genre:        phaseskit.GenrePlatformer,
-			SceneType:    gamescenephases.SceneTypeForGenre(phaseskit.GenrePlatformer),
-			ID:           1,
-			Name:         "Sample Phase",
-			TilemapPath:  "assets/tilemap/phase-000.tmj",
-			GoalType:     gamescenephases.ReactEndpointType,
-			SequencePath: "assets/sequences/sample_phase.json",
-		},
-		{
-			Genre:        phaseskit.GenreBeatemup,
-			SceneType:    gamescenephases.SceneTypeForGenre(phaseskit.GenreBeatemup),
-			ID:           2,
-			Name:         "Sample Phase",
-			TilemapPath:  "assets/tilemap/beatemup-000.tmj",
-			GoalType:     gamescenephases.ReactEndpointType,
-			SequencePath: "assets/sequences/sample_phase.json",
-		},
+// PhasesPath is the JSON file the phase graph is loaded from.
+const PhasesPath = "assets/data/phases.json"
+
+// LoadPhases loads and validates the phase graph, and sets each phase's scene
+// type from its genre.
+func LoadPhases(assets fs.FS) (*phases.Graph, error) {
+	g, err := phases.LoadGraph(assets, PhasesPath, phaseskit.GenresByName())
+	if err != nil {
+		return nil, err
+	}
+	for i := range g.Phases {
+		g.Phases[i].SceneType = gamescenephases.SceneTypeForGenre(g.Phases[i].Genre)
+	}
+	return g, nil
+}
+
+// phaseEntries lists every phase of m for the phase-jump overlay, with its
+// exits and whether it is unlocked yet.
+func phaseEntries(m *phases.Manager) []phaseoverlay.Entry {
+	list := m.Phases()
+	entries := make([]phaseoverlay.Entry, 0, len(list))
+	for _, p := range list {
+		e := phaseoverlay.Entry{ID: p.ID, Name: p.Name, Locked: !m.Unlocked(p.ID)}
+		for _, x := range p.Exits {
+			e.Exits = append(e.Exits, phaseoverlay.Exit{EventID: x.EventID, To: x.NextPhaseID})
+		}
+		if len(p.Exits) == 0 && p.NextPhaseID != 0 {
+			e.Exits = append(e.Exits, phaseoverlay.Exit{To: p.NextPhaseID})
+		}
+		entries = append(entries, e)
 	}
+	return entries
 }
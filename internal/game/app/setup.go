@@ -1,8 +1,12 @@
 package gamesetup
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/fs"
 	"log"
+	"time"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
 	"github.com/boilerplate/ebiten-template/internal/engine/assets/font"
@@ -14,6 +18,8 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
 	"github.com/boilerplate/ebiten-template/internal/engine/render/particles/vfx"
 	enginestylevfx "github.com/boilerplate/ebiten-template/internal/engine/render/vfx"
+	"github.com/boilerplate/ebiten-template/internal/engine/replay"
+	"github.com/boilerplate/ebiten-template/internal/engine/save"
 	"github.com/boilerplate/ebiten-template/internal/engine/scene"
 	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
 	"github.com/boilerplate/ebiten-template/internal/engine/ui/phaseoverlay"
@@ -67,16 +73,23 @@ func Setup(assets fs.FS) error {
 
 	// Load audio assets
 	audio.LoadAudioAssetsFromFS(assets, audioManager)
+	loadAudioSettings(audioManager.Mixer())
 
 	// Load VFX Manager (particles + floating text)
 	vfxManager := vfx.NewManager(assets, "assets/particles/vfx.json")
 	vfxManager.SetDefaultFont(fontMain)
 
 	// Load phases
-	for _, p := range GetPhases() {
+	phaseGraph, err := LoadPhases(assets)
+	if err != nil {
+		return err
+	}
+	for _, p := range phaseGraph.Phases {
 		phaseManager.AddPhase(p)
 	}
-	phaseManager.SetCurrentPhase(1)
+	if err := phaseManager.SetCurrentPhase(phaseGraph.StartPhaseID); err != nil {
+		return err
+	}
 
 	appContext := &app.AppContext{
 		AudioManager:      audioManager,
@@ -85,6 +98,7 @@ func Setup(assets fs.FS) error {
 		ActorManager:      actorManager,
 		SceneManager:      sceneManager,
 		PhaseManager:      phaseManager,
+		SaveManager:       save.NewManager(save.NewDefaultStorage(SaveNamespace)),
 		I18n:              i18nManager,
 		ImageManager:      nil,
 		DataManager:       nil,
@@ -97,8 +111,15 @@ func Setup(assets fs.FS) error {
 
 		Font: fontMain,
 	}
+	actorManager.SetEventManager(appContext.EventManager)
+
+	projectileTypes, err := projectile.LoadRegistry(assets, ProjectilesPath)
+	if err != nil {
+		return err
+	}
 	projManager := projectile.NewManager(appContext.Space)
 	projManager.SetVFXManager(vfxManager)
+	projManager.SetRegistry(projectileTypes)
 	appContext.ProjectileManager = projManager
 
 	sceneFactory := scene.NewDefaultSceneFactory(gamescene.InitSceneMap(appContext))
@@ -114,13 +135,11 @@ func Setup(assets fs.FS) error {
 	game.DebugOverlay().SetFont(fontSmall.NewFace(8))
 	game.ActorInspector().SetFont(fontSmall.NewFace(8))
 
-	// F2 phase-jump overlay: list every phase and warp to the chosen one.
-	phaseEntries := make([]phaseoverlay.Entry, 0, len(GetPhases()))
-	for _, p := range GetPhases() {
-		phaseEntries = append(phaseEntries, phaseoverlay.Entry{ID: p.ID, Name: p.Name})
-	}
+	// F2 phase-jump overlay: show the phase graph and warp to the chosen phase.
 	game.PhaseOverlay().SetFont(fontSmall.NewFace(8))
-	game.PhaseOverlay().SetEntries(phaseEntries)
+	game.PhaseOverlay().SetEntriesFunc(func() []phaseoverlay.Entry {
+		return phaseEntries(phaseManager)
+	})
 	game.PhaseOverlay().SetOnSelect(func(id int) {
 		if err := appContext.PhaseManager.SetCurrentPhase(id); err != nil {
 			log.Printf("phase jump: %v", err)
@@ -132,9 +151,16 @@ func Setup(assets fs.FS) error {
 		appContext.GoToCurrentPhaseScene(nil, true)
 	})
 
-	// Set initial game scene
+	replayController, err := setupReplay(cfg, phaseManager)
+	if err != nil {
+		return err
+	}
+	appContext.Replay = replayController
+
+	// Set initial game scene. Recording and replaying both start straight in
+	// the phase so the input stream lines up from the first frame.
 	initialScene := scenestypes.SceneMenu
-	if cfg.SkipIntro {
+	if cfg.SkipIntro || replayController != nil {
 		phase, _ := phaseManager.GetCurrentPhase()
 		initialScene = phase.SceneType
 	}
@@ -144,5 +170,85 @@ func Setup(assets fs.FS) error {
 		return err
 	}
 
+	return finishReplay(cfg, replayController)
+}
+
+// audioSettingsSlot is the settings-storage slot holding the bus mixer state.
+const audioSettingsSlot = 0
+
+// loadAudioSettings restores the player's bus volumes from the previous
+// session and saves them again whenever they change.
+func loadAudioSettings(mixer *audio.Mixer) {
+	storage := save.NewDefaultStorage(SaveNamespace + "-settings")
+	data, err := storage.Read(audioSettingsSlot)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, mixer); err != nil {
+			log.Printf("audio settings: %v", err)
+		}
+	case !errors.Is(err, save.ErrSlotNotFound):
+		log.Printf("audio settings: %v", err)
+	}
+	mixer.SetOnChange(func() {
+		data, err := json.Marshal(mixer)
+		if err == nil {
+			err = storage.Write(audioSettingsSlot, data)
+		}
+		if err != nil {
+			log.Printf("save audio settings: %v", err)
+		}
+	})
+}
+
+// setupReplay builds the input replay controller requested on the command
+// line, if any, and installs it. For playback it also jumps to the recorded
+// phase.
+func setupReplay(cfg *config.AppConfig, phaseManager *phases.Manager) (*replay.Controller, error) {
+	var c *replay.Controller
+	switch {
+	case cfg.ReplayPath != "":
+		rec, err := replay.ReadFile(cfg.ReplayPath)
+		if err != nil {
+			return nil, fmt.Errorf("load replay: %w", err)
+		}
+		if err := phaseManager.SetCurrentPhase(rec.PhaseID); err != nil {
+			return nil, fmt.Errorf("load replay: %w", err)
+		}
+		mode := replay.ModePlayback
+		if cfg.ReplayVerify {
+			mode = replay.ModeVerify
+		}
+		c = replay.NewPlayer(rec, mode)
+		c.OnDivergence = func(d replay.Divergence) {
+			log.Printf("replay diverged at frame %d (want %016x, got %016x)", d.Frame, d.Want, d.Got)
+		}
+	case cfg.RecordPath != "":
+		seed := time.Now().UnixNano()
+		c = replay.NewRecorder(phaseManager.CurrentPhase, seed, true)
+	default:
+		return nil, nil
+	}
+	c.Install()
+	return c, nil
+}
+
+// finishReplay writes the recording on exit, or reports how playback went.
+func finishReplay(cfg *config.AppConfig, c *replay.Controller) error {
+	if c == nil {
+		return nil
+	}
+	c.Uninstall()
+	if c.Mode() == replay.ModeRecord {
+		if err := replay.WriteFile(cfg.RecordPath, c.Recording()); err != nil {
+			return fmt.Errorf("save replay: %w", err)
+		}
+		log.Printf("replay: recorded %d frames to %s", c.Frame(), cfg.RecordPath)
+		return nil
+	}
+	if c.Mode() == replay.ModeVerify {
+		if _, diverged := c.Divergence(); !diverged {
+			log.Printf("replay: %d frames verified without divergence", c.Frame())
+		}
+	}
 	return nil
 }
@@ -29,6 +29,11 @@ func InitEnemyMap(ctx *app.AppContext) enemies.EnemyMap[platformer.PlatformerAct
 			if err != nil {
 				log.Fatal(err)
 			}
+			if enemy.Guard() != nil {
+				// Bats patrol blind; only a guard needs to watch the player.
+				player, _ := ctx.ActorManager.GetPlayer()
+				enemy.SetTarget(player)
+			}
 			return enemy
 		},
 	}
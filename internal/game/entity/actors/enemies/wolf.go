@@ -10,12 +10,16 @@ import (
 	kitactors "github.com/boilerplate/ebiten-template/internal/kit/actors"
 	"github.com/boilerplate/ebiten-template/internal/kit/actors/platformer"
 	kitcombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
+	meleeengine "github.com/boilerplate/ebiten-template/internal/kit/combat/melee"
 	kitcombatweapon "github.com/boilerplate/ebiten-template/internal/kit/combat/weapon"
+	kitstates "github.com/boilerplate/ebiten-template/internal/kit/states"
 )
 
 type WolfEnemy struct {
 	*platformer.PlatformerCharacter
 	*kitactors.ShooterCharacter
+
+	guardAI *meleeengine.GuardAI
 }
 
 // NewWolfEnemy creates a new wolf enemy.
@@ -42,8 +46,17 @@ func NewWolfEnemy(ctx *app.AppContext, x, y int, id string) (*WolfEnemy, error)
 	if err != nil {
 		return nil, err
 	}
+	if es, ok := shooter.(*kitcombatweapon.EnemyShooting); ok {
+		es.SetLineOfSight(ctx.Space)
+	}
 	enemy.ShooterCharacter = kitactors.NewShooterCharacter(shooter)
 
+	if spriteData.Guard != nil {
+		guard := meleeengine.NewGuard(kitstates.StateGuard, *spriteData.Guard)
+		guard.Install(enemy.Character)
+		enemy.guardAI = meleeengine.NewGuardAI(guard)
+	}
+
 	enemy.GetCharacter().SetFaction(kitcombat.FactionEnemy)
 	enemy.SetMovementState(
 		movement.SideToSide,
@@ -60,11 +73,25 @@ func (e *WolfEnemy) SetTarget(target body.MovableCollidable) {
 	if e.Shooter() != nil {
 		e.Shooter().SetTarget(target)
 	}
+	if e.guardAI != nil {
+		e.guardAI.SetTarget(target)
+	}
+}
+
+// Guard returns the enemy's guard, or nil if its JSON has no guard block.
+func (e *WolfEnemy) Guard() *meleeengine.Guard {
+	if e.guardAI == nil {
+		return nil
+	}
+	return e.guardAI.Guard()
 }
 
 // Character Methods
 func (e *WolfEnemy) Update(space body.BodiesSpace) error {
 	e.UpdateShooter()
+	if e.guardAI != nil {
+		e.guardAI.Update()
+	}
 	return e.Character.Update(space)
 }
 
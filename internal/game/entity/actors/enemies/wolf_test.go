@@ -6,6 +6,7 @@ import (
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
 	kitcombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
 
@@ -209,3 +210,50 @@ func (m *fakePlayerTarget) VAltitude16() int              { return 0 }
 func (m *fakePlayerTarget) SetVAltitude16(v16 int)        {}
 func (m *fakePlayerTarget) AccelerationAltitude() int     { return 0 }
 func (m *fakePlayerTarget) SetAccelerationAltitude(a int) {}
+
+// attackingPlayerTarget is a fakePlayerTarget that reports an attack, so the
+// wolf's GuardAI reacts to it.
+type attackingPlayerTarget struct {
+	*fakePlayerTarget
+	attacking bool
+}
+
+func (p *attackingPlayerTarget) IsAttacking() bool { return p.attacking }
+
+// TestWolfEnemy_GuardBlocksAttack verifies wolf.json's guard block gives the
+// wolf a GuardAI that raises its guard against the targeted player's attack
+// and blocks the hit.
+func TestWolfEnemy_GuardBlocksAttack(t *testing.T) {
+	ctx := newEnemyTestContext()
+
+	wolf, err := NewWolfEnemy(ctx, 100, 100, "wolf-guard")
+	if err != nil {
+		t.Fatalf("NewWolfEnemy returned error: %v", err)
+	}
+	if wolf.Guard() == nil {
+		t.Fatal("expected wolf.json's guard block to install a guard")
+	}
+
+	// wolf.json react_range=40; place target 30px to the right.
+	player := &attackingPlayerTarget{fakePlayerTarget: newFakePlayerTarget(130, 100)}
+	wolf.SetTarget(player)
+	player.attacking = true
+	if err := wolf.Update(ctx.Space); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	if !wolf.Guard().IsUp() {
+		t.Fatal("expected the wolf to raise its guard against an attack in range")
+	}
+
+	health := wolf.GetCharacter().Health()
+	var got contractscombat.DamageInfo
+	wolf.GetCharacter().OnHit = func(info contractscombat.DamageInfo, _ int) { got = info }
+	wolf.GetCharacter().TakeDamage(contractscombat.DamageInfo{Amount: 1, DirectionX: -1, Source: player})
+
+	if h := wolf.GetCharacter().Health(); h != health {
+		t.Errorf("health = %d after a guarded hit, want %d", h, health)
+	}
+	if !got.Blocked && !got.Parried {
+		t.Errorf("OnHit got %+v, want a blocked or parried hit", got)
+	}
+}
@@ -58,19 +58,28 @@ func NewCodyPlayer(ctx *app.AppContext) (beatemupkit.BeatEmUpActorEntity, error)
 
 	player.spriteData = &spriteData
 
+	if spriteData.Guard != nil {
+		guard := meleeengine.NewGuard(kitstates.StateGuard, *spriteData.Guard)
+		guard.Install(character.Character)
+		player.SetGuard(guard)
+	}
+
 	return player, nil
 }
 
 func (p *CodyPlayer) Update(space body.BodiesSpace) error {
 	cmds := input.CommandsReader()
 
+	// Launched, floored or waking up: the hit is in control.
+	isJuggled := p.Juggle().IsActive()
+
 	melee := p.MeleeController()
 	if melee != nil {
 		melee.SetSpace(space)
 		melee.Tick(p.GetCharacter())
 
 		// TODO: HandleInput has too many unused parameters for different game-genre actors
-		if melee.HandleInput(cmds.Melee, cmds.Dash, cmds.Jump, true, false) {
+		if !isJuggled && melee.HandleInput(cmds.Melee, cmds.Dash, cmds.Jump, true, false) {
 			melee.EnterAttackState(p.GetCharacter())
 		}
 	}
@@ -78,7 +87,14 @@ func (p *CodyPlayer) Update(space body.BodiesSpace) error {
 	isMeleeActive := melee != nil && melee.IsBlockingMovement()
 	isShooting := p.State() == actors.IdleShooting || p.State() == actors.WalkingShooting
 
-	if isMeleeActive || isShooting {
+	isGuarding := false
+	if guard := p.Guard(); guard != nil {
+		guard.HandleInput(cmds.Guard && !isMeleeActive && !isJuggled)
+		guard.Update()
+		isGuarding = guard.IsUp()
+	}
+
+	if isMeleeActive || isShooting || isGuarding || isJuggled {
 		p.SetSpeed(0)
 	} else {
 		p.SetSpeed(p.baseSpeed)
@@ -7,6 +7,7 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/vfx"
 	actors "github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
+	kitcombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
 	"github.com/boilerplate/ebiten-template/internal/kit/combat/inventory"
 	"github.com/boilerplate/ebiten-template/internal/kit/combat/weapon"
 )
@@ -53,10 +54,18 @@ func NewClimberInventory(projectileManager combat.ProjectileManager, vfxManager
 
 // NewPlayerMeleeWeapon creates the player's melee weapon with a 3-step combo chain.
 func NewPlayerMeleeWeapon() *weapon.MeleeWeapon {
+	// Short i-frames let the whole chain land; the finisher knocks back and,
+	// in beat-em-up phases, launches into a ground bounce. It keeps short
+	// i-frames too, so the next chain can juggle the launched target.
+	jab := kitcombat.Attack{HitStunFrames: 12, IFrames: 8}
+	finisher := kitcombat.Attack{
+		Knockback: fp16.To16(2), KnockbackUp: fp16.To16(1), HitStunFrames: 24, CritChance: 0.1,
+		Launch: fp16.To16(3), GroundBounce: true, IFrames: 8,
+	}
 	steps := []weapon.ComboStep{
-		{Damage: 1, StartupFrames: 2, ActiveFrames: [2]int{4, 10}, HitboxW16: fp16.To16(24), HitboxH16: fp16.To16(16), HitboxOffsetX16: fp16.To16(12), HitboxOffsetY16: fp16.To16(0)},
-		{Damage: 1, StartupFrames: 2, ActiveFrames: [2]int{3, 8}, HitboxW16: fp16.To16(28), HitboxH16: fp16.To16(16), HitboxOffsetX16: fp16.To16(14), HitboxOffsetY16: fp16.To16(-4)},
-		{Damage: 2, StartupFrames: 2, ActiveFrames: [2]int{5, 12}, HitboxW16: fp16.To16(32), HitboxH16: fp16.To16(20), HitboxOffsetX16: fp16.To16(16), HitboxOffsetY16: fp16.To16(0)},
+		{Damage: 1, StartupFrames: 2, ActiveFrames: [2]int{4, 10}, HitboxW16: fp16.To16(24), HitboxH16: fp16.To16(16), HitboxOffsetX16: fp16.To16(12), HitboxOffsetY16: fp16.To16(0), Attack: jab},
+		{Damage: 1, StartupFrames: 2, ActiveFrames: [2]int{3, 8}, HitboxW16: fp16.To16(28), HitboxH16: fp16.To16(16), HitboxOffsetX16: fp16.To16(14), HitboxOffsetY16: fp16.To16(-4), Attack: jab},
+		{Damage: 2, StartupFrames: 2, ActiveFrames: [2]int{5, 12}, HitboxW16: fp16.To16(32), HitboxH16: fp16.To16(20), HitboxOffsetX16: fp16.To16(16), HitboxOffsetY16: fp16.To16(0), Attack: finisher},
 	}
 	w := weapon.NewMeleeWeapon("player_melee", 8, 30, steps)
 	w.SetPostComboCooldownFrames(45)
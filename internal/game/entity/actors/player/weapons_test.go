@@ -137,3 +137,14 @@ func TestLoadShootingSkill_StateSpawnOffsets(t *testing.T) {
 		})
 	}
 }
+
+// TestNewPlayerMeleeWeapon_LaunchersKeepShortIFrames guards juggles: a step
+// that launches must set its own i-frames, or the target gets the two-second
+// default and cannot be juggled.
+func TestNewPlayerMeleeWeapon_LaunchersKeepShortIFrames(t *testing.T) {
+	for i, step := range gameplayer.NewPlayerMeleeWeapon().Steps() {
+		if step.Attack.Launch > 0 && (step.Attack.IFrames <= 0 || step.Attack.IFrames > 30) {
+			t.Errorf("step %d launches with IFrames %d, want 1-30", i, step.Attack.IFrames)
+		}
+	}
+}
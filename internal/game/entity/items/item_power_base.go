@@ -73,7 +73,7 @@ func (p *PowerUpItem) OnTouch(other body.Collidable) {
 	}
 
 	// Activate the skill via callback
-	p.SetRemoved(true)
+	p.Collect()
 	if p.activateSkill != nil {
 		p.activateSkill()
 	}
@@ -39,3 +39,9 @@ func (m *mockBodiesSpace) Find(id string) body.Collidable {
 	return nil
 }
 func (m *mockBodiesSpace) Query(image.Rectangle) []body.Collidable { return nil }
+func (m *mockBodiesSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (m *mockBodiesSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
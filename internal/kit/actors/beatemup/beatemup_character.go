@@ -5,6 +5,7 @@ import (
 	"io/fs"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
 	"github.com/boilerplate/ebiten-template/internal/engine/data/schemas"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
@@ -20,6 +21,7 @@ type BeatEmUpCharacter struct {
 	*actors.Character
 	*kitactors.MeleeCharacter
 	footprints map[actors.ActorStateEnum]image.Rectangle // local rect, NOT world-offset
+	juggle     *Juggle
 }
 
 // buildFootprints constructs a per-state footprint map from asset data.
@@ -70,6 +72,11 @@ func NewBeatEmUpCharacter(
 		MeleeCharacter: kitactors.NewMeleeCharacter(),
 	}
 	be.footprints = buildFootprints(spriteData.Assets, stateMap)
+	be.juggle = newJuggle(c)
+	if spriteData.Juggle != nil {
+		be.juggle.SetConfig(JuggleConfig(*spriteData.Juggle))
+	}
+	c.AddStateContributor(be.juggle)
 	c.SetMovementModel(physicsmovement.NewBeatEmUpMovementModel(blocker))
 	c.SetFaceDirection(spriteData.FacingDirection)
 	c.SetFrameRate(spriteData.FrameRate)
@@ -78,6 +85,25 @@ func NewBeatEmUpCharacter(
 	return be, nil
 }
 
+// Juggle returns the character's juggle, which launches, bounces and floors
+// it when hit.
+func (c *BeatEmUpCharacter) Juggle() *Juggle { return c.juggle }
+
+// TakeDamage shadows the embedded Character method: a hit that deals damage
+// can also launch, juggle or floor the character, interrupting its melee.
+func (c *BeatEmUpCharacter) TakeDamage(info contractscombat.DamageInfo) {
+	before := c.Health()
+	c.Character.TakeDamage(info)
+	if c.Health() >= before {
+		return
+	}
+	if c.juggle.onHit(info) {
+		if melee := c.MeleeController(); melee != nil {
+			melee.OnInterrupt()
+		}
+	}
+}
+
 // Footprint returns the current state's footprint rectangle in world coordinates.
 // Falls back to the union of the actor's collision rects when no footprint is
 // declared for the current state. If no collision rects exist either, returns
@@ -0,0 +1,276 @@
+package beatemup
+
+import (
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
+)
+
+// State enums: part of kit public API
+//
+//nolint:gochecknoglobals
+var (
+	StateLaunched    actors.ActorStateEnum
+	StateWallSplat   actors.ActorStateEnum
+	StateKnockedDown actors.ActorStateEnum
+	StateWakeUp      actors.ActorStateEnum
+)
+
+func init() {
+	juggleState := func(b actors.BaseState) actors.ActorState {
+		return &actors.IdleState{BaseState: b} // placeholder; Juggle drives these states
+	}
+	StateLaunched = actors.RegisterState("launched", juggleState)
+	StateWallSplat = actors.RegisterState("wall_splat", juggleState)
+	StateKnockedDown = actors.RegisterState("knocked_down", juggleState)
+	StateWakeUp = actors.RegisterState("wake_up", juggleState)
+}
+
+// JuggleConfig tunes how a character reacts to launching hits. Speeds are
+// fp16; non-positive values fall back to defaults. It mirrors
+// schemas.JuggleConfig, which NewBeatEmUpCharacter loads from the "juggle"
+// block of the sprite JSON.
+type JuggleConfig struct {
+	// JuggleLift16 is the upward speed a hit without launch gives an
+	// airborne character.
+	JuggleLift16 int
+	// GravityScale16 is the extra gravity per frame for each juggle hit
+	// since the launch, so long juggles come down faster.
+	GravityScale16 int
+	// BounceLift16 is the upward speed of a ground bounce.
+	BounceLift16    int
+	WallSplatFrames int
+	KnockdownFrames int
+	WakeUpFrames    int
+	// WakeUpIFrames is the invulnerability left after waking up; the
+	// character is also invulnerable while down and waking up.
+	WakeUpIFrames int
+	// JuggleIFrames caps the invulnerability a launching or juggle hit
+	// leaves, so follow-up hits can connect whatever the hit asked for.
+	JuggleIFrames int
+	// JuggleStunDecay is the hit-stun, in frames, each juggle hit since the
+	// launch takes off an airborne hit, so long juggles recover sooner.
+	JuggleStunDecay int
+}
+
+// DefaultJuggleConfig returns the defaults used for non-positive fields.
+func DefaultJuggleConfig() JuggleConfig {
+	return JuggleConfig{
+		JuggleLift16:    fp16.To16(2),
+		GravityScale16:  1,
+		BounceLift16:    fp16.To16(3),
+		WallSplatFrames: 24,
+		KnockdownFrames: 45,
+		WakeUpFrames:    20,
+		WakeUpIFrames:   30,
+		JuggleIFrames:   8,
+		JuggleStunDecay: 4,
+	}
+}
+
+func (cfg JuggleConfig) withDefaults() JuggleConfig {
+	def := DefaultJuggleConfig()
+	orDefault := func(v, d int) int {
+		if v <= 0 {
+			return d
+		}
+		return v
+	}
+	cfg.JuggleLift16 = orDefault(cfg.JuggleLift16, def.JuggleLift16)
+	cfg.GravityScale16 = orDefault(cfg.GravityScale16, def.GravityScale16)
+	cfg.BounceLift16 = orDefault(cfg.BounceLift16, def.BounceLift16)
+	cfg.WallSplatFrames = orDefault(cfg.WallSplatFrames, def.WallSplatFrames)
+	cfg.KnockdownFrames = orDefault(cfg.KnockdownFrames, def.KnockdownFrames)
+	cfg.WakeUpFrames = orDefault(cfg.WakeUpFrames, def.WakeUpFrames)
+	cfg.WakeUpIFrames = orDefault(cfg.WakeUpIFrames, def.WakeUpIFrames)
+	cfg.JuggleIFrames = orDefault(cfg.JuggleIFrames, def.JuggleIFrames)
+	cfg.JuggleStunDecay = orDefault(cfg.JuggleStunDecay, def.JuggleStunDecay)
+	return cfg
+}
+
+type jugglePhase int
+
+const (
+	juggleNone jugglePhase = iota
+	juggleAirborne
+	juggleSplat
+	juggleDown
+	juggleWakeUp
+)
+
+// Juggle moves a beat-em-up character along its altitude axis when hit. A
+// hit with LaunchAltitude16 launches it, and any hit while airborne juggles
+// it higher, each one adding GravityScale16 to its fall. A launched character
+// stays in StateLaunched until it lands, bouncing once first if a hit asked
+// for a ground bounce, and sticking in StateWallSplat if a wall-splat hit
+// knocked it into a wall. Landing, or a Knockdown hit on the ground, floors
+// it in StateKnockedDown and then StateWakeUp, invulnerable throughout.
+//
+// Airborne hits leave at most JuggleIFrames of invulnerability. A hit with
+// HitStunFrames stuns the character for that long less JuggleStunDecay per
+// juggle hit; if the stun runs out before it lands, it recovers in the air
+// and lands on its feet instead of being floored. Hits without hit-stun keep
+// it launched until it lands.
+//
+// Juggle is a StateContributor installed by NewBeatEmUpCharacter ahead of any
+// other contributor. Everything is integer fp16 math, so replays reproduce it.
+type Juggle struct {
+	char  *actors.Character
+	cfg   JuggleConfig
+	phase jugglePhase
+
+	hits   int  // juggle hits since the launch
+	bounce bool // a hit asked for a ground bounce
+	splat  bool // the last hit asked for a wall splat
+	timer  int
+
+	stun      int  // airborne hit-stun left; 0 = none
+	recovered bool // the airborne hit-stun ran out
+
+	// Position and velocity after the last airborne frame, to tell when a
+	// wall stopped the character.
+	lastX16, lastVX16 int
+}
+
+func newJuggle(char *actors.Character) *Juggle {
+	return &Juggle{char: char, cfg: DefaultJuggleConfig()}
+}
+
+// SetConfig replaces the juggle tuning.
+func (j *Juggle) SetConfig(cfg JuggleConfig) { j.cfg = cfg.withDefaults() }
+
+// IsActive reports whether the character is launched, splatted, down or
+// waking up, and so not under its own control.
+func (j *Juggle) IsActive() bool { return j.phase != juggleNone }
+
+// Hits returns the number of juggle hits since the last launch.
+func (j *Juggle) Hits() int { return j.hits }
+
+// onHit reacts to a hit that dealt damage and reports whether it launched,
+// juggled or floored the character.
+func (j *Juggle) onHit(info contractscombat.DamageInfo) bool {
+	airborne := j.phase == juggleAirborne || j.phase == juggleSplat || j.char.Altitude() > 0
+	switch {
+	case info.LaunchAltitude16 > 0:
+		j.launch(info.LaunchAltitude16, airborne, info)
+		j.char.SetInvulnerableFrames(j.airIFrames(info.IFrames))
+	case airborne:
+		j.launch(j.cfg.JuggleLift16, true, info)
+		j.char.SetInvulnerableFrames(j.airIFrames(info.IFrames))
+	case info.Knockdown:
+		j.knockDown()
+	default:
+		return false
+	}
+	return true
+}
+
+func (j *Juggle) launch(lift16 int, juggled bool, info contractscombat.DamageInfo) {
+	if juggled && j.phase != juggleNone {
+		j.hits++
+	} else {
+		j.hits = 0
+		j.bounce = false
+	}
+	j.phase = juggleAirborne
+	j.bounce = j.bounce || info.GroundBounce
+	j.splat = info.WallSplat
+	j.stun, j.recovered = 0, false
+	if info.HitStunFrames > 0 {
+		j.stun = max(info.HitStunFrames-j.hits*j.cfg.JuggleStunDecay, 1)
+	}
+	j.char.SetVAltitude16(-lift16)
+	j.lastX16, _ = j.char.GetPosition16()
+	j.lastVX16, _ = j.char.Velocity()
+	j.char.SetNewStateFatal(StateLaunched)
+}
+
+// airIFrames returns the invulnerability an airborne hit asking for iFrames
+// leaves: the hit's own if shorter, else JuggleIFrames.
+func (j *Juggle) airIFrames(iFrames int) int {
+	if iFrames > 0 && iFrames < j.cfg.JuggleIFrames {
+		return iFrames
+	}
+	return j.cfg.JuggleIFrames
+}
+
+func (j *Juggle) knockDown() {
+	j.phase = juggleDown
+	j.timer = j.cfg.KnockdownFrames
+	j.hits, j.stun = 0, 0
+	j.bounce, j.splat, j.recovered = false, false, false
+	j.char.SetInvulnerableFrames(j.cfg.KnockdownFrames + j.cfg.WakeUpFrames + j.cfg.WakeUpIFrames)
+	j.char.SetNewStateFatal(StateKnockedDown)
+}
+
+// ContributeState implements actors.StateContributor. It runs once per frame
+// after movement, so it also advances the juggle.
+func (j *Juggle) ContributeState(_ actors.ActorStateEnum) (actors.ActorStateEnum, bool) {
+	switch j.phase {
+	case juggleAirborne:
+		return j.updateAirborne()
+	case juggleSplat:
+		j.char.SetVelocity(0, 0)
+		j.char.SetVAltitude16(0) // pinned to the wall
+		if j.timer--; j.timer <= 0 {
+			j.phase = juggleAirborne
+			j.lastX16, _ = j.char.GetPosition16()
+			j.lastVX16 = 0
+			return StateLaunched, true
+		}
+		return StateWallSplat, true
+	case juggleDown:
+		if j.timer--; j.timer <= 0 {
+			j.phase = juggleWakeUp
+			j.timer = j.cfg.WakeUpFrames
+			return StateWakeUp, true
+		}
+		return StateKnockedDown, true
+	case juggleWakeUp:
+		if j.timer--; j.timer <= 0 {
+			j.phase = juggleNone
+			return 0, false
+		}
+		return StateWakeUp, true
+	}
+	return 0, false
+}
+
+func (j *Juggle) updateAirborne() (actors.ActorStateEnum, bool) {
+	if j.stun > 0 {
+		if j.stun--; j.stun == 0 {
+			j.recovered = true
+			j.bounce, j.splat = false, false
+		}
+	}
+
+	x16, _ := j.char.GetPosition16()
+	if j.splat && x16 == j.lastX16 && (j.lastVX16 >= fp16.To16(1) || j.lastVX16 <= -fp16.To16(1)) {
+		j.splat = false
+		j.phase = juggleSplat
+		j.timer = j.cfg.WallSplatFrames
+		j.char.SetVelocity(0, 0)
+		j.char.SetVAltitude16(0)
+		return StateWallSplat, true
+	}
+
+	if j.char.Altitude() <= 0 && j.char.VAltitude16() >= 0 {
+		if j.recovered {
+			j.phase = juggleNone
+			j.hits, j.recovered = 0, false
+			return 0, false
+		}
+		if j.bounce {
+			j.bounce = false
+			j.char.SetVAltitude16(-j.cfg.BounceLift16)
+			return StateLaunched, true
+		}
+		j.knockDown()
+		return StateKnockedDown, true
+	}
+
+	j.char.SetVAltitude16(j.char.VAltitude16() + j.hits*j.cfg.GravityScale16)
+	j.lastX16 = x16
+	j.lastVX16, _ = j.char.Velocity()
+	return StateLaunched, true
+}
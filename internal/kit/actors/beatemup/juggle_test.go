@@ -0,0 +1,263 @@
+package beatemup_test
+
+import (
+	"testing"
+
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/data/schemas"
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	"github.com/boilerplate/ebiten-template/internal/kit/actors/beatemup"
+	"github.com/boilerplate/ebiten-template/internal/kit/combat/weapon"
+)
+
+// newJuggleCharacter builds a character with health and short juggle timers.
+// Tests update it without a space, so it never moves horizontally.
+func newJuggleCharacter(t *testing.T) *beatemup.BeatEmUpCharacter {
+	t.Helper()
+	fsys, stateMap, spriteData, bodyRect := newTestFixtures()
+	c, err := beatemup.NewBeatEmUpCharacter(fsys, stateMap, spriteData, bodyRect, nil)
+	if err != nil {
+		t.Fatalf("NewBeatEmUpCharacter: %v", err)
+	}
+	c.SetID("target")
+	c.SetMaxHealth(10)
+	c.SetHealth(10)
+	c.Juggle().SetConfig(beatemup.JuggleConfig{
+		WallSplatFrames: 3,
+		KnockdownFrames: 4,
+		WakeUpFrames:    2,
+		WakeUpIFrames:   5,
+	})
+	return c
+}
+
+func launcher(lift16 int) contractscombat.DamageInfo {
+	return contractscombat.DamageInfo{Amount: 1, LaunchAltitude16: lift16, IFrames: 1}
+}
+
+// updateUntil updates c until its state is want, failing after limit frames.
+func updateUntil(t *testing.T, c *beatemup.BeatEmUpCharacter, want actors.ActorStateEnum, limit int) int {
+	t.Helper()
+	for frame := 1; frame <= limit; frame++ {
+		if err := c.Update(nil); err != nil {
+			t.Fatal(err)
+		}
+		if c.State() == want {
+			return frame
+		}
+	}
+	t.Fatalf("state %v not reached in %d frames; state = %v, altitude = %d", want, limit, c.State(), c.Altitude())
+	return 0
+}
+
+func TestJuggle_LaunchLandsIntoKnockdownAndWakeUp(t *testing.T) {
+	c := newJuggleCharacter(t)
+	c.TakeDamage(launcher(64))
+
+	if c.State() != beatemup.StateLaunched {
+		t.Fatalf("state = %v, want StateLaunched", c.State())
+	}
+	c.Update(nil)
+	if c.Altitude() <= 0 {
+		t.Fatalf("altitude = %d after launch, want > 0", c.Altitude())
+	}
+
+	updateUntil(t, c, beatemup.StateKnockedDown, 120)
+	if c.Altitude() != 0 || !c.Invulnerable() {
+		t.Errorf("knocked down: altitude %d, invulnerable %v", c.Altitude(), c.Invulnerable())
+	}
+	if got := updateUntil(t, c, beatemup.StateWakeUp, 10); got != 4 {
+		t.Errorf("woke up after %d frames, want 4", got)
+	}
+	updateUntil(t, c, actors.Idle, 10)
+	if c.Juggle().IsActive() {
+		t.Error("juggle still active after waking up")
+	}
+	if !c.Invulnerable() {
+		t.Error("no i-frames after waking up")
+	}
+}
+
+func TestJuggle_GroundBounce(t *testing.T) {
+	c := newJuggleCharacter(t)
+	info := launcher(64)
+	info.GroundBounce = true
+	c.TakeDamage(info)
+
+	// Fly until the first landing, which must bounce instead of flooring.
+	for c.Altitude() == 0 {
+		c.Update(nil)
+	}
+	for c.Altitude() > 0 {
+		c.Update(nil)
+	}
+	c.Update(nil)
+	if c.State() != beatemup.StateLaunched || c.VAltitude16() >= 0 {
+		t.Fatalf("first landing: state %v, vAlt %d, want a bounce", c.State(), c.VAltitude16())
+	}
+	updateUntil(t, c, beatemup.StateKnockedDown, 120)
+}
+
+func TestJuggle_AirborneHitsJuggleUnderScaledGravity(t *testing.T) {
+	fall := func(hits int) int {
+		c := newJuggleCharacter(t)
+		c.TakeDamage(launcher(64))
+		for i := 0; i < hits; i++ {
+			for c.Invulnerable() {
+				c.Update(nil)
+			}
+			c.TakeDamage(contractscombat.DamageInfo{Amount: 1, IFrames: 1})
+			if c.State() != beatemup.StateLaunched {
+				t.Fatalf("juggle hit %d: state = %v", i+1, c.State())
+			}
+		}
+		if c.Juggle().Hits() != hits {
+			t.Fatalf("Hits = %d, want %d", c.Juggle().Hits(), hits)
+		}
+		return updateUntil(t, c, beatemup.StateKnockedDown, 240)
+	}
+
+	// Every juggle hit pops the target back up by the same amount, but the
+	// extra gravity brings it down sooner each time.
+	if once, thrice := fall(1), fall(3); thrice >= once*3 {
+		t.Errorf("three juggle hits fell in %d frames, one in %d; gravity not scaled", thrice, once)
+	}
+}
+
+func TestJuggle_KnockdownOnGround(t *testing.T) {
+	c := newJuggleCharacter(t)
+	c.TakeDamage(contractscombat.DamageInfo{Amount: 1, Knockdown: true})
+	if c.State() != beatemup.StateKnockedDown || !c.Invulnerable() {
+		t.Fatalf("state %v, invulnerable %v; want knocked down and invulnerable", c.State(), c.Invulnerable())
+	}
+}
+
+func TestJuggle_WallSplat(t *testing.T) {
+	c := newJuggleCharacter(t)
+	info := launcher(64)
+	info.KnockbackX16 = 48
+	info.WallSplat = true
+	c.TakeDamage(info)
+
+	updateUntil(t, c, beatemup.StateWallSplat, 2)
+	alt := c.Altitude()
+	if got := updateUntil(t, c, beatemup.StateLaunched, 10); got != 3 {
+		t.Errorf("splat lasted %d frames, want 3", got)
+	}
+	if c.Altitude() != alt {
+		t.Errorf("altitude moved from %d to %d while splatted", alt, c.Altitude())
+	}
+	if vx, _ := c.Velocity(); vx != 0 {
+		t.Errorf("vx = %d after the splat, want 0", vx)
+	}
+	updateUntil(t, c, beatemup.StateKnockedDown, 120)
+}
+
+func TestJuggle_ConfigFromSpriteData(t *testing.T) {
+	fsys, stateMap, spriteData, bodyRect := newTestFixtures()
+	spriteData.Juggle = &schemas.JuggleConfig{KnockdownFrames: 3, WakeUpFrames: 2}
+	c, err := beatemup.NewBeatEmUpCharacter(fsys, stateMap, spriteData, bodyRect, nil)
+	if err != nil {
+		t.Fatalf("NewBeatEmUpCharacter: %v", err)
+	}
+	c.SetMaxHealth(10)
+	c.SetHealth(10)
+
+	c.TakeDamage(contractscombat.DamageInfo{Amount: 1, Knockdown: true})
+	if got := updateUntil(t, c, beatemup.StateWakeUp, 10); got != 3 {
+		t.Errorf("woke up after %d frames, want the JSON knockdown_frames 3", got)
+	}
+}
+
+func TestJuggle_HitsWithoutDamageDoNotLaunch(t *testing.T) {
+	c := newJuggleCharacter(t)
+	info := launcher(64)
+	info.Amount = 0
+	c.TakeDamage(info)
+	if c.Juggle().IsActive() || c.VAltitude16() != 0 {
+		t.Errorf("zero-damage hit launched: state %v, vAlt %d", c.State(), c.VAltitude16())
+	}
+}
+
+// jsonSteps loads a melee weapon from JSON and returns its combo steps.
+func jsonSteps(t *testing.T, data string) []weapon.ComboStep {
+	t.Helper()
+	w, err := weapon.NewWeaponFromJSON([]byte(data), nil)
+	if err != nil {
+		t.Fatalf("NewWeaponFromJSON: %v", err)
+	}
+	return w.(*weapon.MeleeWeapon).Steps()
+}
+
+func hit(step weapon.ComboStep) contractscombat.DamageInfo {
+	return step.Attack.Hit(step.Damage, nil, 1, 0)
+}
+
+const juggleWeaponJSON = `{
+	"id": "launcher",
+	"type": "melee",
+	"combo_steps": [
+		{"damage": 1, "active_frames": [0, 1], "hitbox": {"width": 8, "height": 8}, "launch": 96, "hit_stun_frames": 40},
+		{"damage": 1, "active_frames": [0, 1], "hitbox": {"width": 8, "height": 8}, "hit_stun_frames": 40}
+	]
+}`
+
+func TestJuggle_JSONLauncherWithoutIFramesCanBeJuggled(t *testing.T) {
+	steps := jsonSteps(t, juggleWeaponJSON)
+	c := newJuggleCharacter(t)
+	c.TakeDamage(hit(steps[0]))
+
+	// The step has no i_frames, which would leave the character's default
+	// of 120 frames; airborne hits are capped at JuggleIFrames instead.
+	frames := 0
+	for c.Invulnerable() {
+		if frames++; frames > beatemup.DefaultJuggleConfig().JuggleIFrames {
+			t.Fatalf("still invulnerable %d frames after the launch", frames)
+		}
+		c.Update(nil)
+	}
+	c.TakeDamage(hit(steps[1]))
+	if c.State() != beatemup.StateLaunched || c.Juggle().Hits() != 1 {
+		t.Errorf("follow-up hit: state %v, hits %d; want a juggle", c.State(), c.Juggle().Hits())
+	}
+}
+
+func TestJuggle_JSONHitStunShrinksWithJuggleHits(t *testing.T) {
+	steps := jsonSteps(t, `{
+		"id": "launcher",
+		"type": "melee",
+		"combo_steps": [
+			{"damage": 1, "active_frames": [0, 1], "hitbox": {"width": 8, "height": 8}, "launch": 96, "hit_stun_frames": 90},
+			{"damage": 1, "active_frames": [0, 1], "hitbox": {"width": 8, "height": 8}, "hit_stun_frames": 90}
+		]
+	}`)
+	land := func(juggles int) actors.ActorStateEnum {
+		c := newJuggleCharacter(t)
+		c.Juggle().SetConfig(beatemup.JuggleConfig{
+			JuggleLift16:    64,
+			JuggleStunDecay: 20,
+			KnockdownFrames: 4,
+		})
+		c.TakeDamage(hit(steps[0]))
+		for i := 0; i < juggles; i++ {
+			for c.Invulnerable() {
+				c.Update(nil)
+			}
+			c.TakeDamage(hit(steps[1]))
+		}
+		for c.State() == beatemup.StateLaunched {
+			c.Update(nil)
+		}
+		return c.State()
+	}
+
+	// 90 frames of hit-stun outlast the launch, so the target is floored.
+	if got := land(0); got != beatemup.StateKnockedDown {
+		t.Errorf("launch alone landed in %v, want StateKnockedDown", got)
+	}
+	// After four juggle hits the stun is down to 10 frames and runs out in
+	// the air, so the target recovers and lands on its feet.
+	if got := land(4); got == beatemup.StateKnockedDown {
+		t.Errorf("after four juggle hits the target was still floored")
+	}
+}
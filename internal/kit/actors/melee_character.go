@@ -2,9 +2,11 @@ package kitactors
 
 import meleeengine "github.com/boilerplate/ebiten-template/internal/kit/combat/melee"
 
-// MeleeCharacter is a reusable trait that holds a melee Controller and provides accessor methods.
+// MeleeCharacter is a reusable trait that holds a melee Controller and an
+// optional Guard and provides accessor methods.
 type MeleeCharacter struct {
 	melee *meleeengine.Controller
+	guard *meleeengine.Guard
 }
 
 // NewMeleeCharacter creates a new MeleeCharacter with melee initialized to nil.
@@ -21,3 +23,19 @@ func (m *MeleeCharacter) MeleeController() *meleeengine.Controller {
 func (m *MeleeCharacter) SetMeleeController(c *meleeengine.Controller) {
 	m.melee = c
 }
+
+// Guard returns the guard (may be nil).
+func (m *MeleeCharacter) Guard() *meleeengine.Guard {
+	return m.guard
+}
+
+// SetGuard assigns the guard field.
+func (m *MeleeCharacter) SetGuard(g *meleeengine.Guard) {
+	m.guard = g
+}
+
+// IsAttacking reports whether the melee controller is mid-attack. It lets a
+// GuardAI react to this character.
+func (m *MeleeCharacter) IsAttacking() bool {
+	return m.melee != nil && m.melee.IsAttacking()
+}
@@ -21,3 +21,21 @@ func TestMeleeCharacter_SetMeleeController_RoundTrip(t *testing.T) {
 		t.Error("MeleeController() did not return the value set via SetMeleeController")
 	}
 }
+
+func TestMeleeCharacter_SetGuard_RoundTrip(t *testing.T) {
+	mc := NewMeleeCharacter()
+	if mc.Guard() != nil {
+		t.Error("expected Guard() to be nil before SetGuard")
+	}
+	g := &meleeengine.Guard{}
+	mc.SetGuard(g)
+	if mc.Guard() != g {
+		t.Error("Guard() did not return the value set via SetGuard")
+	}
+}
+
+func TestMeleeCharacter_IsAttacking_NilController(t *testing.T) {
+	if NewMeleeCharacter().IsAttacking() {
+		t.Error("IsAttacking() = true without a controller")
+	}
+}
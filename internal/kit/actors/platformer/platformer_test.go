@@ -31,6 +31,13 @@ func (s *stubSpace) GetTilemapDimensionsProvider() tilemaplayer.TilemapDimension
 func (s *stubSpace) Query(_ image.Rectangle) []body.Collidable        { return nil }
 func (s *stubSpace) ResolveCollisions(_ body.Collidable) (bool, bool) { return false, false }
 
+func (s *stubSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (s *stubSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+
 func newPlatformerTestCharacter(states ...actors.ActorStateEnum) *actors.Character {
 	img := ebiten.NewImage(1, 1)
 	sMap := sprites.SpriteMap{
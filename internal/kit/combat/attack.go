@@ -0,0 +1,67 @@
+package combat
+
+import (
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/rng"
+)
+
+// DamageType aliases contracts/combat.DamageType for kit callers.
+type DamageType = contractscombat.DamageType
+
+// Attack holds what a projectile type or melee step does to whatever it hits
+// besides its damage amount. The zero value is a plain physical hit.
+type Attack struct {
+	DamageType     DamageType `json:"damage_type,omitempty"`
+	Knockback      int        `json:"knockback,omitempty"`       // fp16 speed pushed along the hit direction
+	KnockbackUp    int        `json:"knockback_up,omitempty"`    // fp16 upward speed added to the push
+	HitStunFrames  int        `json:"hit_stun_frames,omitempty"` // 0 = until the hurt animation ends
+	IFrames        int        `json:"i_frames,omitempty"`        // 0 = the receiver's default
+	CritChance     float64    `json:"crit_chance,omitempty"`     // 0..1
+	CritMultiplier float64    `json:"crit_multiplier,omitempty"` // non-positive = 2
+	Launch         int        `json:"launch,omitempty"`          // fp16 speed lifting a beat-em-up target off the ground
+	GroundBounce   bool       `json:"ground_bounce,omitempty"`   // the launched target bounces once when it lands
+	WallSplat      bool       `json:"wall_splat,omitempty"`      // the launched target sticks to a wall it is knocked into
+	Knockdown      bool       `json:"knockdown,omitempty"`       // a grounded target is floored at once
+}
+
+// Hit builds the DamageInfo for a hit of amount travelling along (dx, dy);
+// only the signs of dx and dy matter. Crits are rolled on the gameplay rng so
+// replays reproduce them.
+func (a Attack) Hit(amount int, source interface{}, dx, dy int) contractscombat.DamageInfo {
+	dirX, dirY := sign(dx), sign(dy)
+	info := contractscombat.DamageInfo{
+		Amount:        amount,
+		Type:          a.DamageType,
+		Source:        source,
+		DirectionX:    dirX,
+		DirectionY:    dirY,
+		KnockbackX16:  dirX * a.Knockback,
+		KnockbackY16:  dirY*a.Knockback - a.KnockbackUp,
+		HitStunFrames: a.HitStunFrames,
+		IFrames:       a.IFrames,
+
+		LaunchAltitude16: a.Launch,
+		GroundBounce:     a.GroundBounce,
+		WallSplat:        a.WallSplat,
+		Knockdown:        a.Knockdown,
+	}
+	if a.CritChance > 0 && rng.Float64() < a.CritChance {
+		mult := a.CritMultiplier
+		if mult <= 0 {
+			mult = 2
+		}
+		info.Crit = true
+		info.Amount = int(float64(amount) * mult)
+	}
+	return info
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
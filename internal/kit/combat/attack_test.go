@@ -0,0 +1,44 @@
+package combat_test
+
+import (
+	"testing"
+
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/rng"
+	"github.com/boilerplate/ebiten-template/internal/kit/combat"
+)
+
+func TestAttack_Hit(t *testing.T) {
+	a := combat.Attack{
+		DamageType: contractscombat.DamageIce, Knockback: 20, KnockbackUp: 8, HitStunFrames: 10, IFrames: 4,
+		Launch: 48, GroundBounce: true, WallSplat: true,
+	}
+
+	got := a.Hit(3, "owner", -50, 7)
+	want := contractscombat.DamageInfo{
+		Amount: 3, Type: contractscombat.DamageIce, Source: "owner",
+		DirectionX: -1, DirectionY: 1,
+		KnockbackX16: -20, KnockbackY16: 12,
+		HitStunFrames: 10, IFrames: 4,
+		LaunchAltitude16: 48, GroundBounce: true, WallSplat: true,
+	}
+	if got != want {
+		t.Errorf("Hit = %+v, want %+v", got, want)
+	}
+
+	if plain := (combat.Attack{}).Hit(1, nil, 16, 0); plain.KnockbackX16 != 0 || plain.Crit {
+		t.Errorf("zero Attack should be a plain hit, got %+v", plain)
+	}
+}
+
+func TestAttack_HitCrits(t *testing.T) {
+	rng.Seed(1)
+	always := combat.Attack{CritChance: 1}
+	if got := always.Hit(3, nil, 1, 0); !got.Crit || got.Amount != 6 {
+		t.Errorf("crit hit = %+v, want a crit for 6", got)
+	}
+	tripled := combat.Attack{CritChance: 1, CritMultiplier: 3}
+	if got := tripled.Hit(3, nil, 1, 0); got.Amount != 9 {
+		t.Errorf("crit amount = %d, want 9", got.Amount)
+	}
+}
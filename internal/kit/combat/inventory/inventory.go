@@ -2,6 +2,7 @@ package inventory
 
 import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/save"
 )
 
 // Inventory manages a collection of weapons with ammo tracking.
@@ -110,3 +111,44 @@ func (i *Inventory) Update() {
 		weapon.Update()
 	}
 }
+
+// State returns a save snapshot of the owned weapon IDs, their ammo and the
+// active index.
+func (i *Inventory) State() save.InventoryState {
+	state := save.InventoryState{
+		Weapons:     make([]string, 0, len(i.weapons)),
+		Ammo:        make(map[string]int, len(i.ammo)),
+		ActiveIndex: i.activeIndex,
+	}
+	for _, w := range i.weapons {
+		state.Weapons = append(state.Weapons, w.ID())
+	}
+	for id, ammo := range i.ammo {
+		state.Ammo[id] = ammo
+	}
+	return state
+}
+
+// Restore replaces the inventory contents with state. Weapons are rebuilt by
+// resolve; IDs it does not recognize are skipped. The saved active weapon
+// stays active, or the first weapon if it was skipped.
+func (i *Inventory) Restore(state save.InventoryState, resolve func(id string) (combat.Weapon, bool)) {
+	i.weapons = i.weapons[:0]
+	i.ammo = make(map[string]int)
+	i.activeIndex = 0
+	active := 0
+	for idx, id := range state.Weapons {
+		w, ok := resolve(id)
+		if !ok {
+			continue
+		}
+		if idx == state.ActiveIndex {
+			active = len(i.weapons)
+		}
+		i.AddWeapon(w)
+		if ammo, ok := state.Ammo[id]; ok {
+			i.ammo[id] = ammo
+		}
+	}
+	i.SwitchTo(active)
+}
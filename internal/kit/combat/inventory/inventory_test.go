@@ -3,7 +3,9 @@ package inventory
 import (
 	"testing"
 
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/mocks"
+	"github.com/boilerplate/ebiten-template/internal/engine/save"
 )
 
 func TestActiveWeaponEmptyInventory(t *testing.T) {
@@ -132,3 +134,77 @@ func TestSwitchToBoundsCheck(t *testing.T) {
 		t.Error("expected activeIndex to remain 1 after invalid SwitchTo(5)")
 	}
 }
+
+func TestStateRestoreRoundTrip(t *testing.T) {
+	w1 := &mocks.MockWeapon{IDFunc: func() string { return "w1" }}
+	w2 := &mocks.MockWeapon{IDFunc: func() string { return "w2" }}
+	weapons := map[string]combat.Weapon{"w1": w1, "w2": w2}
+
+	inv := New()
+	inv.AddWeapon(w1)
+	inv.AddWeapon(w2)
+	inv.SetAmmo("w2", 12)
+	inv.SwitchTo(1)
+
+	state := inv.State()
+
+	restored := New()
+	restored.Restore(state, func(id string) (combat.Weapon, bool) {
+		w, ok := weapons[id]
+		return w, ok
+	})
+
+	if restored.ActiveWeapon() != w2 {
+		t.Error("expected w2 to be active after restore")
+	}
+	if got := restored.GetAmmo("w1"); got != -1 {
+		t.Errorf("w1 ammo = %d, want -1", got)
+	}
+	if got := restored.GetAmmo("w2"); got != 12 {
+		t.Errorf("w2 ammo = %d, want 12", got)
+	}
+}
+
+func TestRestoreSkipsUnknownWeapons(t *testing.T) {
+	w1 := &mocks.MockWeapon{IDFunc: func() string { return "w1" }}
+	inv := New()
+	inv.Restore(save.InventoryState{
+		Weapons:     []string{"gone", "w1"},
+		Ammo:        map[string]int{"gone": 3, "w1": 5},
+		ActiveIndex: 0,
+	}, func(id string) (combat.Weapon, bool) {
+		if id == "w1" {
+			return w1, true
+		}
+		return nil, false
+	})
+
+	if inv.HasWeapon("gone") {
+		t.Error("expected unknown weapon to be skipped")
+	}
+	if inv.ActiveWeapon() != w1 || inv.GetAmmo("w1") != 5 {
+		t.Errorf("expected w1 active with 5 ammo, got %v/%d", inv.ActiveWeapon(), inv.GetAmmo("w1"))
+	}
+}
+
+func TestRestoreKeepsActiveWeaponAfterSkipped(t *testing.T) {
+	weapons := map[string]combat.Weapon{
+		"w1": &mocks.MockWeapon{IDFunc: func() string { return "w1" }},
+		"w2": &mocks.MockWeapon{IDFunc: func() string { return "w2" }},
+	}
+	resolve := func(id string) (combat.Weapon, bool) {
+		w, ok := weapons[id]
+		return w, ok
+	}
+
+	inv := New()
+	inv.Restore(save.InventoryState{Weapons: []string{"gone", "w1", "w2"}, ActiveIndex: 2}, resolve)
+	if inv.ActiveWeapon() != weapons["w2"] {
+		t.Errorf("active = %v, want w2", inv.ActiveWeapon())
+	}
+
+	inv.Restore(save.InventoryState{Weapons: []string{"w1", "gone", "w2"}, ActiveIndex: 1}, resolve)
+	if inv.ActiveWeapon() != weapons["w1"] {
+		t.Errorf("skipped active weapon: active = %v, want the first weapon", inv.ActiveWeapon())
+	}
+}
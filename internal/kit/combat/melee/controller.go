@@ -193,6 +193,12 @@ func (c *Controller) IsBlockingMovement() bool {
 	return c.weapon.IsSwinging() || c.animWait > 0
 }
 
+// IsAttacking reports whether an attack is winding up or mid-swing. A
+// GuardAI watching this actor raises its guard while it is true.
+func (c *Controller) IsAttacking() bool {
+	return c.weapon.IsInStartup() || c.weapon.IsSwinging()
+}
+
 // StepCount returns the number of combo steps in the weapon.
 func (c *Controller) StepCount() int {
 	return len(c.weapon.Steps())
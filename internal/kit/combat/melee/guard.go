@@ -0,0 +1,286 @@
+package melee
+
+import (
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
+	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/data/schemas"
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+)
+
+// Guard defaults, used for non-positive GuardConfig values.
+const (
+	defaultGuardMeter         = 100
+	defaultBlockCost          = 25
+	defaultRegenDelayFrames   = 60
+	defaultRegenPerFrame      = 1
+	defaultParryFrames        = 6
+	defaultParryStaggerFrames = 45
+	defaultBreakStunFrames    = 60
+	defaultReactRange         = 48
+	defaultHoldFrames         = 30
+)
+
+// withGuardDefaults fills the non-positive fields of cfg with defaults.
+func withGuardDefaults(cfg schemas.GuardConfig) schemas.GuardConfig {
+	orDefault := func(v, def int) int {
+		if v <= 0 {
+			return def
+		}
+		return v
+	}
+	cfg.Meter = orDefault(cfg.Meter, defaultGuardMeter)
+	cfg.BlockCost = orDefault(cfg.BlockCost, defaultBlockCost)
+	cfg.RegenDelayFrames = orDefault(cfg.RegenDelayFrames, defaultRegenDelayFrames)
+	cfg.RegenPerFrame = orDefault(cfg.RegenPerFrame, defaultRegenPerFrame)
+	cfg.ParryFrames = orDefault(cfg.ParryFrames, defaultParryFrames)
+	cfg.ParryStaggerFrames = orDefault(cfg.ParryStaggerFrames, defaultParryStaggerFrames)
+	cfg.BreakStunFrames = orDefault(cfg.BreakStunFrames, defaultBreakStunFrames)
+	cfg.ReactRange = orDefault(cfg.ReactRange, defaultReactRange)
+	cfg.HoldFrames = orDefault(cfg.HoldFrames, defaultHoldFrames)
+	return cfg
+}
+
+// Guard lets an actor block hits from the front while its guard is up.
+// Blocked hits drain the guard meter instead of health. A hit that empties the
+// meter, or a guard-break attack, breaks the guard and lands in full with a
+// longer stun; a broken guard cannot be raised until the meter refills.
+// A hit in the first ParryFrames of the guard is parried: projectiles are
+// reflected and melee attackers staggered.
+type Guard struct {
+	char      *actors.Character
+	guardEnum actors.ActorStateEnum
+	cfg       schemas.GuardConfig
+
+	meter      int
+	up         bool
+	upFrames   int // frames since the guard was raised
+	sinceBlock int // frames since the last blocked hit; the meter refills after RegenDelayFrames
+	broken     bool
+}
+
+// NewGuard constructs a Guard with a full meter. guardEnum is the
+// game-registered state shown while the guard is up.
+func NewGuard(guardEnum actors.ActorStateEnum, cfg schemas.GuardConfig) *Guard {
+	cfg = withGuardDefaults(cfg)
+	return &Guard{
+		guardEnum:  guardEnum,
+		cfg:        cfg,
+		meter:      cfg.Meter,
+		sinceBlock: cfg.RegenDelayFrames,
+	}
+}
+
+// Install wires the guard into a Character: it contributes the guard state
+// while up and filters the character's incoming hits.
+func (g *Guard) Install(char *actors.Character) {
+	g.char = char
+	char.AddStateContributor(g)
+	char.HitFilter = g.filter
+}
+
+// ContributeState implements actors.StateContributor. Getting hurt lowers the
+// guard.
+func (g *Guard) ContributeState(current actors.ActorStateEnum) (actors.ActorStateEnum, bool) {
+	if !g.up {
+		return 0, false
+	}
+	if isStunned(current) {
+		g.up = false
+		return 0, false
+	}
+	return g.guardEnum, true
+}
+
+// Raise puts the guard up, opening the parry window if it was down. It reports
+// false while the guard is broken or the character is stunned.
+func (g *Guard) Raise() bool {
+	if g.broken || (g.char != nil && isStunned(g.char.State())) {
+		return false
+	}
+	if !g.up {
+		g.up = true
+		g.upFrames = 0
+	}
+	return true
+}
+
+// Lower puts the guard down.
+func (g *Guard) Lower() { g.up = false }
+
+// HandleInput raises the guard while held is true and lowers it otherwise.
+func (g *Guard) HandleInput(held bool) {
+	if held {
+		g.Raise()
+	} else {
+		g.Lower()
+	}
+}
+
+// Update advances the parry window and refills the meter. Call once per frame.
+func (g *Guard) Update() {
+	if g.up {
+		g.upFrames++
+	}
+	if g.sinceBlock < g.cfg.RegenDelayFrames {
+		g.sinceBlock++
+		return
+	}
+	g.meter = min(g.meter+g.cfg.RegenPerFrame, g.cfg.Meter)
+	if g.broken && g.meter == g.cfg.Meter {
+		g.broken = false
+	}
+}
+
+// IsUp reports whether the guard is up.
+func (g *Guard) IsUp() bool { return g.up }
+
+// IsParrying reports whether a hit now would be parried.
+func (g *Guard) IsParrying() bool { return g.up && g.upFrames < g.cfg.ParryFrames }
+
+// IsBroken reports whether the guard is broken and refilling.
+func (g *Guard) IsBroken() bool { return g.broken }
+
+// Meter returns the current guard meter.
+func (g *Guard) Meter() int { return g.meter }
+
+// MaxMeter returns the guard meter when full.
+func (g *Guard) MaxMeter() int { return g.cfg.Meter }
+
+// filter is installed as the Character's HitFilter.
+func (g *Guard) filter(info contractscombat.DamageInfo) contractscombat.DamageInfo {
+	if !g.up || !g.facing(info) {
+		return info
+	}
+	if g.IsParrying() {
+		g.parry(info)
+		info = absorbed(info)
+		info.Parried = true
+		return info
+	}
+
+	g.sinceBlock = 0
+	g.meter -= g.cfg.BlockCost
+	if info.GuardBreak || g.meter <= 0 {
+		g.meter = 0
+		g.broken = true
+		g.up = false
+		info.HitStunFrames = max(info.HitStunFrames, g.cfg.BreakStunFrames)
+		return info
+	}
+	if info.KnockbackX16 != 0 {
+		// Blocked hits still push the guard back a little.
+		g.char.SetVelocity(info.KnockbackX16/2, 0)
+	}
+	info = absorbed(info)
+	info.Blocked = true
+	return info
+}
+
+// facing reports whether a hit comes from the front: it travels against the
+// character's facing, or has no horizontal direction.
+func (g *Guard) facing(info contractscombat.DamageInfo) bool {
+	if info.DirectionX == 0 {
+		return true
+	}
+	if g.char.FaceDirection() == animation.FaceDirectionLeft {
+		return info.DirectionX > 0
+	}
+	return info.DirectionX < 0
+}
+
+// parry reflects the projectile that carried the hit, or staggers the attacker.
+func (g *Guard) parry(info contractscombat.DamageInfo) {
+	if r, ok := info.Projectile.(contractscombat.Reflector); ok {
+		var owner interface{} = g.char
+		if last := g.char.LastOwner(); last != nil {
+			owner = last
+		}
+		r.Reflect(owner)
+		return
+	}
+	if s, ok := info.Source.(contractscombat.Staggerable); ok {
+		s.Stagger(g.cfg.ParryStaggerFrames)
+	}
+}
+
+// absorbed strips a hit of its damage, knockback and stun.
+func absorbed(info contractscombat.DamageInfo) contractscombat.DamageInfo {
+	info.Amount = 0
+	info.KnockbackX16, info.KnockbackY16 = 0, 0
+	info.HitStunFrames = 0
+	info.Crit = false
+	return info
+}
+
+// isStunned reports whether s is a state the guard cannot be up in.
+func isStunned(s actors.ActorStateEnum) bool {
+	return s == actors.Hurted || s == actors.Dying || s == actors.Dead
+}
+
+// attacker is implemented by targets that report whether they are attacking,
+// such as kitactors.MeleeCharacter.
+type attacker interface {
+	IsAttacking() bool
+}
+
+// GuardAI raises an enemy's guard when its target starts an attack within
+// ReactRange, turning to face it, and keeps the guard up for HoldFrames.
+type GuardAI struct {
+	guard  *Guard
+	target contractsbody.Collidable
+	hold   int
+}
+
+// NewGuardAI constructs a GuardAI driving g, which must be installed.
+func NewGuardAI(g *Guard) *GuardAI {
+	return &GuardAI{guard: g}
+}
+
+// SetTarget sets the body whose attacks the AI guards against.
+func (a *GuardAI) SetTarget(target contractsbody.Collidable) { a.target = target }
+
+// Guard returns the driven guard.
+func (a *GuardAI) Guard() *Guard { return a.guard }
+
+// Update raises or lowers the guard and advances it. Call once per frame
+// instead of Guard.Update.
+func (a *GuardAI) Update() {
+	switch {
+	case a.hold > 0:
+		a.hold--
+		if a.hold == 0 {
+			a.guard.Lower()
+		}
+	case a.threatened() && a.guard.Raise():
+		a.hold = a.guard.cfg.HoldFrames
+		a.faceTarget()
+	}
+	a.guard.Update()
+}
+
+// threatened reports whether the target is attacking within range.
+func (a *GuardAI) threatened() bool {
+	if a.target == nil {
+		return false
+	}
+	atk, ok := a.target.(attacker)
+	if !ok || !atk.IsAttacking() {
+		return false
+	}
+	dx := centerX(a.target) - centerX(a.guard.char)
+	return dx >= -a.guard.cfg.ReactRange && dx <= a.guard.cfg.ReactRange
+}
+
+func (a *GuardAI) faceTarget() {
+	if centerX(a.target) < centerX(a.guard.char) {
+		a.guard.char.SetFaceDirection(animation.FaceDirectionLeft)
+	} else {
+		a.guard.char.SetFaceDirection(animation.FaceDirectionRight)
+	}
+}
+
+func centerX(b contractsbody.Collidable) int {
+	pos := b.Position()
+	return (pos.Min.X + pos.Max.X) / 2
+}
@@ -0,0 +1,243 @@
+package melee_test
+
+import (
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/data/schemas"
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/render/sprites"
+	"github.com/boilerplate/ebiten-template/internal/kit/combat/melee"
+)
+
+//nolint:gochecknoglobals
+var guardState = actors.RegisterState("test_guard", func(b actors.BaseState) actors.ActorState {
+	return &actors.IdleState{BaseState: b}
+})
+
+// newGuardedCharacter builds a right-facing character at x with a guard
+// installed.
+func newGuardedCharacter(id string, x int, cfg schemas.GuardConfig) (*actors.Character, *melee.Guard) {
+	c := actors.NewCharacter(sprites.SpriteMap{}, bodyphysics.NewRect(0, 0, 16, 16))
+	c.SetID(id)
+	c.SetPosition(x, 0)
+	c.SetMaxHealth(10)
+	c.SetHealth(10)
+	g := melee.NewGuard(guardState, cfg)
+	g.Install(c)
+	return c, g
+}
+
+// fromFront is a hit travelling left, into a right-facing character.
+func fromFront(amount int) contractscombat.DamageInfo {
+	return contractscombat.DamageInfo{Amount: amount, DirectionX: -1, KnockbackX16: -32, HitStunFrames: 10}
+}
+
+// guardUpPastParry raises g and runs it past its parry window.
+func guardUpPastParry(g *melee.Guard) {
+	g.Raise()
+	for g.IsParrying() {
+		g.Update()
+	}
+}
+
+type staggerRecorder struct{ frames int }
+
+func (s *staggerRecorder) Stagger(frames int) { s.frames = frames }
+
+type reflectRecorder struct{ owner interface{} }
+
+func (r *reflectRecorder) Reflect(owner interface{}) { r.owner = owner }
+
+func TestGuard_Parry_StaggersAttacker(t *testing.T) {
+	c, g := newGuardedCharacter("defender", 0, schemas.GuardConfig{ParryFrames: 3, ParryStaggerFrames: 40})
+	attacker := &staggerRecorder{}
+	g.Raise()
+
+	info := fromFront(3)
+	info.Source = attacker
+	c.TakeDamage(info)
+
+	if c.Health() != 10 {
+		t.Errorf("health = %d, want 10", c.Health())
+	}
+	if attacker.frames != 40 {
+		t.Errorf("attacker staggered %d frames, want 40", attacker.frames)
+	}
+	if g.Meter() != g.MaxMeter() {
+		t.Errorf("parry drained the meter to %d", g.Meter())
+	}
+}
+
+func TestGuard_Parry_ReflectsProjectile(t *testing.T) {
+	c, g := newGuardedCharacter("defender", 0, schemas.GuardConfig{})
+	c.SetOwner("player")
+	proj := &reflectRecorder{}
+	attacker := &staggerRecorder{}
+	g.Raise()
+
+	var got contractscombat.DamageInfo
+	c.OnHit = func(info contractscombat.DamageInfo, _ int) { got = info }
+	info := fromFront(3)
+	info.Source = attacker
+	info.Projectile = proj
+	c.TakeDamage(info)
+
+	if proj.owner != "player" {
+		t.Errorf("reflected to %v, want the root owner", proj.owner)
+	}
+	if attacker.frames != 0 {
+		t.Error("reflecting a projectile also staggered its shooter")
+	}
+	if !got.Parried || got.Amount != 0 {
+		t.Errorf("OnHit got %+v, want a parried hit with no damage", got)
+	}
+}
+
+func TestGuard_Block_DrainsMeterUntilBroken(t *testing.T) {
+	c, g := newGuardedCharacter("defender", 0, schemas.GuardConfig{Meter: 50, BlockCost: 20, BreakStunFrames: 70})
+	guardUpPastParry(g)
+
+	for i, want := range []int{30, 10} {
+		c.TakeDamage(fromFront(3))
+		if c.Health() != 10 {
+			t.Fatalf("block %d: health = %d, want 10", i, c.Health())
+		}
+		if g.Meter() != want {
+			t.Fatalf("block %d: meter = %d, want %d", i, g.Meter(), want)
+		}
+	}
+
+	c.TakeDamage(fromFront(3))
+	if !g.IsBroken() || g.IsUp() {
+		t.Fatalf("emptied meter: broken=%v up=%v, want broken and down", g.IsBroken(), g.IsUp())
+	}
+	if c.Health() != 7 {
+		t.Errorf("guard-breaking hit: health = %d, want 7", c.Health())
+	}
+	if c.State() != actors.Hurted {
+		t.Errorf("state = %v, want Hurted", c.State())
+	}
+	if g.Raise() {
+		t.Error("Raise succeeded on a broken guard")
+	}
+}
+
+func TestGuard_GuardBreakStep(t *testing.T) {
+	c, g := newGuardedCharacter("defender", 0, schemas.GuardConfig{})
+	guardUpPastParry(g)
+
+	info := fromFront(2)
+	info.GuardBreak = true
+	c.TakeDamage(info)
+
+	if !g.IsBroken() {
+		t.Error("guard-break hit did not break the guard")
+	}
+	if c.Health() != 8 {
+		t.Errorf("health = %d, want 8", c.Health())
+	}
+}
+
+func TestGuard_HitFromBehindLands(t *testing.T) {
+	c, g := newGuardedCharacter("defender", 0, schemas.GuardConfig{})
+	guardUpPastParry(g)
+
+	info := fromFront(2)
+	info.DirectionX = 1
+	c.TakeDamage(info)
+	if c.Health() != 8 {
+		t.Errorf("hit from behind: health = %d, want 8", c.Health())
+	}
+
+	c.SetFaceDirection(animation.FaceDirectionLeft)
+	c.SetInvulnerability(false)
+	c.TakeDamage(info)
+	if c.Health() != 8 {
+		t.Errorf("facing the hit: health = %d, want 8", c.Health())
+	}
+}
+
+func TestGuard_MeterRefillsAfterDelay(t *testing.T) {
+	c, g := newGuardedCharacter("defender", 0, schemas.GuardConfig{Meter: 20, BlockCost: 20, RegenDelayFrames: 5, RegenPerFrame: 4})
+	guardUpPastParry(g)
+	c.TakeDamage(fromFront(1))
+
+	for i := 0; i < 5; i++ {
+		g.Update()
+	}
+	if g.Meter() != 0 || !g.IsBroken() {
+		t.Fatalf("during delay: meter = %d broken = %v", g.Meter(), g.IsBroken())
+	}
+	for i := 0; i < 5; i++ {
+		g.Update()
+	}
+	if g.Meter() != 20 || g.IsBroken() {
+		t.Errorf("after refill: meter = %d broken = %v, want 20 and mended", g.Meter(), g.IsBroken())
+	}
+}
+
+func TestGuard_ContributeState(t *testing.T) {
+	_, g := newGuardedCharacter("defender", 0, schemas.GuardConfig{})
+	if _, ok := g.ContributeState(actors.Idle); ok {
+		t.Error("guard down: ContributeState returned ok")
+	}
+	g.HandleInput(true)
+	if got, ok := g.ContributeState(actors.Idle); !ok || got != guardState {
+		t.Errorf("guard up: ContributeState = %v, %v", got, ok)
+	}
+	if _, ok := g.ContributeState(actors.Hurted); ok || g.IsUp() {
+		t.Error("hurt did not lower the guard")
+	}
+}
+
+type attackingTarget struct {
+	*actors.Character
+	attacking bool
+}
+
+func (a *attackingTarget) IsAttacking() bool { return a.attacking }
+
+func TestGuardAI_RaisesAgainstAttackInRange(t *testing.T) {
+	c, g := newGuardedCharacter("enemy", 100, schemas.GuardConfig{ReactRange: 40, HoldFrames: 3})
+	target, _ := newGuardedCharacter("player", 70, schemas.GuardConfig{})
+	player := &attackingTarget{Character: target}
+	ai := melee.NewGuardAI(g)
+	ai.SetTarget(player)
+
+	ai.Update()
+	if g.IsUp() {
+		t.Fatal("raised the guard while the target was idle")
+	}
+
+	player.attacking = true
+	ai.Update()
+	if !g.IsUp() {
+		t.Fatal("did not raise the guard against an attack in range")
+	}
+	if c.FaceDirection() != animation.FaceDirectionLeft {
+		t.Error("did not turn to face the attacker")
+	}
+
+	player.attacking = false
+	for i := 0; i < 3; i++ {
+		ai.Update()
+	}
+	if g.IsUp() {
+		t.Error("guard still up after HoldFrames")
+	}
+}
+
+func TestGuardAI_IgnoresAttackOutOfRange(t *testing.T) {
+	_, g := newGuardedCharacter("enemy", 100, schemas.GuardConfig{ReactRange: 20})
+	target, _ := newGuardedCharacter("player", 0, schemas.GuardConfig{})
+	ai := melee.NewGuardAI(g)
+	ai.SetTarget(&attackingTarget{Character: target, attacking: true})
+
+	ai.Update()
+	if g.IsUp() {
+		t.Error("raised the guard against an attack out of range")
+	}
+}
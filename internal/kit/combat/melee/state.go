@@ -1,6 +1,8 @@
 package melee
 
 import (
+	"image"
+
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
@@ -16,6 +18,7 @@ type weaponIface interface {
 	IsSwinging() bool
 	IsInStartup() bool
 	ApplyHitbox(space contractsbody.BodiesSpace)
+	ApplyHitboxRects(space contractsbody.BodiesSpace, rects []image.Rectangle)
 	StepIndex() int
 	ComboWindowRemaining() int
 	ResetCombo()
@@ -37,6 +40,13 @@ type ownerIface interface {
 	IsDucking() bool
 }
 
+// frameHitboxer is implemented by owners whose sprite data authors hitboxes
+// per animation frame. Satisfied by actors.Character.
+type frameHitboxer interface {
+	HasFrameHitboxes(state actors.ActorStateEnum) bool
+	Hitboxes() []image.Rectangle
+}
+
 // State is the actor state active during a melee swing.
 type State struct {
 	owner               ownerIface
@@ -167,9 +177,15 @@ func (s *State) OnFinish() {}
 // The state exits when both the animation has played in full AND the weapon is
 // no longer swinging or in startup. This decouples sprite frame count from
 // weapon physical timing: a 2-frame or 20-frame animation both work correctly.
+// When the owner's sprite data authors hitboxes for the step's animation, they
+// replace the step's hitbox and active frames once startup is over.
 func (s *State) Update() actors.ActorStateEnum {
 	s.weapon.Update()
-	if s.weapon.IsHitboxActive() {
+	if fh, ok := s.owner.(frameHitboxer); ok && fh.HasFrameHitboxes(s.activeStepEnum()) {
+		if !s.weapon.IsInStartup() {
+			s.weapon.ApplyHitboxRects(s.space, fh.Hitboxes())
+		}
+	} else if s.weapon.IsHitboxActive() {
 		s.weapon.ApplyHitbox(s.space)
 	}
 	s.frame++
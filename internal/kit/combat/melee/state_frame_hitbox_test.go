@@ -0,0 +1,51 @@
+package melee_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	"github.com/boilerplate/ebiten-template/internal/kit/combat/melee"
+)
+
+// frameHitboxOwner is a mockOwner whose sprite data authors hitboxes for one
+// state.
+type frameHitboxOwner struct {
+	mockOwner
+	state actors.ActorStateEnum
+	boxes []image.Rectangle
+}
+
+func (o *frameHitboxOwner) HasFrameHitboxes(s actors.ActorStateEnum) bool { return s == o.state }
+func (o *frameHitboxOwner) Hitboxes() []image.Rectangle                   { return o.boxes }
+
+func TestState_Update_UsesFrameHitboxes(t *testing.T) {
+	boxes := []image.Rectangle{image.Rect(10, 0, 20, 8)}
+	tests := []struct {
+		name     string
+		boxState actors.ActorStateEnum
+		want     int
+	}{
+		{"active step authors hitboxes", stepState0, 1},
+		{"other step authors hitboxes", stepState1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := &frameHitboxOwner{state: tt.boxState, boxes: boxes}
+			rec := &recordingWeapon{}
+			st := melee.NewState(owner, &mockSpace{}, rec, nil, meleeAttackEnum, actors.Idle, actors.Falling)
+			st.SetStepStates(stepStates())
+			st.SetAnimationFrames(4)
+			st.OnStart(0)
+
+			st.Update()
+
+			if len(rec.appliedRects) != tt.want {
+				t.Fatalf("ApplyHitboxRects called %d times, want %d", len(rec.appliedRects), tt.want)
+			}
+			if tt.want > 0 && (len(rec.appliedRects[0]) != 1 || rec.appliedRects[0][0] != boxes[0]) {
+				t.Errorf("applied %v, want %v", rec.appliedRects[0], boxes)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package melee_test
 
 import (
+	"image"
 	"testing"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
@@ -21,6 +22,7 @@ type recordingWeapon struct {
 	overrideCallCount     int
 	fireCalled            bool
 	overrideSetBeforeFire bool
+	appliedRects          [][]image.Rectangle
 
 	// configurable
 	stepIndex int
@@ -45,9 +47,12 @@ func (r *recordingWeapon) IsHitboxActive() bool           { return false }
 func (r *recordingWeapon) IsSwinging() bool               { return false }
 func (r *recordingWeapon) IsInStartup() bool              { return false }
 func (r *recordingWeapon) ApplyHitbox(_ body.BodiesSpace) {}
-func (r *recordingWeapon) StepIndex() int                 { return r.stepIndex }
-func (r *recordingWeapon) ComboWindowRemaining() int      { return 0 }
-func (r *recordingWeapon) ResetCombo()                    {}
+func (r *recordingWeapon) ApplyHitboxRects(_ body.BodiesSpace, rects []image.Rectangle) {
+	r.appliedRects = append(r.appliedRects, rects)
+}
+func (r *recordingWeapon) StepIndex() int            { return r.stepIndex }
+func (r *recordingWeapon) ComboWindowRemaining() int { return 0 }
+func (r *recordingWeapon) ResetCombo()               {}
 
 // SetActiveFramesOverride is the NEW method this story introduces; the test
 // will fail to compile until the production weaponIface declares it.
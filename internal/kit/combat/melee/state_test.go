@@ -40,6 +40,13 @@ func (s *mockSpace) GetTilemapDimensionsProvider() tilemaplayer.TilemapDimension
 func (s *mockSpace) Find(_ string) body.Collidable                                         { return nil }
 func (s *mockSpace) Query(_ image.Rectangle) []body.Collidable                             { return nil }
 
+func (s *mockSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (s *mockSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+
 func TestState_GetAnimationCount_ProgressesWithTime(t *testing.T) {
 	w := threeStepWeapon()
 	owner := &mockOwner{faceDir: animation.FaceDirectionRight}
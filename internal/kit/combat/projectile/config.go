@@ -13,4 +13,29 @@ type ProjectileConfig struct {
 	DespawnEffect  string               `json:"despawn_effect,omitempty"`  // VFX type for lifetime expiration
 	LifetimeFrames int                  `json:"lifetime_frames,omitempty"` // 0 = infinite (backward compat)
 	Interceptable  bool                 `json:"interceptable,omitempty"`   // true = can be hit by other projectiles
+
+	// Attack sets the damage type, knockback, hit-stun, i-frames and crits
+	// of each hit.
+	enginecombat.Attack
+
+	// Motion behaviors. The zero value flies in a straight line and is
+	// removed by the first thing it hits.
+	Gravity int     `json:"gravity,omitempty"` // fp16 added to the vertical speed every frame
+	Homing  *Homing `json:"homing,omitempty"`  // steer toward the nearest opposing faction
+	Wave    *Wave   `json:"wave,omitempty"`    // sine-wave sway across the flight path
+	Pierce  int     `json:"pierce,omitempty"`  // damageable targets passed through before despawning
+	Bounces int     `json:"bounces,omitempty"` // obstructive bodies bounced off before despawning
+}
+
+// Homing steers a projectile toward the nearest damageable target of an
+// opposing faction. Neutral projectiles never home.
+type Homing struct {
+	Range int     `json:"range"` // search radius in pixels
+	Turn  float64 `json:"turn"`  // 0..1 share of the velocity turned toward the target each frame
+}
+
+// Wave sways a projectile sideways to its initial direction of travel.
+type Wave struct {
+	Amplitude    int `json:"amplitude"`     // peak offset in pixels
+	PeriodFrames int `json:"period_frames"` // frames per full oscillation
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	enginecombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
 )
 
@@ -341,3 +342,59 @@ func TestProjectile_PassthroughBodiesIgnored(t *testing.T) {
 		})
 	}
 }
+
+// TestProjectile_HitCarriesAttack verifies the hit passes the configured
+// attack along the direction of travel.
+func TestProjectile_HitCarriesAttack(t *testing.T) {
+	mgr := NewManager(&mockBodiesSpace{})
+	owner := &fakeDamageable{}
+	mgr.Spawn(ProjectileConfig{
+		Width: 2, Height: 1, Damage: 4,
+		Attack: enginecombat.Attack{DamageType: contractscombat.DamageFire, Knockback: 16, HitStunFrames: 12},
+	}, 0, 0, -48, 0, owner)
+	target := &fakeDamageableBody{id: "target"}
+
+	mgr.projectiles[0].OnTouch(target)
+
+	got := target.lastHit
+	if got.Amount != 4 || got.Type != contractscombat.DamageFire || got.Source != owner {
+		t.Errorf("hit = %+v", got)
+	}
+	if got.DirectionX != -1 || got.KnockbackX16 != -16 || got.HitStunFrames != 12 {
+		t.Errorf("hit direction/knockback = %+v", got)
+	}
+}
+
+// parryingBody is a damageable body that parries every projectile hit.
+type parryingBody struct {
+	fakeDamageableBody
+}
+
+func (p *parryingBody) TakeDamage(info contractscombat.DamageInfo) {
+	if r, ok := info.Projectile.(contractscombat.Reflector); ok {
+		r.Reflect(p)
+	}
+}
+
+// TestProjectile_ReflectTurnsAround verifies a parried projectile survives,
+// reverses and changes side.
+func TestProjectile_ReflectTurnsAround(t *testing.T) {
+	space := &mockBodiesSpace{}
+	mgr := NewManager(space)
+	shooter := &fakeDamageable{faction: enginecombat.FactionEnemy}
+	mgr.Spawn(ProjectileConfig{Width: 2, Height: 1, Damage: 1}, 0, 0, 48, 16, shooter)
+	p := mgr.projectiles[0]
+	parrier := &parryingBody{fakeDamageableBody{id: "player", faction: enginecombat.FactionPlayer}}
+
+	p.OnTouch(parrier)
+
+	if len(space.queuedForRemoval) != 0 {
+		t.Fatal("reflected projectile was removed")
+	}
+	if p.speedX16 != -48 || p.speedY16 != -16 {
+		t.Errorf("speed = (%d, %d), want (-48, -16)", p.speedX16, p.speedY16)
+	}
+	if p.body.Owner() != parrier || p.faction != enginecombat.FactionPlayer {
+		t.Errorf("owner = %v faction = %v, want the parrier's", p.body.Owner(), p.faction)
+	}
+}
@@ -3,6 +3,7 @@ package projectile
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
@@ -12,14 +13,6 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-// getDefaultBulletImg creates and returns a white 2x1 image.
-// This is called per Draw to avoid global state.
-func getDefaultBulletImg() *ebiten.Image {
-	img := ebiten.NewImage(2, 1)
-	img.Fill(color.White)
-	return img
-}
-
 // Manager handles the lifecycle of all projectiles in the game.
 type Manager struct {
 	projectiles   []*projectile
@@ -28,6 +21,8 @@ type Manager struct {
 	vfxManager    contractsvfx.Manager
 	impactEffect  string
 	despawnEffect string
+	registry      *Registry
+	blankImages   map[image.Point]*ebiten.Image // white hitbox-sized images for sprite-less projectiles
 }
 
 // NewManager creates a new projectile manager.
@@ -45,13 +40,26 @@ func (m *Manager) SetVFXManager(v contractsvfx.Manager) {
 	m.vfxManager = v
 }
 
+// SetRegistry sets the projectile types SpawnProjectile picks from.
+func (m *Manager) SetRegistry(r *Registry) {
+	m.registry = r
+}
+
+// Registry returns the projectile types SpawnProjectile picks from.
+func (m *Manager) Registry() *Registry {
+	return m.registry
+}
+
 // Spawn creates a new projectile and registers it in the physics space.
 func (m *Manager) Spawn(cfg interface{}, x16, y16, vx16, vy16 int, owner interface{}) {
 	config, ok := cfg.(ProjectileConfig)
 	if !ok {
 		return
 	}
+	m.spawn(config, x16, y16, vx16, vy16, owner)
+}
 
+func (m *Manager) spawn(config ProjectileConfig, x16, y16, vx16, vy16 int, owner interface{}) *projectile {
 	m.counter++
 	id := fmt.Sprintf("bullet_%d", m.counter)
 
@@ -105,8 +113,16 @@ func (m *Manager) Spawn(cfg interface{}, x16, y16, vx16, vy16 int, owner interfa
 		lifetimeFrames:  lifetime,
 		currentLifetime: lifetime,
 		damage:          config.Damage,
+		attack:          config.Attack,
 		faction:         faction,
 		interceptable:   config.Interceptable,
+		gravity16:       config.Gravity,
+		homing:          config.Homing,
+		pierceLeft:      max(config.Pierce, 0),
+		bouncesLeft:     max(config.Bounces, 0),
+	}
+	if config.Wave != nil {
+		p.setWave(config.Wave, vx16, vy16)
 	}
 
 	// Register collision callbacks
@@ -114,12 +130,24 @@ func (m *Manager) Spawn(cfg interface{}, x16, y16, vx16, vy16 int, owner interfa
 
 	m.projectiles = append(m.projectiles, p)
 	m.space.AddBody(wrappedBody)
+	return p
 }
 
-// SpawnProjectile implements the ProjectileManager interface.
+// SpawnProjectile implements the ProjectileManager interface. The type is
+// looked up in the registry; a non-zero damage overrides the type's own.
+// Unknown types fall back to a plain 2x1 bullet.
 func (m *Manager) SpawnProjectile(projectileType string, x16, y16, vx16, vy16, damage int, owner interface{}) {
-	cfg := ProjectileConfig{Width: 2, Height: 1, Damage: damage}
-	m.Spawn(cfg, x16, y16, vx16, vy16, owner)
+	t, ok := m.registry.Lookup(projectileType)
+	if !ok {
+		m.spawn(ProjectileConfig{Width: 2, Height: 1, Damage: damage}, x16, y16, vx16, vy16, owner)
+		return
+	}
+	cfg := t.Config
+	if damage != 0 {
+		cfg.Damage = damage
+	}
+	p := m.spawn(cfg, x16, y16, vx16, vy16, owner)
+	p.image = t.image
 }
 
 // Update advances all active projectiles and removes those that are despawned.
@@ -153,24 +181,46 @@ func (m *Manager) isBodyInSpace(b body.Collidable) bool {
 // For world-space rendering, the caller is expected to provide a translated screen
 // or the interface should be updated to include a camera.
 func (m *Manager) Draw(screen *ebiten.Image) {
-	for _, p := range m.projectiles {
-		opts := &ebiten.DrawImageOptions{}
-		x, y := p.body.GetPositionMin()
-		opts.GeoM.Translate(float64(x), float64(y))
-		screen.DrawImage(getDefaultBulletImg(), opts)
-	}
+	m.DrawWithOffset(screen, 0, 0)
 }
 
 // DrawWithOffset renders all active projectiles with camera offset applied.
+// Sprites face the direction of horizontal travel.
 func (m *Manager) DrawWithOffset(screen *ebiten.Image, camX, camY float64) {
 	for _, p := range m.projectiles {
+		img := p.image
+		if img == nil {
+			img = m.blankImage(p.body.GetShape())
+		}
 		opts := &ebiten.DrawImageOptions{}
+		if p.speedX16 < 0 {
+			opts.GeoM.Scale(-1, 1)
+			opts.GeoM.Translate(float64(img.Bounds().Dx()), 0)
+		}
 		x, y := p.body.GetPositionMin()
 		opts.GeoM.Translate(float64(x)-camX, float64(y)-camY)
-		screen.DrawImage(getDefaultBulletImg(), opts)
+		screen.DrawImage(img, opts)
 	}
 }
 
+// blankImage returns a cached white image the size of shape.
+func (m *Manager) blankImage(shape body.Shape) *ebiten.Image {
+	size := image.Pt(2, 1)
+	if shape != nil && shape.Width() > 0 && shape.Height() > 0 {
+		size = image.Pt(shape.Width(), shape.Height())
+	}
+	if img, ok := m.blankImages[size]; ok {
+		return img
+	}
+	if m.blankImages == nil {
+		m.blankImages = make(map[image.Point]*ebiten.Image)
+	}
+	img := ebiten.NewImage(size.X, size.Y)
+	img.Fill(color.White)
+	m.blankImages[size] = img
+	return img
+}
+
 // DrawCollisionBoxesWithOffset renders each active projectile's collision box
 // using the given camera-space draw helper. The helper is invoked once per
 // active projectile body.
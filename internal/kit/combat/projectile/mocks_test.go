@@ -19,8 +19,10 @@ type mockBodiesSpace struct {
 	AddBodyFunc         func(body.Collidable)
 	QueueForRemovalFunc func(body.Collidable)
 	RemoveBodyFunc      func(body.Collidable)
+	ResolveFunc         func(body.Collidable)
 	tilemapProvider     tilemaplayer.TilemapDimensionsProvider
 	queuedForRemoval    []body.Collidable
+	queryResult         []body.Collidable
 }
 
 func (m *mockBodiesSpace) AddBody(b body.Collidable) {
@@ -53,8 +55,13 @@ func (m *mockBodiesSpace) ProcessRemovals() {
 	}
 	m.queuedForRemoval = nil
 }
-func (m *mockBodiesSpace) Clear()                                         {}
-func (m *mockBodiesSpace) ResolveCollisions(body.Collidable) (bool, bool) { return false, false }
+func (m *mockBodiesSpace) Clear() {}
+func (m *mockBodiesSpace) ResolveCollisions(b body.Collidable) (bool, bool) {
+	if m.ResolveFunc != nil {
+		m.ResolveFunc(b)
+	}
+	return false, false
+}
 func (m *mockBodiesSpace) SetTilemapDimensionsProvider(p tilemaplayer.TilemapDimensionsProvider) {
 	m.tilemapProvider = p
 }
@@ -69,7 +76,13 @@ func (m *mockBodiesSpace) Find(id string) body.Collidable {
 	}
 	return nil
 }
-func (m *mockBodiesSpace) Query(image.Rectangle) []body.Collidable { return nil }
+func (m *mockBodiesSpace) Query(image.Rectangle) []body.Collidable { return m.queryResult }
+func (m *mockBodiesSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (m *mockBodiesSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
 
 // mockTilemapDimensionsProvider implements tilemaplayer.TilemapDimensionsProvider.
 type mockTilemapDimensionsProvider struct {
@@ -171,6 +184,13 @@ func (m *mockCollidable) ApplyValidPosition(_ int, _ bool, _ body.BodiesSpace) (
 	return 0, 0, false
 }
 
+// mockWall is an obstructive mockCollidable.
+type mockWall struct {
+	mockCollidable
+}
+
+func (m *mockWall) IsObstructive() bool { return true }
+
 // fakeDamageable implements contractscombat.Damageable and tracks calls.
 // It also provides a Faction() method for faction checks.
 type fakeDamageable struct {
@@ -178,8 +198,8 @@ type fakeDamageable struct {
 	faction         combat.Faction
 }
 
-func (f *fakeDamageable) TakeDamage(amount int) {
-	f.takeDamageCalls = append(f.takeDamageCalls, amount)
+func (f *fakeDamageable) TakeDamage(info contractscombat.DamageInfo) {
+	f.takeDamageCalls = append(f.takeDamageCalls, info.Amount)
 }
 
 func (f *fakeDamageable) Faction() combat.Faction {
@@ -191,6 +211,7 @@ type fakeDamageableBody struct {
 	id              string
 	owner           interface{}
 	takeDamageCalls []int
+	lastHit         contractscombat.DamageInfo
 	faction         combat.Faction
 }
 
@@ -222,8 +243,9 @@ func (f *fakeDamageableBody) SetTouchable(_ body.Touchable)
 func (f *fakeDamageableBody) ApplyValidPosition(_ int, _ bool, _ body.BodiesSpace) (int, int, bool) {
 	return 0, 0, false
 }
-func (f *fakeDamageableBody) TakeDamage(amount int) {
-	f.takeDamageCalls = append(f.takeDamageCalls, amount)
+func (f *fakeDamageableBody) TakeDamage(info contractscombat.DamageInfo) {
+	f.takeDamageCalls = append(f.takeDamageCalls, info.Amount)
+	f.lastHit = info
 }
 func (f *fakeDamageableBody) Faction() combat.Faction {
 	return f.faction
@@ -237,8 +259,8 @@ type fakeDestructible struct {
 	faction         combat.Faction
 }
 
-func (f *fakeDestructible) TakeDamage(amount int) {
-	f.takeDamageCalls = append(f.takeDamageCalls, amount)
+func (f *fakeDestructible) TakeDamage(info contractscombat.DamageInfo) {
+	f.takeDamageCalls = append(f.takeDamageCalls, info.Amount)
 }
 
 func (f *fakeDestructible) IsDestroyed() bool {
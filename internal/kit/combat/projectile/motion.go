@@ -0,0 +1,108 @@
+package projectile
+
+import (
+	"image"
+	"math"
+
+	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
+	enginecombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
+)
+
+// setWave starts the sine sway, measured across the direction of
+// (vx16, vy16). A projectile spawned at rest sways vertically.
+func (p *projectile) setWave(w *Wave, vx16, vy16 int) {
+	p.wave = w
+	p.waveNormX, p.waveNormY = 0, 1
+	if speed := math.Hypot(float64(vx16), float64(vy16)); speed > 0 {
+		p.waveNormX = -float64(vy16) / speed
+		p.waveNormY = float64(vx16) / speed
+	}
+}
+
+// waveStep returns the fp16 offset to add this frame so the projectile sits
+// on a sine curve around its straight path.
+func (p *projectile) waveStep() (dx16, dy16 int) {
+	if p.wave == nil || p.wave.PeriodFrames <= 0 {
+		return 0, 0
+	}
+	off := float64(fp16.To16(p.wave.Amplitude)) * math.Sin(2*math.Pi*float64(p.age)/float64(p.wave.PeriodFrames))
+	offX16 := int(math.Round(off * p.waveNormX))
+	offY16 := int(math.Round(off * p.waveNormY))
+	dx16, dy16 = offX16-p.waveOffX16, offY16-p.waveOffY16
+	p.waveOffX16, p.waveOffY16 = offX16, offY16
+	return dx16, dy16
+}
+
+// steer turns the velocity toward the nearest homing target, keeping the
+// speed unchanged.
+func (p *projectile) steer() {
+	if p.homing == nil {
+		return
+	}
+	target, ok := p.homingTarget()
+	if !ok {
+		return
+	}
+	x, y := p.center()
+	tx, ty := centerOf(target)
+	dx, dy := float64(tx-x), float64(ty-y)
+	dist := math.Hypot(dx, dy)
+	vx, vy := float64(p.speedX16), float64(p.speedY16)
+	speed := math.Hypot(vx, vy)
+	if dist == 0 || speed == 0 {
+		return
+	}
+	vx += (dx/dist*speed - vx) * p.homing.Turn
+	vy += (dy/dist*speed - vy) * p.homing.Turn
+	if n := math.Hypot(vx, vy); n > 0 {
+		vx, vy = vx/n*speed, vy/n*speed
+	}
+	p.speedX16 = int(math.Round(vx))
+	p.speedY16 = int(math.Round(vy))
+}
+
+// homingTarget returns the nearest damageable body within range whose
+// faction opposes the projectile's. Ties go to the lowest body ID.
+func (p *projectile) homingTarget() (contractsbody.Collidable, bool) {
+	if p.faction == enginecombat.FactionNeutral {
+		return nil, false
+	}
+	x, y := p.center()
+	r := p.homing.Range
+	var (
+		best     contractsbody.Collidable
+		bestDist = math.MaxInt
+	)
+	for _, other := range p.space.Query(image.Rect(x-r, y-r, x+r+1, y+r+1)) {
+		if other == nil || other.ID() == p.body.ID() || p.isOwner(other) || isPassthrough(other) {
+			continue
+		}
+		if isProj, _ := isProjectile(other); isProj {
+			continue
+		}
+		_, faction, ok := p.resolveDamageable(other)
+		if !ok || faction == enginecombat.FactionNeutral || faction == p.faction {
+			continue
+		}
+		ox, oy := centerOf(other)
+		if d := (ox-x)*(ox-x) + (oy-y)*(oy-y); d < bestDist && d <= r*r {
+			best, bestDist = other, d
+		}
+	}
+	return best, best != nil
+}
+
+func (p *projectile) center() (int, int) {
+	return centerOf(p.body)
+}
+
+// centerOf returns the pixel center of b's shape, or its position when it
+// has none.
+func centerOf(b contractsbody.Collidable) (int, int) {
+	x, y := b.GetPositionMin()
+	if shape := b.GetShape(); shape != nil {
+		return x + shape.Width()/2, y + shape.Height()/2
+	}
+	return x, y
+}
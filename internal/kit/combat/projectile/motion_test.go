@@ -0,0 +1,112 @@
+package projectile
+
+import (
+	"testing"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/kit/combat"
+)
+
+func TestProjectile_GravityArcs(t *testing.T) {
+	mgr := NewManager(&mockBodiesSpace{})
+	mgr.Spawn(ProjectileConfig{Width: 2, Height: 2, Gravity: 2}, 0, 0, 16, -4, nil)
+	p := mgr.projectiles[0]
+
+	for range 4 {
+		p.Update()
+	}
+	if p.speedY16 != 4 {
+		t.Errorf("speedY16 = %d, want 4", p.speedY16)
+	}
+	// Vertical steps -2, 0, 2, 4.
+	if x, y := p.body.GetPosition16(); x != 64 || y != 4 {
+		t.Errorf("position = (%d, %d), want (64, 4)", x, y)
+	}
+}
+
+func TestProjectile_WaveSwaysAcrossPath(t *testing.T) {
+	mgr := NewManager(&mockBodiesSpace{})
+	mgr.Spawn(ProjectileConfig{Width: 2, Height: 2, Wave: &Wave{Amplitude: 4, PeriodFrames: 4}}, 0, 0, 16, 0, nil)
+	p := mgr.projectiles[0]
+
+	wantY := []int{64, 0, -64, 0}
+	for i, want := range wantY {
+		p.Update()
+		x, y := p.body.GetPosition16()
+		if x != 16*(i+1) || y != want {
+			t.Errorf("frame %d: position = (%d, %d), want (%d, %d)", i+1, x, y, 16*(i+1), want)
+		}
+	}
+}
+
+func TestProjectile_HomingTurnsTowardOpposingFaction(t *testing.T) {
+	friend := &fakeDamageableBody{id: "friend", faction: combat.FactionEnemy}
+	foe := &fakeDamageableBody{id: "foe", faction: combat.FactionPlayer}
+	space := &mockBodiesSpace{queryResult: []body.Collidable{friend, foe}}
+	mgr := NewManager(space)
+	mgr.Spawn(ProjectileConfig{
+		Width: 1, Height: 1, Faction: combat.FactionEnemy,
+		Homing: &Homing{Range: 64, Turn: 1},
+	}, 0, 32<<4, 16, 0, nil)
+	p := mgr.projectiles[0]
+
+	p.Update()
+	// The foe sits straight above at (0, 0): full turn points the velocity up.
+	if p.speedX16 != 0 || p.speedY16 != -16 {
+		t.Errorf("speed = (%d, %d), want (0, -16)", p.speedX16, p.speedY16)
+	}
+
+	mgr.Spawn(ProjectileConfig{Width: 1, Height: 1, Homing: &Homing{Range: 64, Turn: 1}}, 0, 32<<4, 16, 0, nil)
+	neutral := mgr.projectiles[1]
+	neutral.Update()
+	if neutral.speedX16 != 16 || neutral.speedY16 != 0 {
+		t.Errorf("neutral projectile should not home, speed = (%d, %d)", neutral.speedX16, neutral.speedY16)
+	}
+}
+
+func TestProjectile_PierceHitsEachTargetOnce(t *testing.T) {
+	space := &mockBodiesSpace{}
+	mgr := NewManager(space)
+	mgr.Spawn(ProjectileConfig{Width: 2, Height: 1, Damage: 1, Pierce: 1}, 0, 0, 16, 0, nil)
+	p := mgr.projectiles[0]
+	first := &fakeDamageableBody{id: "first"}
+	second := &fakeDamageableBody{id: "second"}
+
+	p.OnTouch(first)
+	p.OnTouch(first)
+	if len(first.takeDamageCalls) != 1 || len(space.queuedForRemoval) != 0 {
+		t.Fatalf("after piercing: first hit %d times, %d removals", len(first.takeDamageCalls), len(space.queuedForRemoval))
+	}
+	p.OnTouch(second)
+	if len(second.takeDamageCalls) != 1 || len(space.queuedForRemoval) != 1 {
+		t.Errorf("out of pierces: second hit %d times, %d removals", len(second.takeDamageCalls), len(space.queuedForRemoval))
+	}
+}
+
+func TestProjectile_BouncesOffObstructiveBodies(t *testing.T) {
+	wall := &mockWall{mockCollidable{id: "wall"}}
+	space := &mockBodiesSpace{}
+	space.ResolveFunc = func(b body.Collidable) {
+		if x, _ := b.GetPosition16(); x >= 32 {
+			b.GetTouchable().OnTouch(wall)
+			b.GetTouchable().OnBlock(wall)
+		}
+	}
+	mgr := NewManager(space)
+	mgr.Spawn(ProjectileConfig{Width: 1, Height: 1, Bounces: 1}, 16, 0, 16, 0, nil)
+	p := mgr.projectiles[0]
+
+	p.Update()
+	if x, _ := p.body.GetPosition16(); x != 16 || p.speedX16 != -16 {
+		t.Errorf("after bounce: x = %d, speed = %d, want 16 and -16", x, p.speedX16)
+	}
+	if len(space.queuedForRemoval) != 0 {
+		t.Fatal("bouncing projectile was removed")
+	}
+
+	p.speedX16 = 16
+	p.Update()
+	if len(space.queuedForRemoval) == 0 {
+		t.Error("expected removal once bounces run out")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	contractsvfx "github.com/boilerplate/ebiten-template/internal/engine/contracts/vfx"
 	enginecombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // factioned is a file-local interface for entities that expose their faction.
@@ -60,6 +61,14 @@ type projectileBody struct {
 
 func (pb *projectileBody) Interceptable() bool { return pb.interceptable }
 
+// SetChangeObserver forwards to the wrapped body so the space can index
+// projectiles incrementally.
+func (pb *projectileBody) SetChangeObserver(fn func()) {
+	if o, ok := pb.Collidable.(contractsbody.ChangeObservable); ok {
+		o.SetChangeObserver(fn)
+	}
+}
+
 // projectile is the internal state of a spawned projectile.
 type projectile struct {
 	movable         contractsbody.Movable
@@ -73,26 +82,53 @@ type projectile struct {
 	lifetimeFrames  int // configured total lifetime (0 = infinite)
 	currentLifetime int // frames remaining; only meaningful when lifetimeFrames > 0
 	damage          int
+	attack          enginecombat.Attack
 	faction         enginecombat.Faction
 	interceptable   bool
+	image           *ebiten.Image // nil draws a white rectangle of the hitbox size
+
+	gravity16   int
+	homing      *Homing
+	wave        *Wave
+	waveNormX   float64 // unit normal to the initial direction of travel
+	waveNormY   float64
+	waveOffX16  int // wave offset applied so far
+	waveOffY16  int
+	age         int
+	pierceLeft  int
+	bouncesLeft int
+	bounced     bool
+	removed     bool
+	hits        map[string]bool // targets already pierced
+	reflected   bool            // set by Reflect during the current hit
 }
 
 func (p *projectile) Interceptable() bool { return p.interceptable }
 
 func (p *projectile) Update() {
-	x, y := p.body.GetPosition16()
-	x += p.speedX16
-	y += p.speedY16
-	p.body.SetPosition16(x, y)
+	p.age++
+	p.steer()
+	p.speedY16 += p.gravity16
+	waveX16, waveY16 := p.waveStep()
 
-	p.space.ResolveCollisions(p.body)
+	if p.bouncesLeft > 0 {
+		// Move one axis at a time so a bounce knows which speed to reflect.
+		p.moveAxis(p.speedX16+waveX16, 0)
+		if !p.removed {
+			p.moveAxis(0, p.speedY16+waveY16)
+		}
+	} else {
+		x, y := p.body.GetPosition16()
+		p.body.SetPosition16(x+p.speedX16+waveX16, y+p.speedY16+waveY16)
+		p.space.ResolveCollisions(p.body)
+	}
+	x, y := p.body.GetPosition16()
 
 	// Lifetime tick: if lifetimeFrames > 0, decrement and despawn when expired.
 	if p.lifetimeFrames > 0 {
 		p.currentLifetime--
 		if p.currentLifetime <= 0 {
-			p.spawnVFX(p.despawnEffect)
-			p.space.QueueForRemoval(p.body)
+			p.despawn(p.despawnEffect)
 			return
 		}
 	}
@@ -106,7 +142,25 @@ func (p *projectile) Update() {
 
 	// Bounds are in fp16 units (scale factor 16: 1 pixel = 16 units)
 	if x < 0 || y < 0 || x > w<<4 || y > h<<4 {
-		p.space.QueueForRemoval(p.body)
+		p.despawn("")
+	}
+}
+
+// moveAxis moves the projectile by (dx16, dy16) and resolves collisions,
+// undoing the move and reflecting that axis' speed when it bounced.
+func (p *projectile) moveAxis(dx16, dy16 int) {
+	x, y := p.body.GetPosition16()
+	p.body.SetPosition16(x+dx16, y+dy16)
+	p.bounced = false
+	p.space.ResolveCollisions(p.body)
+	if !p.bounced {
+		return
+	}
+	p.body.SetPosition16(x, y)
+	if dx16 != 0 {
+		p.speedX16 = -p.speedX16
+	} else {
+		p.speedY16 = -p.speedY16
 	}
 }
 
@@ -120,9 +174,19 @@ func (p *projectile) OnTouch(other contractsbody.Collidable) {
 	if isProj, interceptable := isProjectile(other); isProj && !interceptable {
 		return
 	}
-	p.applyDamage(other)
-	p.spawnVFX(p.impactEffect)
-	p.space.QueueForRemoval(p.body)
+	if other != nil && other.IsObstructive() && p.bouncesLeft > 0 {
+		// OnBlock follows and bounces off it.
+		return
+	}
+	if other != nil && (p.pierceLeft > 0 || p.hits[other.ID()]) {
+		if p.pierce(other) {
+			return
+		}
+	}
+	if p.hit(other) {
+		return
+	}
+	p.despawn(p.impactEffect)
 }
 
 func (p *projectile) OnBlock(other contractsbody.Collidable) {
@@ -135,9 +199,68 @@ func (p *projectile) OnBlock(other contractsbody.Collidable) {
 	if isProj, interceptable := isProjectile(other); isProj && !interceptable {
 		return
 	}
-	p.applyDamage(other)
+	if p.bouncesLeft > 0 {
+		p.bouncesLeft--
+		p.bounced = true
+		return
+	}
+	if p.hit(other) {
+		return
+	}
+	p.despawn(p.impactEffect)
+}
+
+// pierce damages a non-obstructive target and keeps flying through it.
+// A target already pierced is ignored while the projectile overlaps it.
+// It reports false when other cannot be pierced.
+func (p *projectile) pierce(other contractsbody.Collidable) bool {
+	if other.IsObstructive() {
+		return false
+	}
+	if p.hits[other.ID()] {
+		return true
+	}
+	if _, _, ok := p.resolveDamageable(other); !ok {
+		return false
+	}
+	if p.hit(other) {
+		return true
+	}
 	p.spawnVFX(p.impactEffect)
+	p.pierceLeft--
+	if p.hits == nil {
+		p.hits = make(map[string]bool)
+	}
+	p.hits[other.ID()] = true
+	return true
+}
+
+// hit damages other and reports whether the projectile survived because the
+// target parried it back.
+func (p *projectile) hit(other contractsbody.Collidable) bool {
+	p.applyDamage(other)
+	reflected := p.reflected
+	p.reflected = false
+	return reflected
+}
+
+// Reflect implements contracts/combat.Reflector: the projectile turns around
+// and fights for owner, so it can hit whoever fired it.
+func (p *projectile) Reflect(owner interface{}) {
+	p.speedX16, p.speedY16 = -p.speedX16, -p.speedY16
+	p.body.SetOwner(owner)
+	if f, ok := owner.(factioned); ok {
+		p.faction = f.Faction()
+	}
+	p.hits = nil
+	p.reflected = true
+}
+
+// despawn spawns effect and queues the projectile for removal.
+func (p *projectile) despawn(effect string) {
+	p.spawnVFX(effect)
 	p.space.QueueForRemoval(p.body)
+	p.removed = true
 }
 
 // isOwner returns true if the other body is the projectile's owner or belongs to it.
@@ -202,7 +325,9 @@ func (p *projectile) applyDamage(other contractsbody.Collidable) {
 		return
 	}
 
-	target.TakeDamage(p.damage)
+	info := p.attack.Hit(p.damage, p.body.Owner(), p.speedX16, p.speedY16)
+	info.Projectile = p
+	target.TakeDamage(info)
 }
 
 // resolveDamageable tries (1) the body itself, then (2) body.Owner().
@@ -0,0 +1,155 @@
+package projectile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	enginecombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Type is a named projectile definition: the config every spawn of that
+// type starts from and the sprite drawn for it.
+type Type struct {
+	Name   string
+	Sprite string
+	Config ProjectileConfig
+
+	image *ebiten.Image
+}
+
+// Registry holds projectile types keyed by name.
+type Registry struct {
+	types map[string]*Type
+}
+
+type typeData struct {
+	Sprite         string               `json:"sprite"`
+	Width          int                  `json:"width"`
+	Height         int                  `json:"height"`
+	Damage         int                  `json:"damage"`
+	Faction        enginecombat.Faction `json:"faction"`
+	ImpactEffect   string               `json:"impact_effect"`
+	DespawnEffect  string               `json:"despawn_effect"`
+	LifetimeFrames int                  `json:"lifetime_frames"`
+	Interceptable  bool                 `json:"interceptable"`
+	Gravity        int                  `json:"gravity"`
+	Homing         *Homing              `json:"homing"`
+	Wave           *Wave                `json:"wave"`
+	Pierce         int                  `json:"pierce"`
+	Bounces        int                  `json:"bounces"`
+	enginecombat.Attack
+}
+
+// NewRegistry returns a registry holding types.
+func NewRegistry(types ...Type) *Registry {
+	r := &Registry{types: make(map[string]*Type, len(types))}
+	for i := range types {
+		t := types[i]
+		r.types[t.Name] = &t
+	}
+	return r
+}
+
+// LoadRegistry reads projectile types from a JSON object in fsys keyed by
+// type name, validates them and loads their sprites. Types without a sprite
+// are drawn as a white rectangle of their hitbox size.
+func LoadRegistry(fsys fs.FS, path string) (*Registry, error) {
+	raw, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]typeData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	r := &Registry{types: make(map[string]*Type, len(data))}
+	var errs []error
+	for name, d := range data {
+		t := &Type{
+			Name:   name,
+			Sprite: d.Sprite,
+			Config: ProjectileConfig{
+				Width:          d.Width,
+				Height:         d.Height,
+				Damage:         d.Damage,
+				Faction:        d.Faction,
+				ImpactEffect:   d.ImpactEffect,
+				DespawnEffect:  d.DespawnEffect,
+				LifetimeFrames: d.LifetimeFrames,
+				Interceptable:  d.Interceptable,
+				Gravity:        d.Gravity,
+				Homing:         d.Homing,
+				Wave:           d.Wave,
+				Pierce:         d.Pierce,
+				Bounces:        d.Bounces,
+				Attack:         d.Attack,
+			},
+		}
+		if t.Sprite != "" {
+			img, _, err := ebitenutil.NewImageFromFileSystem(fsys, t.Sprite)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("projectile %q: %w", name, err))
+			}
+			t.image = img
+		}
+		r.types[name] = t
+	}
+	errs = append(errs, r.Validate())
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("loading projectiles from %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Validate checks that every type has a hitbox and sane behavior settings.
+func (r *Registry) Validate() error {
+	var errs []error
+	for _, name := range r.Names() {
+		cfg := r.types[name].Config
+		if cfg.Width <= 0 || cfg.Height <= 0 {
+			errs = append(errs, fmt.Errorf("projectile %q: hitbox must be positive, got %dx%d", name, cfg.Width, cfg.Height))
+		}
+		if cfg.Pierce < 0 {
+			errs = append(errs, fmt.Errorf("projectile %q: pierce must not be negative", name))
+		}
+		if cfg.Bounces < 0 {
+			errs = append(errs, fmt.Errorf("projectile %q: bounces must not be negative", name))
+		}
+		if h := cfg.Homing; h != nil && (h.Range <= 0 || h.Turn <= 0 || h.Turn > 1) {
+			errs = append(errs, fmt.Errorf("projectile %q: homing needs a positive range and a turn in (0, 1]", name))
+		}
+		if w := cfg.Wave; w != nil && w.PeriodFrames <= 0 {
+			errs = append(errs, fmt.Errorf("projectile %q: wave period_frames must be positive", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Lookup returns the type registered under name. A nil registry has no
+// types.
+func (r *Registry) Lookup(name string) (*Type, bool) {
+	if r == nil {
+		return nil, false
+	}
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// Names returns the registered type names in sorted order.
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,97 @@
+package projectile
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadRegistry(t *testing.T) {
+	var sprite bytes.Buffer
+	if err := png.Encode(&sprite, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{
+		"projectiles.json": {Data: []byte(`{
+			"bullet": {"width": 2, "height": 1},
+			"grenade": {"sprite": "grenade.png", "width": 4, "height": 4, "gravity": 2, "bounces": 2},
+			"orb": {"width": 3, "height": 3, "homing": {"range": 64, "turn": 0.1}},
+			"lance": {"width": 6, "height": 2, "pierce": 3, "wave": {"amplitude": 4, "period_frames": 20}}
+		}`)},
+		"grenade.png": {Data: sprite.Bytes()},
+	}
+
+	r, err := LoadRegistry(fsys, "projectiles.json")
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+	if got := strings.Join(r.Names(), ","); got != "bullet,grenade,lance,orb" {
+		t.Errorf("Names = %s", got)
+	}
+	grenade, ok := r.Lookup("grenade")
+	if !ok {
+		t.Fatal("grenade not registered")
+	}
+	if grenade.Config.Gravity != 2 || grenade.Config.Bounces != 2 || grenade.image == nil {
+		t.Errorf("grenade = %+v", grenade)
+	}
+	if orb, _ := r.Lookup("orb"); orb.Config.Homing == nil || orb.Config.Homing.Range != 64 {
+		t.Errorf("orb homing = %+v", orb.Config.Homing)
+	}
+	if lance, _ := r.Lookup("lance"); lance.Config.Pierce != 3 || lance.Config.Wave.PeriodFrames != 20 {
+		t.Errorf("lance = %+v", lance.Config)
+	}
+}
+
+func TestLoadRegistryReportsEveryProblem(t *testing.T) {
+	fsys := fstest.MapFS{
+		"projectiles.json": {Data: []byte(`{
+			"flat": {"width": 0, "height": 1},
+			"lost": {"sprite": "missing.png", "width": 1, "height": 1},
+			"dizzy": {"width": 1, "height": 1, "homing": {"range": 10, "turn": 2}},
+			"still": {"width": 1, "height": 1, "wave": {"amplitude": 3}}
+		}`)},
+	}
+
+	_, err := LoadRegistry(fsys, "projectiles.json")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{`"flat"`, `"lost"`, `"dizzy"`, `"still"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestManager_SpawnProjectileUsesRegistry(t *testing.T) {
+	mgr := NewManager(&mockBodiesSpace{})
+	mgr.SetRegistry(NewRegistry(Type{
+		Name:   "grenade",
+		Config: ProjectileConfig{Width: 4, Height: 3, Damage: 2, Gravity: 2, Bounces: 1},
+	}))
+
+	mgr.SpawnProjectile("grenade", 0, 0, 16, 0, 0, nil)
+	mgr.SpawnProjectile("grenade", 0, 0, 16, 0, 5, nil)
+	mgr.SpawnProjectile("unknown", 0, 0, 16, 0, 1, nil)
+
+	if len(mgr.projectiles) != 3 {
+		t.Fatalf("expected 3 projectiles, got %d", len(mgr.projectiles))
+	}
+	grenade := mgr.projectiles[0]
+	if s := grenade.body.GetShape(); s.Width() != 4 || s.Height() != 3 {
+		t.Errorf("grenade hitbox = %dx%d, want 4x3", s.Width(), s.Height())
+	}
+	if grenade.damage != 2 || grenade.gravity16 != 2 || grenade.bouncesLeft != 1 {
+		t.Errorf("grenade = damage %d, gravity %d, bounces %d", grenade.damage, grenade.gravity16, grenade.bouncesLeft)
+	}
+	if mgr.projectiles[1].damage != 5 {
+		t.Errorf("weapon damage should override the type's, got %d", mgr.projectiles[1].damage)
+	}
+	if s := mgr.projectiles[2].body.GetShape(); s.Width() != 2 || s.Height() != 1 {
+		t.Errorf("unknown type should fall back to a 2x1 bullet, got %dx%d", s.Width(), s.Height())
+	}
+}
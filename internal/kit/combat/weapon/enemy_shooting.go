@@ -1,6 +1,8 @@
 package weapon
 
 import (
+	"image"
+
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
@@ -27,6 +29,7 @@ type EnemyShooting struct {
 	direction  body.ShootDirection
 	shootState actors.ActorStateEnum
 	hasState   bool
+	sight      body.BodiesSpace
 }
 
 // NewEnemyShooting constructs an EnemyShooting.
@@ -61,6 +64,39 @@ func (e *EnemyShooting) ShootState() (interface{}, bool) {
 	return e.shootState, e.hasState
 }
 
+// SetLineOfSight makes OnSight mode also require that no obstructive body in
+// space lies between the owner and the target. Passing nil disables the check.
+func (e *EnemyShooting) SetLineOfSight(space body.BodiesSpace) {
+	e.sight = space
+}
+
+// hasLineOfSight raycasts between the owner's and the target's centers.
+func (e *EnemyShooting) hasLineOfSight() bool {
+	from := center16(e.owner)
+	to := center16(e.target)
+	filter := body.QueryFilter{ObstructiveOnly: true}
+	if self, ok := e.owner.(body.Collidable); ok {
+		filter.Self = self
+	}
+	hit, blocked := e.sight.Raycast(from, to, filter)
+	if blocked {
+		if tb, ok := e.target.(body.Collidable); ok && hit.Body.ID() == tb.ID() {
+			return true
+		}
+	}
+	return !blocked
+}
+
+// center16 returns the FP16 center of b, or its position when it has no shape.
+func center16(b combat.TargetBody) image.Point {
+	x16, y16 := b.GetPosition16()
+	if sb, ok := b.(interface{ GetShape() body.Shape }); ok && sb.GetShape() != nil {
+		x16 += sb.GetShape().Width() * 8
+		y16 += sb.GetShape().Height() * 8
+	}
+	return image.Pt(x16, y16)
+}
+
 // TryFire runs the gate chain. Returns true if a projectile was actually spawned.
 func (e *EnemyShooting) TryFire() bool {
 	// Gate 1: state gate
@@ -84,6 +120,9 @@ func (e *EnemyShooting) TryFire() bool {
 		if e.rangePx > 0 && dx > e.rangePx {
 			return false
 		}
+		if e.sight != nil && !e.hasLineOfSight() {
+			return false
+		}
 		// Set face direction toward target
 		if tx16 < ox16 {
 			e.owner.SetFaceDirection(animation.FaceDirectionLeft)
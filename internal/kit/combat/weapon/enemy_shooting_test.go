@@ -8,6 +8,8 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
 	"github.com/boilerplate/ebiten-template/internal/kit/combat/weapon"
 )
 
@@ -374,6 +376,43 @@ func TestEnemyShooting_OnSight_FiresAfterCooldown(t *testing.T) {
 	}
 }
 
+func TestEnemyShooting_OnSight_LineOfSight(t *testing.T) {
+	owner := newFakeOwner(100, 100)
+	target := newFakeOwner(140, 100)
+
+	var calls []recordedSpawn
+	w := weapon.NewProjectileWeapon("enemy_w", 0, "bullet", 100, newRecordingManager(&calls), "", 0, 0)
+	w.SetOwner(owner)
+
+	sp := space.NewSpace()
+	shooter := weapon.NewEnemyShooting(owner, w, 160, combat.ShootModeOnSight, body.ShootDirectionStraight, 0, false)
+	shooter.SetTarget(target)
+	shooter.SetLineOfSight(sp)
+
+	if !shooter.TryFire() {
+		t.Fatal("expected a clear line of sight to fire")
+	}
+
+	wall := bodyphysics.NewObstacleRect(bodyphysics.NewRect(124, 90, 8, 40))
+	wall.SetPosition(124, 90)
+	wall.SetID("wall")
+	wall.AddCollisionBodies()
+	wall.SetIsObstructive(true)
+	sp.AddBody(wall)
+
+	if shooter.TryFire() {
+		t.Error("expected a wall between owner and target to block firing")
+	}
+
+	sp.RemoveBody(wall)
+	if !shooter.TryFire() {
+		t.Error("expected firing to resume once the wall is gone")
+	}
+	if len(calls) != 2 {
+		t.Errorf("expected 2 spawns, got %d", len(calls))
+	}
+}
+
 // ------------------------------------------------------------------
 // §8.2 Direction axis mapping
 // ------------------------------------------------------------------
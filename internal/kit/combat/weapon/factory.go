@@ -6,6 +6,7 @@ import (
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
+	kitcombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
 )
 
 // NewWeaponFromJSON creates a Weapon from JSON configuration.
@@ -69,6 +70,8 @@ func parseMeleeWeapon(data []byte) (*MeleeWeapon, error) {
 				OffsetX int `json:"offset_x"`
 				OffsetY int `json:"offset_y"`
 			} `json:"hitbox"`
+			GuardBreak bool `json:"guard_break"`
+			kitcombat.Attack
 		} `json:"combo_steps"`
 	}
 	if err := json.Unmarshal(data, &config); err != nil {
@@ -103,6 +106,8 @@ func parseMeleeWeapon(data []byte) (*MeleeWeapon, error) {
 			HitboxH16:       fp16.To16(cs.Hitbox.Height),
 			HitboxOffsetX16: fp16.To16(cs.Hitbox.OffsetX),
 			HitboxOffsetY16: fp16.To16(cs.Hitbox.OffsetY),
+			Attack:          cs.Attack,
+			GuardBreak:      cs.GuardBreak,
 		}
 	}
 
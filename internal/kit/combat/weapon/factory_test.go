@@ -68,7 +68,7 @@ const comboOKJSON = `{
 	"combo_steps": [
 		{ "damage": 1, "active_frames": [4, 10], "hitbox": { "width": 24, "height": 16, "offset_x": 12, "offset_y": 0 } },
 		{ "damage": 1, "active_frames": [3, 8],  "hitbox": { "width": 28, "height": 16, "offset_x": 14, "offset_y": -4 } },
-		{ "damage": 2, "active_frames": [5, 12], "hitbox": { "width": 32, "height": 20, "offset_x": 16, "offset_y": 0 } }
+		{ "damage": 2, "active_frames": [5, 12], "hitbox": { "width": 32, "height": 20, "offset_x": 16, "offset_y": 0 }, "guard_break": true, "launch": 48, "ground_bounce": true }
 	]
 }`
 
@@ -98,6 +98,12 @@ func TestWeaponFactory_MeleeJSON(t *testing.T) {
 				if steps[2].Damage != 2 {
 					t.Errorf("Steps()[2].Damage = %d, want 2", steps[2].Damage)
 				}
+				if steps[1].GuardBreak || !steps[2].GuardBreak {
+					t.Errorf("GuardBreak = %v/%v, want only the finisher", steps[1].GuardBreak, steps[2].GuardBreak)
+				}
+				if a := steps[2].Attack; a.Launch != 48 || !a.GroundBounce {
+					t.Errorf("finisher Launch = %d, GroundBounce = %v, want 48, true", a.Launch, a.GroundBounce)
+				}
 				if steps[1].HitboxOffsetY16 != fp16.To16(-4) {
 					t.Errorf("Steps()[1].HitboxOffsetY16 = %d, want %d (fp16 of -4)", steps[1].HitboxOffsetY16, fp16.To16(-4))
 				}
@@ -7,6 +7,7 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils"
+	kitcombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
 )
 
 // ComboStep defines the per-step hitbox and damage for a melee combo chain.
@@ -18,6 +19,8 @@ type ComboStep struct {
 	HitboxH16       int
 	HitboxOffsetX16 int
 	HitboxOffsetY16 int
+	Attack          kitcombat.Attack // damage type, knockback, hit-stun, i-frames and crits
+	GuardBreak      bool             // breaks the target's guard instead of being blocked
 }
 
 // MeleeWeapon is a close-range swing weapon that activates a hitbox during a
@@ -208,10 +211,22 @@ func (w *MeleeWeapon) ApplyHitbox(space body.BodiesSpace) {
 		return
 	}
 
-	rect := w.HitboxRect()
-	hits := space.Query(rect)
+	w.hitTargets(space, w.HitboxRect())
+}
 
-	for _, b := range hits {
+// ApplyHitboxRects applies the current step's damage to targets in rects, with
+// the same faction gate and single hit per swing as ApplyHitbox. It is used for
+// hitboxes authored per animation frame, which replace the step's hitbox and
+// active frames, so it does not check IsHitboxActive.
+func (w *MeleeWeapon) ApplyHitboxRects(space body.BodiesSpace, rects []image.Rectangle) {
+	for _, rect := range rects {
+		w.hitTargets(space, rect)
+	}
+}
+
+// hitTargets damages every eligible target overlapping rect.
+func (w *MeleeWeapon) hitTargets(space body.BodiesSpace, rect image.Rectangle) {
+	for _, b := range space.Query(rect) {
 		if w.owner != nil && b == w.owner {
 			continue
 		}
@@ -233,7 +248,14 @@ func (w *MeleeWeapon) ApplyHitbox(space body.BodiesSpace) {
 			continue
 		}
 		w.hitThisSwing[target] = struct{}{}
-		target.TakeDamage(w.steps[w.stepIndex].Damage)
+		dirX := 1
+		if w.faceDir == animation.FaceDirectionLeft {
+			dirX = -1
+		}
+		step := w.steps[w.stepIndex]
+		info := step.Attack.Hit(step.Damage, w.owner, dirX, 0)
+		info.GuardBreak = step.GuardBreak
+		target.TakeDamage(info)
 	}
 }
 
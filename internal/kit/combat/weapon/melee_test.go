@@ -8,6 +8,7 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/tilemaplayer"
+	kitcombat "github.com/boilerplate/ebiten-template/internal/kit/combat"
 	"github.com/boilerplate/ebiten-template/internal/kit/combat/weapon"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -23,6 +24,7 @@ type meleeTarget struct {
 	w, h        int
 	faction     combat.Faction
 	damageCalls []int
+	lastHit     combat.DamageInfo
 }
 
 func newMeleeTarget(id string, xPx, yPx, w, h int, f combat.Faction) *meleeTarget {
@@ -31,7 +33,10 @@ func newMeleeTarget(id string, xPx, yPx, w, h int, f combat.Faction) *meleeTarge
 	}
 }
 
-func (t *meleeTarget) TakeDamage(amount int)   { t.damageCalls = append(t.damageCalls, amount) }
+func (t *meleeTarget) TakeDamage(info combat.DamageInfo) {
+	t.damageCalls = append(t.damageCalls, info.Amount)
+	t.lastHit = info
+}
 func (t *meleeTarget) Faction() combat.Faction { return t.faction }
 
 // body.Collidable surface (minimal, only what ApplyHitbox needs via Query results).
@@ -112,6 +117,12 @@ func (s *fakeSpace) Query(rect image.Rectangle) []body.Collidable {
 	}
 	return hits
 }
+func (s *fakeSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (s *fakeSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
 
 // ---------------------------------------------------------------------------
 // Helpers for US-041 combo-aware weapon construction.
@@ -547,3 +558,58 @@ func (m *meleeTarget) Altitude() int           { return 0 }
 func (m *meleeTarget) SetAltitude(alt int)     {}
 func (m *meleeTarget) Altitude16() int         { return 0 }
 func (m *meleeTarget) SetAltitude16(alt16 int) {}
+
+func TestMeleeWeapon_ApplyHitbox_PassesAttackAlongFacing(t *testing.T) {
+	owner := newMeleeOwner(100, 100, combat.FactionPlayer, animation.FaceDirectionLeft)
+	steps := []weapon.ComboStep{{
+		Damage:          2,
+		ActiveFrames:    [2]int{0, 5},
+		HitboxW16:       24 * 16,
+		HitboxH16:       16 * 16,
+		HitboxOffsetX16: 12 * 16,
+		Attack:          kitcombat.Attack{DamageType: combat.DamageFire, Knockback: 32, KnockbackUp: 16, HitStunFrames: 20, IFrames: 6},
+	}}
+	w := weapon.NewMeleeWeapon("player_melee", 20, 0, steps)
+	w.SetOwner(owner)
+	target := newMeleeTarget("left", 88, 100, 8, 8, combat.FactionEnemy)
+	space := &fakeSpace{}
+	space.AddBody(target)
+
+	w.Fire(owner.x16, owner.y16, owner.face, body.ShootDirectionStraight, 0)
+	w.ApplyHitbox(space)
+
+	got := target.lastHit
+	want := combat.DamageInfo{
+		Amount: 2, Type: combat.DamageFire, Source: owner,
+		DirectionX: -1, KnockbackX16: -32, KnockbackY16: -16,
+		HitStunFrames: 20, IFrames: 6,
+	}
+	if got != want {
+		t.Errorf("hit = %+v, want %+v", got, want)
+	}
+}
+
+// TestMeleeWeapon_ApplyHitboxRects_HitsOncePerSwing verifies frame-authored
+// hitboxes hit targets outside the step's hitbox, once per swing.
+func TestMeleeWeapon_ApplyHitboxRects_HitsOncePerSwing(t *testing.T) {
+	owner := newMeleeOwner(100, 100, combat.FactionPlayer, animation.FaceDirectionRight)
+	steps := []weapon.ComboStep{{Damage: 3, ActiveFrames: [2]int{0, 5}, HitboxW16: 8 * 16, HitboxH16: 8 * 16}}
+	w := weapon.NewMeleeWeapon("player_melee", 20, 0, steps)
+	w.SetOwner(owner)
+	target := newMeleeTarget("far", 140, 100, 8, 8, combat.FactionEnemy)
+	space := &fakeSpace{}
+	space.AddBody(target)
+
+	w.Fire(owner.x16, owner.y16, owner.face, body.ShootDirectionStraight, 0)
+	w.ApplyHitbox(space)
+	if len(target.damageCalls) != 0 {
+		t.Fatalf("step hitbox reached the target: %v", target.damageCalls)
+	}
+
+	rects := []image.Rectangle{image.Rect(136, 96, 150, 110), image.Rect(138, 98, 146, 106)}
+	w.ApplyHitboxRects(space, rects)
+	w.ApplyHitboxRects(space, rects)
+	if len(target.damageCalls) != 1 || target.damageCalls[0] != 3 {
+		t.Errorf("damage calls = %v, want [3]", target.damageCalls)
+	}
+}
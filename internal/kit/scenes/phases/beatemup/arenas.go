@@ -0,0 +1,102 @@
+package beatemupphasescene
+
+import (
+	"image"
+
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
+	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
+	"github.com/boilerplate/ebiten-template/internal/engine/render/tilemap"
+	"github.com/boilerplate/ebiten-template/internal/engine/sequences"
+)
+
+// Event types published, with the arena ID as "id" in the payload, when an
+// arena locks the camera and when its enemies are beaten. Sequences can
+// wait_for_event on them.
+const (
+	ArenaLockedEvent  = "arena_locked"
+	ArenaClearedEvent = "arena_cleared"
+)
+
+// SetArenas sets the arenas of the phase, replacing any previous ones. The
+// full scene loads them from the tilemap's "Arenas" layer on start.
+func (s *BeatemupPhaseScene) SetArenas(arenas []*tilemap.Arena) {
+	s.arenas = arenas
+	s.arena = nil
+	s.clearedArenas = make(map[string]bool)
+}
+
+// ActiveArena returns the arena holding the camera, or nil.
+func (s *BeatemupPhaseScene) ActiveArena() *tilemap.Arena {
+	return s.arena
+}
+
+// updateArenas locks the camera when the player walks into an arena that
+// hasn't been cleared, and unlocks it once its sequence has finished and no
+// living enemy is left inside.
+func (s *BeatemupPhaseScene) updateArenas() {
+	if s.arena != nil {
+		if s.arenaSequencePlaying() || s.enemiesIn(s.arena.Area) {
+			return
+		}
+		s.clearedArenas[s.arena.ID] = true
+		s.camera.Unlock()
+		s.publishArenaEvent(ArenaClearedEvent, s.arena)
+		s.arena = nil
+		return
+	}
+	if !s.hasPlayer || s.player == nil {
+		return
+	}
+	pos := s.player.Position()
+	center := pos.Min.Add(pos.Size().Div(2))
+	for _, a := range s.arenas {
+		if !s.clearedArenas[a.ID] && center.In(a.Area) {
+			s.lockArena(a)
+			return
+		}
+	}
+}
+
+func (s *BeatemupPhaseScene) lockArena(a *tilemap.Arena) {
+	s.arena = a
+	s.camera.Lock(a.Area)
+	if a.Sequence != "" && s.appCtx != nil {
+		if s.sequencePlayer == nil {
+			s.sequencePlayer = sequences.NewSequencePlayer(s.appCtx)
+		}
+		s.sequencePlayer.PlaySequence(a.Sequence)
+	}
+	s.publishArenaEvent(ArenaLockedEvent, a)
+}
+
+func (s *BeatemupPhaseScene) arenaSequencePlaying() bool {
+	return s.arena.Sequence != "" && s.sequencePlayer != nil && s.sequencePlayer.IsPlaying()
+}
+
+// enemiesIn reports whether a living enemy overlaps area.
+func (s *BeatemupPhaseScene) enemiesIn(area image.Rectangle) bool {
+	for _, b := range s.space.Bodies() {
+		f, ok := b.(contractscombat.Factioned)
+		if !ok || f.Faction() != contractscombat.FactionEnemy {
+			continue
+		}
+		if sb, ok := b.(statefulBody); ok && (sb.State() == actors.Dying || sb.State() == actors.Dead) {
+			continue
+		}
+		if b.Position().Overlaps(area) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *BeatemupPhaseScene) publishArenaEvent(eventType string, a *tilemap.Arena) {
+	if s.appCtx == nil || s.appCtx.EventManager == nil {
+		return
+	}
+	s.appCtx.EventManager.Publish(event.GenericEvent{
+		EventType: eventType,
+		Payload:   map[string]interface{}{"id": a.ID},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	"github.com/boilerplate/ebiten-template/internal/engine/audio"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/navigation"
 	sequencestypes "github.com/boilerplate/ebiten-template/internal/engine/contracts/sequences"
@@ -22,16 +23,19 @@ import (
 	enginecamera "github.com/boilerplate/ebiten-template/internal/engine/render/camera"
 	"github.com/boilerplate/ebiten-template/internal/engine/render/draworder"
 	"github.com/boilerplate/ebiten-template/internal/engine/render/screenutil"
+	"github.com/boilerplate/ebiten-template/internal/engine/render/tilemap"
 	"github.com/boilerplate/ebiten-template/internal/engine/scene"
 	"github.com/boilerplate/ebiten-template/internal/engine/scene/pause"
 	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
 	"github.com/boilerplate/ebiten-template/internal/engine/scene/transition"
 	"github.com/boilerplate/ebiten-template/internal/engine/sequences"
+	"github.com/boilerplate/ebiten-template/internal/engine/ui/hud"
 	"github.com/boilerplate/ebiten-template/internal/engine/ui/menu"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
 	beatemupkit "github.com/boilerplate/ebiten-template/internal/kit/actors/beatemup"
 	"github.com/boilerplate/ebiten-template/internal/kit/render/shadow"
+	phaseskit "github.com/boilerplate/ebiten-template/internal/kit/scenes/phases"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
@@ -102,8 +106,10 @@ type BeatemupPhaseScene struct {
 
 	// Full-loop fields (nil when created via NewForTest)
 	tilemapScene      *scene.TilemapScene
+	events            *event.Group
 	appCtx            *app.AppContext
 	goal              phases.Goal
+	goalHUD           *hud.GoalProgress
 	sequencePlayer    sequencestypes.Player
 	allowPause        bool
 	pauseScreen       *pause.PauseScreen
@@ -115,6 +121,12 @@ type BeatemupPhaseScene struct {
 	playerFactory     func(*app.AppContext) (Player, error)
 	initActors        func(*scene.TilemapScene)
 	count             int
+
+	// Arena lock state: the arena holding the camera, if any, and the IDs
+	// of the arenas already beaten.
+	arenas        []*tilemap.Arena
+	arena         *tilemap.Arena
+	clearedArenas map[string]bool
 }
 
 // SetAppContext implements navigation.Scene. The kit scene context is set at
@@ -221,6 +233,8 @@ func (s *BeatemupPhaseScene) fullOnStart() {
 	tilemapRect := image.Rect(0, 0, ts.GetTilemapWidth(), ts.GetTilemapHeight())
 	s.camera.SetBounds(&tilemapRect)
 
+	s.SetArenas(ts.Tilemap().GetArenas())
+
 	ts.Tilemap().CreateCollisionBodies(s.space, func(id string) body.Touchable {
 		return bodyphysics.NewTouchTrigger(func() {
 			s.endpointTrigger(id)
@@ -244,6 +258,7 @@ func (s *BeatemupPhaseScene) fullOnStart() {
 	s.buildPauseScreen()
 	s.buildSequencePlayer()
 	s.initGoal()
+	s.goalHUD = phaseskit.NewGoalHUD(s.appCtx, s.Goal)
 	s.subscribeEvents()
 }
 
@@ -252,6 +267,8 @@ func (s *BeatemupPhaseScene) buildPauseScreen() {
 	s.pauseMenu = menu.NewMenu()
 	s.pauseMenu.SetFontSize(8)
 	s.pauseMenu.AddItem("", func() { s.pauseScreen.Toggle() })
+	s.pauseMenu.AddItem("", func() { s.cycleBusVolume(audio.BusMusic) })
+	s.pauseMenu.AddItem("", func() { s.cycleBusVolume(audio.BusSFX) })
 	s.pauseMenu.AddItem("", func() {
 		s.pauseScreen.Toggle()
 		s.freezeAllActors()
@@ -263,10 +280,10 @@ func (s *BeatemupPhaseScene) buildPauseScreen() {
 		)
 	})
 	s.pauseMenu.SetOnNavigate(func() {
-		s.appCtx.AudioManager.PlaySound("assets/audio/Menu_Click.ogg")
+		s.appCtx.AudioManager.PlaySoundOnBus(audio.BusUI, "assets/audio/Menu_Click.ogg", 1)
 	})
 	s.pauseMenu.SetOnSelect(func() {
-		s.appCtx.AudioManager.PlaySound("assets/audio/Menu_Select2.ogg")
+		s.appCtx.AudioManager.PlaySoundOnBus(audio.BusUI, "assets/audio/Menu_Select2.ogg", 1)
 	})
 	s.pauseScreen.SetMenu(s.pauseMenu)
 	s.pauseScreen.SetFont(s.appCtx.Font)
@@ -297,27 +314,39 @@ func (s *BeatemupPhaseScene) buildSequencePlayer() {
 
 func (s *BeatemupPhaseScene) initGoal() {
 	phase, _ := s.appCtx.PhaseManager.GetCurrentPhase()
-	switch phase.GoalType {
-	case phases.ReactEndpointType:
-		goal := &phases.ReachEndpointGoal{}
-		goal.OnCompletion_ = func() {
-			s.freezeAllActors()
-			if s.appCtx.AudioManager != nil {
-				s.appCtx.AudioManager.FadeOutCurrentTrack(time.Second)
-			}
-			s.completionTrigger.Enable(timing.FromDuration(time.Second))
+	spec := phase.GoalSpec()
+	onComplete := func() {
+		s.freezeAllActors()
+		if s.appCtx.AudioManager != nil {
+			s.appCtx.AudioManager.FadeOutCurrentTrack(time.Second)
 		}
-		s.goal = goal
-	case phases.SequenceGoalType:
-		s.goal = &phases.SequenceGoal{
-			Player: s.sequencePlayer,
-			OnCompleteFunc: func() {
-				s.completionTrigger.Enable(timing.FromDuration(time.Second))
-			},
+		s.completionTrigger.Enable(timing.FromDuration(time.Second))
+	}
+	if spec.Type == phases.SequenceGoalType {
+		onComplete = func() {
+			s.completionTrigger.Enable(timing.FromDuration(time.Second))
 		}
-	default:
-		s.goal = &phases.NoGoal{}
 	}
+	ctx := phases.GoalContext{Sequences: s.sequencePlayer, OnComplete: onComplete}
+	if s.tilemapScene != nil {
+		ctx.Enemies = s.tilemapScene.EnemyIDs
+	}
+	s.goal = phases.BuildGoal(spec, ctx)
+	phases.SubscribeGoal(s.goal, s.appCtx.EventManager, event.InGroup(s.EventGroup()))
+}
+
+// publishActorDied tells goals that a dead actor left the space.
+func (s *BeatemupPhaseScene) publishActorDied(b body.Collidable) {
+	if s.appCtx == nil || s.appCtx.EventManager == nil {
+		return
+	}
+	x, y := b.GetPositionMin()
+	s.appCtx.EventManager.Publish(&actorevents.ActorDiedEvent{ID: b.ID(), X: float64(x), Y: float64(y)})
+}
+
+// Goal returns the goal of the phase, whose progress DrawOver shows.
+func (s *BeatemupPhaseScene) Goal() phases.Goal {
+	return s.goal
 }
 
 func (s *BeatemupPhaseScene) freezeAllActors() {
@@ -336,28 +365,44 @@ func (s *BeatemupPhaseScene) refreshPauseMenuLabels() {
 	}
 	i18n := s.appCtx.I18n
 	s.pauseMenu.UpdateItemLabel(0, i18n.T("menu.start"))
-	s.pauseMenu.UpdateItemLabel(1, i18n.T("menu.exit"))
+	s.pauseMenu.UpdateItemLabel(1, phaseskit.BusVolumeLabel(i18n.T("options.music_volume"), s.appCtx.AudioManager, audio.BusMusic))
+	s.pauseMenu.UpdateItemLabel(2, phaseskit.BusVolumeLabel(i18n.T("options.sfx_volume"), s.appCtx.AudioManager, audio.BusSFX))
+	s.pauseMenu.UpdateItemLabel(3, i18n.T("menu.exit"))
+}
+
+// cycleBusVolume steps a bus volume from the pause menu and refreshes the
+// labels.
+func (s *BeatemupPhaseScene) cycleBusVolume(bus audio.Bus) {
+	phaseskit.CycleBusVolume(s.appCtx.AudioManager, bus)
+	s.refreshPauseMenuLabels()
 }
 
 func (s *BeatemupPhaseScene) subscribeEvents() {
 	em := s.appCtx.EventManager
-	em.Subscribe(actorevents.ActorJumpedType, func(e event.Event) {
+	group := event.InGroup(s.EventGroup())
+	event.Subscribe(em, func(evt *actorevents.ActorJumpedEvent) {
 		if s.appCtx.VFX == nil {
 			return
 		}
-		if evt, ok := e.(*actorevents.ActorJumpedEvent); ok {
-			s.appCtx.VFX.SpawnJumpPuff(evt.X, evt.Y+1.0, 1)
-			s.appCtx.AudioManager.PlaySoundAtVolume("assets/audio/Menu_Select.ogg", 0.3)
-		}
-	})
-	em.Subscribe(actorevents.ActorLandedType, func(e event.Event) {
+		s.appCtx.VFX.SpawnJumpPuff(evt.X, evt.Y+1.0, 1)
+		s.appCtx.AudioManager.PlaySoundAtVolume("assets/audio/Menu_Select.ogg", 0.3)
+	}, group)
+	event.Subscribe(em, func(evt *actorevents.ActorLandedEvent) {
 		if s.appCtx.VFX == nil {
 			return
 		}
-		if evt, ok := e.(*actorevents.ActorLandedEvent); ok {
-			s.appCtx.VFX.SpawnLandingPuff(evt.X, evt.Y+1.0, 1)
-		}
-	})
+		s.appCtx.VFX.SpawnLandingPuff(evt.X, evt.Y+1.0, 1)
+	}, group)
+	phaseskit.SubscribeHitFeedback(s.appCtx, group)
+}
+
+// EventGroup returns the group holding the scene's event listeners; the
+// SceneManager clears it when the scene finishes.
+func (s *BeatemupPhaseScene) EventGroup() *event.Group {
+	if s.events == nil {
+		s.events = event.NewGroup()
+	}
+	return s.events
 }
 
 func (s *BeatemupPhaseScene) canPause() bool {
@@ -377,9 +422,11 @@ func (s *BeatemupPhaseScene) endpointTrigger(id string) {
 	case "CUTSCENE":
 		// reserved
 	default:
-		if g, ok := s.goal.(*phases.ReachEndpointGoal); ok {
-			g.Reach()
+		// The endpoint's event_id picks the exit the phase leaves by.
+		if s.goal != nil && !s.goal.IsCompleted() && s.appCtx != nil && s.appCtx.PhaseManager != nil {
+			s.appCtx.PhaseManager.ChooseExit(id)
 		}
+		phases.ReachEndpoint(s.goal)
 	}
 }
 
@@ -415,10 +462,12 @@ func (s *BeatemupPhaseScene) Update() error {
 	if s.space == nil {
 		return nil
 	}
+	s.updateArenas()
 	for _, i := range s.space.Bodies() {
 		switch b := i.(type) {
 		case statefulBody:
 			if b.State() == actors.Dead {
+				s.publishActorDied(b)
 				s.space.RemoveBody(i)
 				continue
 			}
@@ -463,6 +512,12 @@ func (s *BeatemupPhaseScene) fullUpdate() error {
 			false,
 		)
 	}
+	if s.goal != nil && !s.deathActive {
+		phases.UpdateGoal(s.goal)
+		if s.hasPlayer && phases.IsFailed(s.goal) {
+			s.startDeathSequence()
+		}
+	}
 	if s.goal != nil && s.goal.IsCompleted() && !s.completionTrigger.IsEnabled() {
 		s.goal.OnCompletion()
 	}
@@ -472,13 +527,17 @@ func (s *BeatemupPhaseScene) fullUpdate() error {
 	if s.completionTrigger.Trigger() {
 		s.appCtx.CompleteCurrentPhase(transition.NewFader(0, config.Get().FadeVisibleDuration), true)
 	}
+	s.updateArenas()
 	s.camera.Update()
 	if err := s.tilemapScene.BaseScene.Update(); err != nil {
 		return err
 	}
 	s.count++
 	space := s.space
+	active, culling := s.activeBounds()
 	for _, i := range space.Bodies() {
+		// Dead actors and removed items are cleared wherever they are; only
+		// updates are culled.
 		switch b := i.(type) {
 		case beatemupkit.BeatEmUpActorEntity:
 			if b.State() == actors.Dead {
@@ -487,9 +546,13 @@ func (s *BeatemupPhaseScene) fullUpdate() error {
 					w, h := b.GetShape().Width(), b.GetShape().Height()
 					s.appCtx.VFX.SpawnDeathExplosion(float64(x)+float64(w)/2, float64(y)+float64(h)/2, 30)
 				}
+				s.publishActorDied(b)
 				space.RemoveBody(i)
 				continue
 			}
+			if culling && !s.isActive(i, active) {
+				continue
+			}
 			if err := b.Update(space); err != nil {
 				return err
 			}
@@ -498,6 +561,9 @@ func (s *BeatemupPhaseScene) fullUpdate() error {
 				space.RemoveBody(i)
 				continue
 			}
+			if culling && !s.isActive(i, active) {
+				continue
+			}
 			if err := b.Update(space); err != nil {
 				return err
 			}
@@ -509,12 +575,32 @@ func (s *BeatemupPhaseScene) fullUpdate() error {
 		s.appCtx.ProjectileManager.Update()
 	}
 	if s.hasPlayer && s.player != nil {
+		// Locked in an arena, the player can't walk off screen.
+		s.camera.KeepInView(s.player, space)
 		space.ResolveCollisions(s.player)
 	}
 	space.ProcessRemovals()
 	return nil
 }
 
+// activeBounds returns the tilemap chunks streamed in around the camera, and
+// false when the scene has no tilemap, so nothing is culled.
+func (s *BeatemupPhaseScene) activeBounds() (image.Rectangle, bool) {
+	if s.tilemapScene == nil || s.tilemapScene.Tilemap() == nil {
+		return image.Rectangle{}, false
+	}
+	return s.tilemapScene.Tilemap().ActiveBounds(s.camera), true
+}
+
+// isActive reports whether b is simulated this frame. The player always is;
+// other bodies only while they overlap the active bounds.
+func (s *BeatemupPhaseScene) isActive(b body.Collidable, active image.Rectangle) bool {
+	if s.hasPlayer && s.player != nil && b.ID() == s.player.ID() {
+		return true
+	}
+	return b.Position().Overlaps(active)
+}
+
 // SetPlayer wires a player into the scene. Calling with non-nil sets
 // hasPlayer=true; calling with nil clears the player.
 func (s *BeatemupPhaseScene) SetPlayer(p Player) {
@@ -593,8 +679,9 @@ func (s *BeatemupPhaseScene) Draw(screen *ebiten.Image) {
 
 func (s *BeatemupPhaseScene) fullDraw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{0, 0, 0, 0xff})
-	tilemapImg, _ := s.tilemapScene.Tilemap().Image(screen)
-	s.camera.Draw(tilemapImg, s.tilemapScene.Tilemap().ImageOptions(), screen)
+	tm := s.tilemapScene.Tilemap()
+	tm.SetFrame(s.appCtx.FrameCount)
+	tm.DrawBackground(screen, s.camera)
 	space := s.space
 	shadow.DrawAll(screen, s.camera, space.Bodies())
 	for _, b := range draworder.SortByGroundYAltitude(space.Bodies()) {
@@ -628,6 +715,7 @@ func (s *BeatemupPhaseScene) fullDraw(screen *ebiten.Image) {
 		camY -= float64(config.Get().ScreenHeight) / 2
 		s.appCtx.ProjectileManager.DrawWithOffset(screen, camX, camY)
 	}
+	tm.DrawForeground(screen, s.camera)
 	if config.Get().CollisionBox {
 		if pm := s.appCtx.ProjectileManager; pm != nil {
 			pm.DrawCollisionBoxesWithOffset(func(b body.Collidable) {
@@ -654,6 +742,9 @@ func (s *BeatemupPhaseScene) fullDraw(screen *ebiten.Image) {
 }
 
 func (s *BeatemupPhaseScene) DrawOver(screen *ebiten.Image) {
+	if s.goalHUD != nil {
+		s.goalHUD.Draw(screen)
+	}
 	if s.sequencePlayer != nil {
 		s.sequencePlayer.DrawOver(screen)
 	}
@@ -806,3 +897,9 @@ func (s *testSpace) Find(id string) body.Collidable {
 	return nil
 }
 func (s *testSpace) Query(image.Rectangle) []body.Collidable { return nil }
+func (s *testSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (s *testSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
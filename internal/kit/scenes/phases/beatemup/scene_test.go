@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	contractscombat "github.com/boilerplate/ebiten-template/internal/engine/contracts/combat"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors/movement"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
+	"github.com/boilerplate/ebiten-template/internal/engine/render/tilemap"
 
 	beatemupphasescene "github.com/boilerplate/ebiten-template/internal/kit/scenes/phases/beatemup"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -268,3 +270,58 @@ func TestBeatemupPhaseScene_ShadowDrawnBeforeActor(t *testing.T) {
 		}
 	}
 }
+
+// --- arena lock --------------------------------------------------------------
+
+type mockEnemy struct {
+	*mockBeatEmUpActor
+}
+
+func (m *mockEnemy) Faction() contractscombat.Faction { return contractscombat.FactionEnemy }
+
+func TestBeatemupPhaseScene_ArenaLocksUntilEnemiesAreBeaten(t *testing.T) {
+	scene := beatemupphasescene.NewForTest(beatemupphasescene.TestOptions{
+		ScreenWidth:  320,
+		ScreenHeight: 200,
+	})
+	arena := &tilemap.Arena{ID: "docks", Area: image.Rect(320, 0, 640, 200)}
+	scene.SetArenas([]*tilemap.Arena{arena})
+
+	player := newMockBeatEmUpActor("player", 100*16, 0)
+	scene.SetPlayerForTest(player)
+	enemy := &mockEnemy{newMockBeatEmUpActor("thug", 100*16, 0)}
+	enemy.SetPosition(500, 100)
+	scene.AddBodyForTest(enemy)
+	cam := scene.EngineCameraForTest()
+
+	if err := scene.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if _, locked := cam.Locked(); locked {
+		t.Fatal("camera locked before the player reached the arena")
+	}
+
+	player.SetPosition(400, 100)
+	if err := scene.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if area, locked := cam.Locked(); !locked || area != arena.Area || scene.ActiveArena() != arena {
+		t.Fatalf("entering the arena: Locked() = %v, %v", area, locked)
+	}
+
+	enemy.state = actors.Dying
+	if err := scene.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if _, locked := cam.Locked(); locked || scene.ActiveArena() != nil {
+		t.Fatal("camera still locked after the last enemy was beaten")
+	}
+
+	// A cleared arena doesn't lock again.
+	if err := scene.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if _, locked := cam.Locked(); locked {
+		t.Error("cleared arena locked the camera again")
+	}
+}
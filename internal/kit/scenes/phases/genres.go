@@ -1,4 +1,5 @@
-// Package phaseskit defines genre constants for phase scenes.
+// Package phaseskit defines genre constants and shared pause-menu helpers
+// for phase scenes.
 // The engine declares the dumb Genre int type; this package names the values.
 package phaseskit
 
@@ -12,3 +13,12 @@ const (
 	// GenreShepherd identifies a shepherd phase (platformer + rescue-sheep goal).
 	GenreShepherd
 )
+
+// GenresByName maps the genre names phase JSON files use to their Genre.
+func GenresByName() map[string]phases.Genre {
+	return map[string]phases.Genre{
+		"platformer": GenrePlatformer,
+		"beatemup":   GenreBeatemup,
+		"shepherd":   GenreShepherd,
+	}
+}
@@ -0,0 +1,19 @@
+package phaseskit
+
+import (
+	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
+	"github.com/boilerplate/ebiten-template/internal/engine/ui/hud"
+)
+
+// GoalHUDFontSize is the font size of the goal progress HUD.
+const GoalHUDFontSize = 8
+
+// NewGoalHUD returns the element phase scenes draw over the level to show the
+// progress of the goal returned by goal, or nil when ctx has no font.
+func NewGoalHUD(ctx *app.AppContext, goal func() phases.Goal) *hud.GoalProgress {
+	if ctx == nil || ctx.Font == nil {
+		return nil
+	}
+	return hud.NewGoalProgress(goal, ctx.Font.NewFace(GoalHUDFontSize))
+}
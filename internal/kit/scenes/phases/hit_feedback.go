@@ -0,0 +1,44 @@
+package phaseskit
+
+import (
+	"strconv"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	actorevents "github.com/boilerplate/ebiten-template/internal/engine/entity/actors/events"
+	"github.com/boilerplate/ebiten-template/internal/engine/event"
+)
+
+// HitSound is the sound played for every hit that deals damage.
+const HitSound = "assets/audio/Hit_Enemy_02.ogg"
+
+// SubscribeHitFeedback reacts to ActorHitEvents on ctx.EventManager: blocked
+// and parried hits show "BLOCK" or "PARRY", hits that deal damage show the
+// amount, with a "!" for crits, and play HitSound. Missing VFX or audio
+// managers are skipped.
+func SubscribeHitFeedback(ctx *app.AppContext, opts ...event.SubscribeOption) {
+	event.Subscribe(ctx.EventManager, func(evt *actorevents.ActorHitEvent) {
+		if evt.Damage.Parried || evt.Damage.Blocked {
+			if ctx.VFX != nil {
+				msg := "BLOCK"
+				if evt.Damage.Parried {
+					msg = "PARRY"
+				}
+				ctx.VFX.SpawnFloatingText(msg, evt.X, evt.Y-4, 30)
+			}
+			return
+		}
+		if evt.Dealt <= 0 {
+			return
+		}
+		if ctx.VFX != nil {
+			msg := strconv.Itoa(evt.Dealt)
+			if evt.Damage.Crit {
+				msg += "!"
+			}
+			ctx.VFX.SpawnFloatingText(msg, evt.X, evt.Y-4, 30)
+		}
+		if ctx.AudioManager != nil {
+			ctx.AudioManager.PlaySoundAtVolume(HitSound, 0.4)
+		}
+	}, opts...)
+}
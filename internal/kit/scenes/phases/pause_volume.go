@@ -0,0 +1,28 @@
+package phaseskit
+
+import (
+	"fmt"
+
+	"github.com/boilerplate/ebiten-template/internal/engine/audio"
+)
+
+// PauseVolumeStep is how much one pause-menu press changes a bus volume.
+const PauseVolumeStep = 0.2
+
+// CycleBusVolume steps a bus volume from a pause menu, wrapping from full back
+// to silent. A nil manager is ignored.
+func CycleBusVolume(am audio.Manager, bus audio.Bus) {
+	if am == nil {
+		return
+	}
+	am.Mixer().CycleVolume(bus, PauseVolumeStep)
+}
+
+// BusVolumeLabel returns the pause-menu label for bus: name and its volume as
+// a percentage, or just name without a manager.
+func BusVolumeLabel(name string, am audio.Manager, bus audio.Bus) string {
+	if am == nil {
+		return name
+	}
+	return fmt.Sprintf("%s: %d%%", name, int(am.Mixer().Volume(bus)*100+0.5))
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
+	"github.com/boilerplate/ebiten-template/internal/engine/audio"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/navigation"
 	sequencestypes "github.com/boilerplate/ebiten-template/internal/engine/contracts/sequences"
@@ -28,10 +29,12 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/scene/phases"
 	"github.com/boilerplate/ebiten-template/internal/engine/scene/transition"
 	"github.com/boilerplate/ebiten-template/internal/engine/sequences"
+	"github.com/boilerplate/ebiten-template/internal/engine/ui/hud"
 	"github.com/boilerplate/ebiten-template/internal/engine/ui/menu"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
 	"github.com/boilerplate/ebiten-template/internal/kit/actors/platformer"
+	phaseskit "github.com/boilerplate/ebiten-template/internal/kit/scenes/phases"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
@@ -97,13 +100,17 @@ type PlatformerPhaseScene struct {
 
 	// Full-loop fields (nil when created via NewForTest)
 	tilemapScene      *scene.TilemapScene
+	events            *event.Group
 	appCtx            *app.AppContext
 	goal              phases.Goal
+	goalHUD           *hud.GoalProgress
 	sequencePlayer    sequencestypes.Player
 	allowPause        bool
 	pauseScreen       *pause.PauseScreen
 	pauseMenu         *menu.Menu
 	screenFlipper     *scene.ScreenFlipper
+	rooms             *scene.RoomGraph
+	minimap           *scene.Minimap
 	completionTrigger utils.DelayTrigger
 	deathTrigger      utils.DelayTrigger
 	rebootScene       navigation.SceneType
@@ -139,6 +146,7 @@ func newScene(
 		dyingState:   dyingState,
 		deadState:    deadState,
 		vfx:          enginevfx.NewVignette(),
+		minimap:      scene.NewMinimap(),
 	}
 }
 
@@ -189,12 +197,7 @@ func (s *PlatformerPhaseScene) fullOnStart() {
 			log.Fatal(err)
 		}
 		s.player = p
-		// Production players implement actors.ActorEntity; use type assertion.
-		if ae, ok := any(p).(actors.ActorEntity); ok {
-			s.appCtx.ActorManager.Register(ae)
-			s.appCtx.ActorManager.RegisterPrimary(ae)
-		}
-		s.space.AddBody(p)
+		s.addPlayer()
 		s.OnDeathStarted = func() {
 			if s.appCtx.VFX != nil {
 				deathX, deathY := s.player.GetPositionMin()
@@ -224,19 +227,55 @@ func (s *PlatformerPhaseScene) fullOnStart() {
 		}
 	}
 
+	s.createCollisionBodies(ts)
+	s.setupCamera(ts)
+
+	s.buildPauseScreen()
+	s.buildSequencePlayer()
+	s.initGoal()
+	s.goalHUD = phaseskit.NewGoalHUD(s.appCtx, s.Goal)
+	s.subscribeEvents()
+
+	if s.onStarted != nil {
+		s.onStarted()
+	}
+}
+
+// addPlayer adds the player to the space and the actor manager.
+func (s *PlatformerPhaseScene) addPlayer() {
+	// Production players implement actors.ActorEntity; use type assertion.
+	if ae, ok := any(s.player).(actors.ActorEntity); ok {
+		s.appCtx.ActorManager.Register(ae)
+		s.appCtx.ActorManager.RegisterPrimary(ae)
+	}
+	s.space.AddBody(s.player)
+}
+
+func (s *PlatformerPhaseScene) createCollisionBodies(ts *scene.TilemapScene) {
 	ts.Tilemap().CreateCollisionBodies(s.space, func(id string) body.Touchable {
 		return bodyphysics.NewTouchTrigger(func() {
 			s.endpointTrigger(id)
 		}, s.player)
 	})
+}
 
+// setupCamera points the camera at the player, with a screen flipper and a
+// room graph for the map's rooms and doors, or at the map's camera start.
+func (s *PlatformerPhaseScene) setupCamera(ts *scene.TilemapScene) {
+	s.screenFlipper, s.rooms = nil, nil
 	if s.hasPlayer {
 		ts.SetCameraConfig(scene.CameraConfig{Mode: scene.CameraModeFollow})
 		s.camera.SetFollowing(true)
 		s.camera.SetVerticalOnlyUpward(false)
 		s.camera.SetFollowTarget(s.player)
-		// Production players implement body.Movable.
-		if mv, ok := any(s.player).(body.Movable); ok {
+		if phase, err := s.appCtx.PhaseManager.GetCurrentPhase(); err == nil &&
+			(phase.AutoScrollX != 0 || phase.AutoScrollY != 0) {
+			// Auto-scrolling phases run through the whole map, not room by room.
+			tilemapRect := image.Rect(0, 0, ts.GetTilemapWidth(), ts.GetTilemapHeight())
+			s.camera.SetBounds(&tilemapRect)
+			s.camera.SetAutoScroll(phase.AutoScrollX, phase.AutoScrollY)
+		} else if mv, ok := any(s.player).(body.Movable); ok {
+			// Production players implement body.Movable.
 			s.screenFlipper = scene.NewScreenFlipper(s.camera, mv, ts.Tilemap(), s.appCtx)
 			s.screenFlipper.PlayerPushDistance = float64(ts.Tilemap().Tilewidth / 2)
 			s.screenFlipper.FlipStrategy = func(dx, dy int) scene.FlipType {
@@ -248,6 +287,8 @@ func (s *PlatformerPhaseScene) fullOnStart() {
 			s.screenFlipper.OnFlipStart = func() { s.player.SetImmobile(true) }
 			s.screenFlipper.OnFlipFinish = func() { s.player.SetImmobile(false) }
 			s.screenFlipper.SnapToCurrentRoom()
+			s.rooms = scene.NewRoomGraph(ts.MapPath(), s.screenFlipper, s.minimap)
+			s.rooms.OnTravel = s.travel
 		}
 	} else {
 		ts.SetCameraConfig(scene.CameraConfig{Mode: scene.CameraModeFixed})
@@ -257,15 +298,37 @@ func (s *PlatformerPhaseScene) fullOnStart() {
 			s.camera.SetPositionTopLeft(0, 0)
 		}
 	}
+}
 
-	s.buildPauseScreen()
-	s.buildSequencePlayer()
-	s.initGoal()
-	s.subscribeEvents()
-
-	if s.onStarted != nil {
-		s.onStarted()
+// travel takes the player through a door into another map of the phase.
+// The new map replaces the old one in the same scene, so the player keeps
+// their state.
+func (s *PlatformerPhaseScene) travel(t scene.Travel) {
+	ts := s.tilemapScene
+	if err := ts.LoadMap(t.Door.TargetMap); err != nil {
+		log.Printf("travel: %v", err)
+		return
+	}
+	if s.appCtx.ProjectileManager != nil {
+		s.appCtx.ProjectileManager.Clear()
+	}
+	s.addPlayer()
+	if s.initActors != nil {
+		s.initActors(ts)
+	}
+	s.createCollisionBodies(ts)
+	s.setupCamera(ts)
+	if s.rooms == nil {
+		return
 	}
+	if err := s.rooms.Arrive(t); err != nil {
+		log.Printf("travel: %v", err)
+	}
+}
+
+// Minimap returns the rooms visited so far in the phase's maps.
+func (s *PlatformerPhaseScene) Minimap() *scene.Minimap {
+	return s.minimap
 }
 
 func (s *PlatformerPhaseScene) buildPauseScreen() {
@@ -273,6 +336,8 @@ func (s *PlatformerPhaseScene) buildPauseScreen() {
 	s.pauseMenu = menu.NewMenu()
 	s.pauseMenu.SetFontSize(8)
 	s.pauseMenu.AddItem("", func() { s.pauseScreen.Toggle() })
+	s.pauseMenu.AddItem("", func() { s.cycleBusVolume(audio.BusMusic) })
+	s.pauseMenu.AddItem("", func() { s.cycleBusVolume(audio.BusSFX) })
 	s.pauseMenu.AddItem("", func() {
 		s.pauseScreen.Toggle()
 		s.freezeAllActors()
@@ -284,10 +349,10 @@ func (s *PlatformerPhaseScene) buildPauseScreen() {
 		)
 	})
 	s.pauseMenu.SetOnNavigate(func() {
-		s.appCtx.AudioManager.PlaySound("assets/audio/Menu_Click.ogg")
+		s.appCtx.AudioManager.PlaySoundOnBus(audio.BusUI, "assets/audio/Menu_Click.ogg", 1)
 	})
 	s.pauseMenu.SetOnSelect(func() {
-		s.appCtx.AudioManager.PlaySound("assets/audio/Menu_Select2.ogg")
+		s.appCtx.AudioManager.PlaySoundOnBus(audio.BusUI, "assets/audio/Menu_Select2.ogg", 1)
 	})
 	s.pauseScreen.SetMenu(s.pauseMenu)
 	s.pauseScreen.SetFont(s.appCtx.Font)
@@ -322,27 +387,39 @@ func (s *PlatformerPhaseScene) initGoal() {
 		return
 	}
 	phase, _ := s.appCtx.PhaseManager.GetCurrentPhase()
-	switch phase.GoalType {
-	case phases.ReactEndpointType:
-		goal := &phases.ReachEndpointGoal{}
-		goal.OnCompletion_ = func() {
-			s.freezeAllActors()
-			if s.appCtx.AudioManager != nil {
-				s.appCtx.AudioManager.FadeOutCurrentTrack(time.Second)
-			}
-			s.completionTrigger.Enable(timing.FromDuration(time.Second))
+	spec := phase.GoalSpec()
+	onComplete := func() {
+		s.freezeAllActors()
+		if s.appCtx.AudioManager != nil {
+			s.appCtx.AudioManager.FadeOutCurrentTrack(time.Second)
 		}
-		s.goal = goal
-	case phases.SequenceGoalType:
-		s.goal = &phases.SequenceGoal{
-			Player: s.sequencePlayer,
-			OnCompleteFunc: func() {
-				s.completionTrigger.Enable(timing.FromDuration(time.Second))
-			},
+		s.completionTrigger.Enable(timing.FromDuration(time.Second))
+	}
+	if spec.Type == phases.SequenceGoalType {
+		onComplete = func() {
+			s.completionTrigger.Enable(timing.FromDuration(time.Second))
 		}
-	default:
-		s.goal = &phases.NoGoal{}
 	}
+	ctx := phases.GoalContext{Sequences: s.sequencePlayer, OnComplete: onComplete}
+	if s.tilemapScene != nil {
+		ctx.Enemies = s.tilemapScene.EnemyIDs
+	}
+	s.goal = phases.BuildGoal(spec, ctx)
+	phases.SubscribeGoal(s.goal, s.appCtx.EventManager, event.InGroup(s.EventGroup()))
+}
+
+// publishActorDied tells goals that a dead actor left the space.
+func (s *PlatformerPhaseScene) publishActorDied(b body.Collidable) {
+	if s.appCtx == nil || s.appCtx.EventManager == nil {
+		return
+	}
+	x, y := b.GetPositionMin()
+	s.appCtx.EventManager.Publish(&actorevents.ActorDiedEvent{ID: b.ID(), X: float64(x), Y: float64(y)})
+}
+
+// Goal returns the goal of the phase, whose progress DrawOver shows.
+func (s *PlatformerPhaseScene) Goal() phases.Goal {
+	return s.goal
 }
 
 func (s *PlatformerPhaseScene) freezeAllActors() {
@@ -361,28 +438,44 @@ func (s *PlatformerPhaseScene) refreshPauseMenuLabels() {
 	}
 	i18n := s.appCtx.I18n
 	s.pauseMenu.UpdateItemLabel(0, i18n.T("menu.start"))
-	s.pauseMenu.UpdateItemLabel(1, i18n.T("menu.exit"))
+	s.pauseMenu.UpdateItemLabel(1, phaseskit.BusVolumeLabel(i18n.T("options.music_volume"), s.appCtx.AudioManager, audio.BusMusic))
+	s.pauseMenu.UpdateItemLabel(2, phaseskit.BusVolumeLabel(i18n.T("options.sfx_volume"), s.appCtx.AudioManager, audio.BusSFX))
+	s.pauseMenu.UpdateItemLabel(3, i18n.T("menu.exit"))
+}
+
+// cycleBusVolume steps a bus volume from the pause menu and refreshes the
+// labels.
+func (s *PlatformerPhaseScene) cycleBusVolume(bus audio.Bus) {
+	phaseskit.CycleBusVolume(s.appCtx.AudioManager, bus)
+	s.refreshPauseMenuLabels()
 }
 
 func (s *PlatformerPhaseScene) subscribeEvents() {
 	em := s.appCtx.EventManager
-	em.Subscribe(actorevents.ActorJumpedType, func(e event.Event) {
+	group := event.InGroup(s.EventGroup())
+	event.Subscribe(em, func(evt *actorevents.ActorJumpedEvent) {
 		if s.appCtx.VFX == nil {
 			return
 		}
-		if evt, ok := e.(*actorevents.ActorJumpedEvent); ok {
-			s.appCtx.VFX.SpawnJumpPuff(evt.X, evt.Y+1.0, 1)
-			s.appCtx.AudioManager.PlaySoundAtVolume("assets/audio/Menu_Select.ogg", 0.3)
-		}
-	})
-	em.Subscribe(actorevents.ActorLandedType, func(e event.Event) {
+		s.appCtx.VFX.SpawnJumpPuff(evt.X, evt.Y+1.0, 1)
+		s.appCtx.AudioManager.PlaySoundAtVolume("assets/audio/Menu_Select.ogg", 0.3)
+	}, group)
+	event.Subscribe(em, func(evt *actorevents.ActorLandedEvent) {
 		if s.appCtx.VFX == nil {
 			return
 		}
-		if evt, ok := e.(*actorevents.ActorLandedEvent); ok {
-			s.appCtx.VFX.SpawnLandingPuff(evt.X, evt.Y+1.0, 1)
-		}
-	})
+		s.appCtx.VFX.SpawnLandingPuff(evt.X, evt.Y+1.0, 1)
+	}, group)
+	phaseskit.SubscribeHitFeedback(s.appCtx, group)
+}
+
+// EventGroup returns the group holding the scene's event listeners; the
+// SceneManager clears it when the scene finishes.
+func (s *PlatformerPhaseScene) EventGroup() *event.Group {
+	if s.events == nil {
+		s.events = event.NewGroup()
+	}
+	return s.events
 }
 
 func (s *PlatformerPhaseScene) canPause() bool {
@@ -405,9 +498,11 @@ func (s *PlatformerPhaseScene) endpointTrigger(id string) {
 	case "CUTSCENE":
 		// reserved
 	default:
-		if g, ok := s.goal.(*phases.ReachEndpointGoal); ok {
-			g.Reach()
+		// The endpoint's event_id picks the exit the phase leaves by.
+		if s.goal != nil && !s.goal.IsCompleted() && s.appCtx != nil && s.appCtx.PhaseManager != nil {
+			s.appCtx.PhaseManager.ChooseExit(id)
 		}
+		phases.ReachEndpoint(s.goal)
 	}
 }
 
@@ -452,6 +547,7 @@ func (s *PlatformerPhaseScene) Update() error {
 		switch b := i.(type) {
 		case platformer.PlatformerActorEntity:
 			if b.State() == actors.Dead {
+				s.publishActorDied(b)
 				s.space.RemoveBody(i)
 				continue
 			}
@@ -462,10 +558,22 @@ func (s *PlatformerPhaseScene) Update() error {
 			continue
 		}
 	}
+	s.keepPlayerInView()
 	s.space.ProcessRemovals()
 	return nil
 }
 
+// keepPlayerInView lets the screen edge of an auto-scrolling camera push the
+// player along, and kills them when it crushes them against a wall.
+func (s *PlatformerPhaseScene) keepPlayerInView() {
+	if !s.hasPlayer || s.player == nil {
+		return
+	}
+	if s.camera.KeepInView(s.player, s.space) {
+		s.startDeathSequence()
+	}
+}
+
 func (s *PlatformerPhaseScene) fullUpdate() error {
 	if s.pauseScreen != nil && s.canPause() {
 		s.pauseScreen.Update()
@@ -488,6 +596,9 @@ func (s *PlatformerPhaseScene) fullUpdate() error {
 			return nil
 		}
 	}
+	if s.rooms != nil {
+		s.rooms.Update()
+	}
 	if s.hasPlayer && s.player != nil && !s.deathActive &&
 		(s.player.State() == s.dyingState || s.player.State() == s.deadState) {
 		s.startDeathSequence()
@@ -501,6 +612,12 @@ func (s *PlatformerPhaseScene) fullUpdate() error {
 			false,
 		)
 	}
+	if s.goal != nil && !s.deathActive {
+		phases.UpdateGoal(s.goal)
+		if s.hasPlayer && phases.IsFailed(s.goal) {
+			s.startDeathSequence()
+		}
+	}
 	if s.goal != nil && s.goal.IsCompleted() && !s.completionTrigger.IsEnabled() {
 		s.goal.OnCompletion()
 	}
@@ -516,7 +633,10 @@ func (s *PlatformerPhaseScene) fullUpdate() error {
 	}
 	s.count++
 	space := s.space
+	active, culling := s.activeBounds()
 	for _, i := range space.Bodies() {
+		// Dead actors and removed items are cleared wherever they are; only
+		// updates are culled.
 		switch b := i.(type) {
 		case platformer.PlatformerActorEntity:
 			if b.State() == actors.Dead {
@@ -525,9 +645,13 @@ func (s *PlatformerPhaseScene) fullUpdate() error {
 					w, h := b.GetShape().Width(), b.GetShape().Height()
 					s.appCtx.VFX.SpawnDeathExplosion(float64(x)+float64(w)/2, float64(y)+float64(h)/2, 30)
 				}
+				s.publishActorDied(b)
 				space.RemoveBody(i)
 				continue
 			}
+			if culling && !s.isActive(i, active) {
+				continue
+			}
 			if err := b.Update(space); err != nil {
 				return err
 			}
@@ -536,6 +660,9 @@ func (s *PlatformerPhaseScene) fullUpdate() error {
 				space.RemoveBody(i)
 				continue
 			}
+			if culling && !s.isActive(i, active) {
+				continue
+			}
 			if err := b.Update(space); err != nil {
 				return err
 			}
@@ -547,12 +674,31 @@ func (s *PlatformerPhaseScene) fullUpdate() error {
 		s.appCtx.ProjectileManager.Update()
 	}
 	if s.hasPlayer && s.player != nil {
+		s.keepPlayerInView()
 		space.ResolveCollisions(s.player)
 	}
 	space.ProcessRemovals()
 	return nil
 }
 
+// activeBounds returns the tilemap chunks streamed in around the camera, and
+// false when the scene has no tilemap, so nothing is culled.
+func (s *PlatformerPhaseScene) activeBounds() (image.Rectangle, bool) {
+	if s.tilemapScene == nil || s.tilemapScene.Tilemap() == nil {
+		return image.Rectangle{}, false
+	}
+	return s.tilemapScene.Tilemap().ActiveBounds(s.camera), true
+}
+
+// isActive reports whether b is simulated this frame. The player always is;
+// other bodies only while they overlap the active bounds.
+func (s *PlatformerPhaseScene) isActive(b body.Collidable, active image.Rectangle) bool {
+	if s.hasPlayer && s.player != nil && b.ID() == s.player.ID() {
+		return true
+	}
+	return b.Position().Overlaps(active)
+}
+
 // SetPlayer wires a player into the scene. Calling this with a non-nil value
 // sets hasPlayer=true; calling with nil clears the player.
 func (s *PlatformerPhaseScene) SetPlayer(p Player) {
@@ -612,13 +758,17 @@ func (s *PlatformerPhaseScene) Draw(screen *ebiten.Image) {
 }
 
 func (s *PlatformerPhaseScene) DrawOver(screen *ebiten.Image) {
+	if s.goalHUD != nil {
+		s.goalHUD.Draw(screen)
+	}
 	s.sequencePlayer.DrawOver(screen)
 }
 
 func (s *PlatformerPhaseScene) fullDraw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{0, 0, 0, 0xff})
-	tilemapImg, _ := s.tilemapScene.Tilemap().Image(screen)
-	s.camera.Draw(tilemapImg, s.tilemapScene.Tilemap().ImageOptions(), screen)
+	tm := s.tilemapScene.Tilemap()
+	tm.SetFrame(s.appCtx.FrameCount)
+	tm.DrawBackground(screen, s.camera)
 	space := s.space
 	for _, b := range draworder.SortByGroundY(space.Bodies()) {
 		switch sb := b.(type) {
@@ -651,6 +801,7 @@ func (s *PlatformerPhaseScene) fullDraw(screen *ebiten.Image) {
 		camY -= float64(config.Get().ScreenHeight) / 2
 		s.appCtx.ProjectileManager.DrawWithOffset(screen, camX, camY)
 	}
+	tm.DrawForeground(screen, s.camera)
 	if config.Get().CollisionBox {
 		if pm := s.appCtx.ProjectileManager; pm != nil {
 			pm.DrawCollisionBoxesWithOffset(func(b body.Collidable) {
@@ -883,6 +1034,11 @@ func (s *PlatformerPhaseScene) ScreenFlipperForTest() interface{} {
 	return struct{}{}
 }
 
+// EngineCameraForTest returns the underlying engine camera controller.
+func (s *PlatformerPhaseScene) EngineCameraForTest() *enginecamera.Controller {
+	return s.camera
+}
+
 // CameraIsFixedModeForTest returns true when the camera is in non-follow mode.
 func (s *PlatformerPhaseScene) CameraIsFixedModeForTest() bool {
 	return !s.camera.IsFollowing()
@@ -921,3 +1077,9 @@ func (s *testSpace) Find(id string) body.Collidable {
 	return nil
 }
 func (s *testSpace) Query(image.Rectangle) []body.Collidable { return nil }
+func (s *testSpace) Raycast(image.Point, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
+func (s *testSpace) Sweep(image.Rectangle, image.Point, body.QueryFilter) (body.Hit, bool) {
+	return body.Hit{}, false
+}
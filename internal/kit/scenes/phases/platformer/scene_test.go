@@ -11,6 +11,7 @@ import (
 
 	"github.com/boilerplate/ebiten-template/internal/engine/app"
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
+	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
 	"github.com/boilerplate/ebiten-template/internal/engine/entity/actors/movement"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
@@ -220,3 +221,46 @@ func TestPlatformerPhaseScene_DebugDrawHookInvoked(t *testing.T) {
 		t.Fatalf("expected DebugDrawHook to be invoked once during Draw, got %d", count)
 	}
 }
+
+// pinnedPlayer is a player a wall stops from moving.
+type pinnedPlayer struct {
+	*mockPlatformerPlayer
+}
+
+func (m *pinnedPlayer) ApplyValidPosition(_ int, _ bool, _ body.BodiesSpace) (int, int, bool) {
+	return m.x16 / 16, m.y16 / 16, true
+}
+
+func TestPlatformerPhaseScene_AutoScrollCrushesPinnedPlayer(t *testing.T) {
+	originalConfig := config.Get()
+	t.Cleanup(func() { config.Set(originalConfig) })
+	config.Set(&config.AppConfig{ScreenWidth: 320, ScreenHeight: 200})
+
+	scene := platformerphasescene.NewForTest(platformerphasescene.TestOptions{
+		// The view spans x 100..420.
+		CameraCenterX: 260,
+		CameraCenterY: 100,
+		ScreenWidth:   320,
+		ScreenHeight:  200,
+	})
+	player := &pinnedPlayer{newMockPlatformerPlayer(80*16, 100*16)}
+	scene.SetPlayerForTest(player)
+	var fatal []actors.ActorStateEnum
+	scene.SetSetNewStateFatalRecorder(func(s actors.ActorStateEnum) { fatal = append(fatal, s) })
+
+	if err := scene.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if scene.DeathActiveForTest() {
+		t.Fatal("player died off screen with a free camera")
+	}
+
+	scene.EngineCameraForTest().SetAutoScroll(1, 0)
+	if err := scene.Update(); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if !scene.DeathActiveForTest() || len(fatal) != 1 {
+		t.Errorf("pinned behind the screen edge: death active %v, fatal calls %d; want a death",
+			scene.DeathActiveForTest(), len(fatal))
+	}
+}
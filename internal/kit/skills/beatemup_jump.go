@@ -8,13 +8,12 @@ import (
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // BeatEmUpJumpSkill drives altitude-axis jumps on *BeatEmUpMovementModel.
 type BeatEmUpJumpSkill struct {
 	skill.SkillBase
-	activationKey     ebiten.Key
+	activationAction  input.Action
 	coyoteTimeCounter int
 	jumpBufferCounter int
 	jumpCutMultiplier float64
@@ -28,7 +27,7 @@ type BeatEmUpJumpSkill struct {
 // NewBeatEmUpJumpSkill returns a ready skill with default jump-cut multiplier (1.0).
 func NewBeatEmUpJumpSkill() *BeatEmUpJumpSkill {
 	s := &BeatEmUpJumpSkill{
-		activationKey:     ebiten.KeySpace,
+		activationAction:  input.ActionJump,
 		jumpCutMultiplier: 1.0,
 	}
 	s.SetState(skill.StateReady)
@@ -46,9 +45,9 @@ func (s *BeatEmUpJumpSkill) SetJumpCutMultiplier(m float64) {
 	}
 }
 
-// ActivationKey returns the key that triggers the jump.
-func (s *BeatEmUpJumpSkill) ActivationKey() ebiten.Key {
-	return s.activationKey
+// ActivationAction returns the action that triggers the jump.
+func (s *BeatEmUpJumpSkill) ActivationAction() input.Action {
+	return s.activationAction
 }
 
 // HandleInput processes leading/trailing-edge jump input on the altitude axis.
@@ -11,7 +11,6 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // --- OffsetToggler ---
@@ -32,12 +31,12 @@ func TestOffsetToggler_NewAndNext(t *testing.T) {
 	}
 }
 
-// --- ShootingSkill.ActivationKey ---
+// --- ShootingSkill.ActivationAction ---
 
-func TestShootingSkill_ActivationKey(t *testing.T) {
+func TestShootingSkill_ActivationAction(t *testing.T) {
 	s := NewShootingSkill(nil)
-	if s.ActivationKey() != ebiten.KeyX {
-		t.Errorf("expected KeyX, got %v", s.ActivationKey())
+	if s.ActivationAction() != input.ActionShoot {
+		t.Errorf("expected shoot action, got %v", s.ActivationAction())
 	}
 }
 
@@ -106,7 +105,7 @@ func TestDashSkill_HandleInput_ReleaseClearsDashPressed(t *testing.T) {
 	}
 }
 
-// --- HorizontalMovementSkill.Update and ActivationKey ---
+// --- HorizontalMovementSkill.Update and ActivationAction ---
 
 func TestHorizontalMovementSkill_Update(t *testing.T) {
 	cfg := &config.AppConfig{Physics: config.PhysicsConfig{}}
@@ -120,10 +119,10 @@ func TestHorizontalMovementSkill_Update(t *testing.T) {
 	s.Update(actor, model)
 }
 
-func TestHorizontalMovementSkill_ActivationKey(t *testing.T) {
+func TestHorizontalMovementSkill_ActivationAction(t *testing.T) {
 	s := NewHorizontalMovementSkill()
-	// activationKey is zero-value; just ensure it's callable
-	_ = s.ActivationKey()
+	// activationAction is zero-value; just ensure it's callable
+	_ = s.ActivationAction()
 }
 
 // --- HorizontalMovementSkill.HandleInput: inertia=0 branch and axis-release paths ---
@@ -10,25 +10,24 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // DashSkill implements a dash and air dash ability.
 type DashSkill struct {
 	skill.SkillBase
 
-	canAirDash    bool
-	airDashUsed   bool
-	activationKey ebiten.Key
-	dashPressed   bool
+	canAirDash       bool
+	airDashUsed      bool
+	activationAction input.Action
+	dashPressed      bool
 }
 
 // NewDashSkill creates a new DashSkill with default values.
 func NewDashSkill() *DashSkill {
 	d := &DashSkill{
-		canAirDash:    true,
-		airDashUsed:   false,
-		activationKey: ebiten.KeyShift,
+		canAirDash:       true,
+		airDashUsed:      false,
+		activationAction: input.ActionDash,
 	}
 	d.SetState(skill.StateReady)
 	d.SetDuration(timing.FromDuration(200 * time.Millisecond))
@@ -37,9 +36,9 @@ func NewDashSkill() *DashSkill {
 	return d
 }
 
-// ActivationKey returns the activation key for the dash skill.
-func (d *DashSkill) ActivationKey() ebiten.Key {
-	return d.activationKey
+// ActivationAction returns the activation action for the dash skill.
+func (d *DashSkill) ActivationAction() input.Action {
+	return d.activationAction
 }
 
 // HandleInput checks for the dash activation key.
@@ -8,7 +8,6 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/input"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // EightDirectionalMovementSkill drives a body in 8 directions on the X/Y
@@ -16,8 +15,8 @@ import (
 // top-down packages. Y is ground-plane depth; altitude is never written.
 type EightDirectionalMovementSkill struct {
 	skill.SkillBase
-	activationKey ebiten.Key
-	wasMoving     bool
+	activationAction input.Action
+	wasMoving        bool
 }
 
 func NewEightDirectionalMovementSkill() *EightDirectionalMovementSkill {
@@ -30,8 +29,8 @@ func (s *EightDirectionalMovementSkill) Update(b body.MovableCollidable, model p
 	s.SkillBase.Update(b, model)
 }
 
-func (s *EightDirectionalMovementSkill) ActivationKey() ebiten.Key {
-	return s.activationKey
+func (s *EightDirectionalMovementSkill) ActivationAction() input.Action {
+	return s.activationAction
 }
 
 func (s *EightDirectionalMovementSkill) HandleInput(b body.MovableCollidable, model physicsmovement.MovementModel, _ body.BodiesSpace) {
@@ -7,7 +7,6 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // mockEightDirBody wraps mockMovableCollidable to record OnMove* calls
@@ -221,9 +220,9 @@ func TestEightDirectionalMovementSkill_Update_NoOp(t *testing.T) {
 	}
 }
 
-func TestEightDirectionalMovementSkill_ActivationKey(t *testing.T) {
+func TestEightDirectionalMovementSkill_ActivationAction(t *testing.T) {
 	s := NewEightDirectionalMovementSkill()
-	if s.ActivationKey() != ebiten.Key(0) {
-		t.Errorf("expected zero ebiten.Key; got %v", s.ActivationKey())
+	if s.ActivationAction() != "" {
+		t.Errorf("expected empty action; got %q", s.ActivationAction())
 	}
 }
@@ -7,13 +7,13 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
+	"github.com/boilerplate/ebiten-template/internal/engine/input"
 	bodyphysics "github.com/boilerplate/ebiten-template/internal/engine/physics/body"
 	"github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	"github.com/boilerplate/ebiten-template/internal/engine/physics/space"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/timing"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // mockMovableCollidable for skill tests
@@ -65,18 +65,18 @@ func TestDashSkill_New(t *testing.T) {
 	if d.Cooldown() <= 0 {
 		t.Error("expected positive cooldown")
 	}
-	if d.ActivationKey() != ebiten.KeyShift {
-		t.Errorf("expected activationKey Shift; got %v", d.ActivationKey())
+	if d.ActivationAction() != input.ActionDash {
+		t.Errorf("expected activationAction dash; got %v", d.ActivationAction())
 	}
 	if !d.canAirDash {
 		t.Error("expected canAirDash=true")
 	}
 }
 
-func TestDashSkill_ActivationKey(t *testing.T) {
+func TestDashSkill_ActivationAction(t *testing.T) {
 	d := NewDashSkill()
-	if d.ActivationKey() != ebiten.KeyShift {
-		t.Error("expected Shift key")
+	if d.ActivationAction() != input.ActionDash {
+		t.Error("expected dash action")
 	}
 }
 
@@ -243,15 +243,15 @@ func TestJumpSkill_New(t *testing.T) {
 	if j.State() != skill.StateReady {
 		t.Errorf("expected state Ready; got %s", j.State())
 	}
-	if j.ActivationKey() != ebiten.KeySpace {
-		t.Errorf("expected activationKey Space; got %v", j.ActivationKey())
+	if j.ActivationAction() != input.ActionJump {
+		t.Errorf("expected activationAction jump; got %v", j.ActivationAction())
 	}
 }
 
-func TestJumpSkill_ActivationKey(t *testing.T) {
+func TestJumpSkill_ActivationAction(t *testing.T) {
 	j := NewJumpSkill()
-	if j.ActivationKey() != ebiten.KeySpace {
-		t.Error("expected Space key")
+	if j.ActivationAction() != input.ActionJump {
+		t.Error("expected jump action")
 	}
 }
 
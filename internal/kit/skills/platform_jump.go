@@ -7,13 +7,12 @@ import (
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	spacephysics "github.com/boilerplate/ebiten-template/internal/engine/physics/space"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // JumpSkill implements a platformer jump with coyote time and jump buffering.
 type JumpSkill struct {
 	skill.SkillBase
-	activationKey ebiten.Key
+	activationAction input.Action
 
 	coyoteTimeCounter int
 	jumpBufferCounter int
@@ -29,7 +28,7 @@ type JumpSkill struct {
 // NewJumpSkill creates a new JumpSkill with default values.
 func NewJumpSkill() *JumpSkill {
 	s := &JumpSkill{
-		activationKey:     ebiten.KeySpace,
+		activationAction:  input.ActionJump,
 		jumpCutMultiplier: 1.0,
 	}
 	s.SetState(skill.StateReady)
@@ -47,9 +46,9 @@ func (s *JumpSkill) SetJumpCutMultiplier(m float64) {
 	s.jumpCutMultiplier = m
 }
 
-// ActivationKey returns the key that triggers the jump.
-func (s *JumpSkill) ActivationKey() ebiten.Key {
-	return s.activationKey
+// ActivationAction returns the action that triggers the jump.
+func (s *JumpSkill) ActivationAction() input.Action {
+	return s.activationAction
 }
 
 // HandleInput checks for the jump activation key.
@@ -7,16 +7,15 @@ import (
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
 	"github.com/boilerplate/ebiten-template/internal/engine/utils/fp16"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // HorizontalMovementSkill handles left/right movement input.
 type HorizontalMovementSkill struct {
 	skill.SkillBase
-	activationKey ebiten.Key
-	axis          *input.HorizontalAxis
-	prevLeft      bool
-	prevRight     bool
+	activationAction input.Action
+	axis             *input.HorizontalAxis
+	prevLeft         bool
+	prevRight        bool
 }
 
 // NewHorizontalMovementSkill creates a new HorizontalMovementSkill.
@@ -33,9 +32,9 @@ func (s *HorizontalMovementSkill) Update(b body.MovableCollidable, model physics
 	s.SkillBase.Update(b, model)
 }
 
-// ActivationKey returns the activation key (unused for movement).
-func (s *HorizontalMovementSkill) ActivationKey() ebiten.Key {
-	return s.activationKey
+// ActivationAction returns the activation action (unused for movement).
+func (s *HorizontalMovementSkill) ActivationAction() input.Action {
+	return s.activationAction
 }
 
 // HandleInput processes left/right movement commands.
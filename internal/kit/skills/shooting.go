@@ -7,7 +7,6 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/input"
 	physicsmovement "github.com/boilerplate/ebiten-template/internal/engine/physics/movement"
 	"github.com/boilerplate/ebiten-template/internal/engine/skill"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // ShootingSkill implements a shooting ability.
@@ -109,9 +108,9 @@ func (s *ShootingSkill) Update(b body.MovableCollidable, model physicsmovement.M
 	}
 }
 
-// ActivationKey returns the key that activates shooting.
-func (s *ShootingSkill) ActivationKey() ebiten.Key {
-	return ebiten.KeyX
+// ActivationAction returns the action that activates shooting.
+func (s *ShootingSkill) ActivationAction() input.Action {
+	return input.ActionShoot
 }
 
 func (s *ShootingSkill) detectShootDirection(b body.MovableCollidable, model physicsmovement.MovementModel, up, down, left, right bool) body.ShootDirection {
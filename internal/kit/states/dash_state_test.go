@@ -102,6 +102,13 @@ func (m *mockSpace) SetTilemapDimensionsProvider(_ tilemaplayer.TilemapDimension
 func (m *mockSpace) GetTilemapDimensionsProvider() tilemaplayer.TilemapDimensionsProvider  { return nil }
 func (m *mockSpace) Find(_ string) contractsbody.Collidable                                { return nil }
 
+func (m *mockSpace) Raycast(image.Point, image.Point, contractsbody.QueryFilter) (contractsbody.Hit, bool) {
+	return contractsbody.Hit{}, false
+}
+func (m *mockSpace) Sweep(image.Rectangle, image.Point, contractsbody.QueryFilter) (contractsbody.Hit, bool) {
+	return contractsbody.Hit{}, false
+}
+
 func defaultCfg() kitstates.DashConfig {
 	return kitstates.DashConfig{
 		Speed:          160,
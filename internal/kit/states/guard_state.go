@@ -0,0 +1,14 @@
+package kitstates
+
+import "github.com/boilerplate/ebiten-template/internal/engine/entity/actors"
+
+// State enum: part of engine public API
+//
+//nolint:gochecknoglobals
+var StateGuard actors.ActorStateEnum
+
+func init() {
+	StateGuard = actors.RegisterState("guard", func(b actors.BaseState) actors.ActorState {
+		return &actors.IdleState{BaseState: b} // the guard is driven by melee.Guard
+	})
+}
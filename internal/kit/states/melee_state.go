@@ -2,6 +2,7 @@ package kitstates
 
 import (
 	"fmt"
+	"image"
 
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/animation"
 	contractsbody "github.com/boilerplate/ebiten-template/internal/engine/contracts/body"
@@ -30,6 +31,7 @@ type meleeWeaponIface interface {
 	IsSwinging() bool
 	IsInStartup() bool
 	ApplyHitbox(space contractsbody.BodiesSpace)
+	ApplyHitboxRects(space contractsbody.BodiesSpace, rects []image.Rectangle)
 	StepIndex() int
 	ComboWindowRemaining() int
 	ResetCombo()
@@ -30,7 +30,9 @@ func newMeleeEnemy(id string, rect image.Rectangle, f combat.Faction) *meleeEnem
 	return &meleeEnemy{id: id, pos: rect, faction: f}
 }
 
-func (e *meleeEnemy) TakeDamage(amount int)                           { e.damageCalls = append(e.damageCalls, amount) }
+func (e *meleeEnemy) TakeDamage(info combat.DamageInfo) {
+	e.damageCalls = append(e.damageCalls, info.Amount)
+}
 func (e *meleeEnemy) Faction() combat.Faction                         { return e.faction }
 func (e *meleeEnemy) ID() string                                      { return e.id }
 func (e *meleeEnemy) SetID(string)                                    {}
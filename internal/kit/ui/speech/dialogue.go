@@ -7,6 +7,7 @@ import (
 	"github.com/boilerplate/ebiten-template/internal/engine/contracts/dialogue"
 	"github.com/boilerplate/ebiten-template/internal/engine/data/config"
 	"github.com/boilerplate/ebiten-template/internal/engine/input"
+	"github.com/boilerplate/ebiten-template/internal/engine/ui/menu"
 	enginespeech "github.com/boilerplate/ebiten-template/internal/engine/ui/speech"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -43,8 +44,27 @@ type Manager struct {
 	dialogueSkipEnabled   bool
 	playerAdvanceEnabled  bool
 	prevConfirm           bool
+	prevUp                bool
+	prevDown              bool
+
+	choice       *choiceState
+	choiceResult int
+	choiceMade   bool
+}
+
+// choiceState is the choice being shown. The options are typed into the
+// speech together with the prompt; the menu only tracks the selection.
+type choiceState struct {
+	prompt  string
+	options []string
+	menu    *menu.Menu
 }
 
+// choiceCursor marks the selected option. Unselected options are padded to
+// the same width so moving the cursor never changes the message length (and
+// so never restarts spelling).
+const choiceCursor = "> "
+
 type typingSoundPolicy interface {
 	TypingSoundEnabled() bool
 }
@@ -140,6 +160,7 @@ func (m *Manager) ShowMessages(lines []string, position string, speed int) {
 	if len(lines) == 0 {
 		return
 	}
+	m.choice = nil
 	s := m.GetActiveSpeech()
 	m.lines = lines
 	m.currentLine = 0
@@ -160,6 +181,78 @@ func (m *Manager) ShowMessages(lines []string, position string, speed int) {
 	m.startSpeechAudioIfNeeded()
 }
 
+// ShowChoice displays prompt followed by options. Once the text has been
+// spelled, up/down move the selection and confirm picks an option, ending the
+// dialogue; ChoiceResult then reports the picked index.
+func (m *Manager) ShowChoice(prompt string, options []string, position string, speed int) {
+	m.choiceMade = false
+	m.choiceResult = 0
+	if len(options) == 0 {
+		return
+	}
+	choice := &choiceState{prompt: prompt, options: options, menu: menu.NewMenu()}
+	for i, option := range options {
+		choice.menu.AddItem(option, func() { m.pickChoice(i) })
+	}
+	choice.menu.SetVisible(true)
+
+	m.ShowMessages([]string{""}, position, speed)
+	m.choice = choice
+	m.lines[0] = m.choiceText()
+}
+
+// ChoiceResult returns the option picked in the last ShowChoice.
+func (m *Manager) ChoiceResult() (int, bool) {
+	return m.choiceResult, m.choiceMade
+}
+
+func (m *Manager) choiceText() string {
+	var b strings.Builder
+	if m.choice.prompt != "" {
+		b.WriteString(m.choice.prompt)
+		b.WriteString("\n\n")
+	}
+	pad := strings.Repeat(" ", len(choiceCursor))
+	for i, option := range m.choice.options {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if i == m.choice.menu.SelectedIndex() {
+			b.WriteString(choiceCursor)
+		} else {
+			b.WriteString(pad)
+		}
+		b.WriteString(option)
+	}
+	return b.String()
+}
+
+func (m *Manager) updateChoice(up, down, confirm bool) {
+	switch {
+	case up:
+		m.choice.menu.NavigateUp()
+	case down:
+		m.choice.menu.NavigateDown()
+	case confirm:
+		m.choice.menu.Select()
+		return
+	default:
+		return
+	}
+	m.lines[0] = m.choiceText()
+}
+
+func (m *Manager) pickChoice(index int) {
+	m.choiceResult = index
+	m.choiceMade = true
+	m.choice = nil
+	if s := m.GetActiveSpeech(); s != nil {
+		s.Hide()
+	}
+	m.isSpeaking = false
+	m.stopSpeechAudio(true)
+}
+
 // IsSpeaking returns true if the dialogue manager is currently displaying a message.
 func (m *Manager) IsSpeaking() bool {
 	return m.isSpeaking
@@ -181,6 +274,9 @@ func (m *Manager) Stop() {
 	m.typingSoundLastCount = 0
 	m.typingSoundCooldown = 0
 	m.prevConfirm = false
+	m.prevUp = false
+	m.prevDown = false
+	m.choice = nil
 }
 
 // Update updates the dialogue state. It handles input for proceeding.
@@ -204,6 +300,10 @@ func (m *Manager) Update() error {
 	cmds := input.CommandsReader()
 	confirmJustPressed := cmds.Confirm && !m.prevConfirm
 	m.prevConfirm = cmds.Confirm
+	upJustPressed := cmds.Up && !m.prevUp
+	m.prevUp = cmds.Up
+	downJustPressed := cmds.Down && !m.prevDown
+	m.prevDown = cmds.Down
 
 	if !m.waitingForInput && !s.IsSpellingComplete() && m.shouldSkipTyping(confirmJustPressed) {
 		s.CompleteSpelling()
@@ -217,6 +317,11 @@ func (m *Manager) Update() error {
 		m.stopSpeechAudio(false)
 	}
 
+	if m.waitingForInput && m.choice != nil {
+		m.updateChoice(upJustPressed, downJustPressed, confirmJustPressed)
+		return nil
+	}
+
 	if m.waitingForInput {
 		if confirmJustPressed && m.playerAdvanceEnabled {
 			m.currentLine++
@@ -283,7 +388,7 @@ func (m *Manager) startSpeechAudioIfNeeded() {
 		return
 	}
 	m.speechAudioPlayingKey = key
-	m.audioManager.PlaySound(m.speechAudioPlayingKey)
+	m.audioManager.PlaySoundOnBus(audio.BusVoice, m.speechAudioPlayingKey, 1)
 }
 
 func (m *Manager) updateSpeechAudio() {
@@ -337,7 +442,8 @@ func (m *Manager) updateTypingSound(s enginespeech.Speech) {
 		if len(m.typingSounds) > 0 {
 			path := m.typingSounds[m.typingSoundIndex%len(m.typingSounds)]
 			if !m.audioManager.IsPlaying(path) {
-				m.audioManager.PlaySoundAtVolume(path, m.audioManager.Volume()*cfg.TypingSoundVolume)
+				// Typing blips ride the ui bus so they do not duck music.
+				m.audioManager.PlaySoundOnBus(audio.BusUI, path, cfg.TypingSoundVolume)
 			}
 			m.typingSoundIndex = (m.typingSoundIndex + 1) % len(m.typingSounds)
 		}
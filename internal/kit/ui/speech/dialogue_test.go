@@ -447,3 +447,58 @@ func TestManager_Update_SkipTyping(t *testing.T) {
 		t.Fatal("expected waitingForInput after skip-completing the line")
 	}
 }
+
+func TestManager_ShowChoice_NavigateAndPick(t *testing.T) {
+	saved := input.CommandsReader
+	t.Cleanup(func() { input.CommandsReader = saved })
+
+	var cmds input.PlayerCommands
+	input.CommandsReader = func() input.PlayerCommands { return cmds }
+
+	s := &mockSpeech{id: "test"}
+	m := NewManager(s)
+	m.SetActiveSpeech("test")
+
+	// tap presses c for one frame, then releases it for one frame.
+	tap := func(c input.PlayerCommands) {
+		t.Helper()
+		for _, frame := range []input.PlayerCommands{c, {}} {
+			cmds = frame
+			if err := m.Update(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	m.ShowChoice("Help him?", []string{"Yes", "No"}, "bottom", 0)
+	if got, want := m.getCurrentMessage(), "Help him?\n\n> Yes\n  No"; got != want {
+		t.Fatalf("message = %q, want %q", got, want)
+	}
+
+	// Confirm must not pick an option before the text is spelled.
+	tap(input.PlayerCommands{Confirm: true})
+	if _, ok := m.ChoiceResult(); ok || !m.IsSpeaking() {
+		t.Fatal("expected no choice before the text is spelled")
+	}
+
+	s.spellingComplete = true
+	tap(input.PlayerCommands{Down: true})
+	if got, want := m.getCurrentMessage(), "Help him?\n\n  Yes\n> No"; got != want {
+		t.Fatalf("message after down = %q, want %q", got, want)
+	}
+	tap(input.PlayerCommands{Down: true}) // wraps around
+	tap(input.PlayerCommands{Up: true})
+
+	tap(input.PlayerCommands{Confirm: true})
+	if got, ok := m.ChoiceResult(); !ok || got != 1 {
+		t.Fatalf("ChoiceResult = (%d, %v), want (1, true)", got, ok)
+	}
+	if m.IsSpeaking() || s.hideCalled == 0 {
+		t.Fatal("expected picking an option to end the dialogue")
+	}
+
+	m.ShowMessages([]string{"after"}, "bottom", 0)
+	if m.choice != nil {
+		t.Fatal("expected ShowMessages to clear choice mode")
+	}
+}